@@ -30,6 +30,7 @@ const ethereumAddressRegex = `^0x[a-fA-F0-9]{40}$`
 const (
 	MetricsNamespace = "op_indexer_api"
 	addressParam     = "{address:%s}"
+	tokenPairParam   = "/{l1Token:%s}/{l2Token:%s}"
 
 	// Endpoint paths
 	DocsPath        = "/docs"
@@ -37,7 +38,10 @@ const (
 	DepositsPath    = "/api/v0/deposits/"
 	WithdrawalsPath = "/api/v0/withdrawals/"
 
-	SupplyPath = "/api/v0/supply"
+	SupplyPath      = "/api/v0/supply"
+	TokenSupplyPath = "/api/v0/supply/tokens"
+
+	TokensPath = "/api/v0/tokens"
 )
 
 // Api ... Indexer API struct
@@ -47,6 +51,7 @@ type APIService struct {
 	router *chi.Mux
 
 	bv      database.BridgeTransfersView
+	tv      database.BridgedTokensView
 	dbClose func() error
 
 	metricsRegistry *prometheus.Registry
@@ -134,13 +139,14 @@ func (a *APIService) initDB(ctx context.Context, connector DBConnector) error {
 	}
 	a.dbClose = db.Closer
 	a.bv = db.BridgeTransfers
+	a.tv = db.BridgedTokens
 	return nil
 }
 
 func (a *APIService) initRouter(apiConfig config.ServerConfig) {
 	v := new(service.Validator)
 
-	svc := service.New(v, a.bv, a.log)
+	svc := service.New(v, a.bv, a.tv, a.log)
 	apiRouter := chi.NewRouter()
 	h := routes.NewRoutes(a.log, apiRouter, svc)
 
@@ -153,6 +159,13 @@ func (a *APIService) initRouter(apiConfig config.ServerConfig) {
 	apiRouter.Get(fmt.Sprintf(DepositsPath+addressParam, ethereumAddressRegex), h.L1DepositsHandler)
 	apiRouter.Get(fmt.Sprintf(WithdrawalsPath+addressParam, ethereumAddressRegex), h.L2WithdrawalsHandler)
 	apiRouter.Get(SupplyPath, h.SupplyView)
+	apiRouter.Get(TokenSupplyPath, h.TokenSupplyView)
+	apiRouter.Get(TokensPath, h.TokensHandler)
+
+	tokenPairPath := TokensPath + fmt.Sprintf(tokenPairParam, ethereumAddressRegex, ethereumAddressRegex)
+	apiRouter.Get(tokenPairPath+"/supply", h.TokenPairSupplyView)
+	apiRouter.Get(tokenPairPath+"/deposits", h.TokenDepositsHandler)
+	apiRouter.Get(tokenPairPath+"/withdrawals", h.TokenWithdrawalsHandler)
 	apiRouter.Get(DocsPath, h.DocsHandler)
 	a.router = apiRouter
 }
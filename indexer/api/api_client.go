@@ -23,6 +23,8 @@ const (
 	deposits    = "get_deposits"
 	withdrawals = "get_withdrawals"
 	sum         = "get_sum"
+	tokens      = "get_tokens"
+	tokenSum    = "get_token_sum"
 )
 
 // Option ... Provides configuration through callback injection
@@ -172,6 +174,37 @@ func (c *Client) GetSupplyAssessment() (*models.BridgeSupplyView, error) {
 	return bsv, nil
 }
 
+// GetTokens ... Returns the registry of bridged tokens
+func (c *Client) GetTokens() (*models.TokensResponse, error) {
+	resp, err := c.doRecordRequest(tokens, c.cfg.BaseURL+TokensPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var tr *models.TokensResponse
+	if err := json.Unmarshal(resp, &tr); err != nil {
+		return nil, err
+	}
+
+	return tr, nil
+}
+
+// GetTokenSupplyAssessment ... Returns an assessment of the current supply of
+// every bridged token. This includes the L1 locked & L2 minted supplies
+func (c *Client) GetTokenSupplyAssessment() (*models.TokenSupplyResponse, error) {
+	resp, err := c.doRecordRequest(tokenSum, c.cfg.BaseURL+TokenSupplyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var tsr *models.TokenSupplyResponse
+	if err := json.Unmarshal(resp, &tsr); err != nil {
+		return nil, err
+	}
+
+	return tsr, nil
+}
+
 // GetAllWithdrawalsByAddress ... Gets all withdrawals provided a L2 address
 func (c *Client) GetAllWithdrawalsByAddress(l2Address common.Address) ([]models.WithdrawalItem, error) {
 	var withdrawals []models.WithdrawalItem
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -96,6 +97,14 @@ func (mbv *MockBridgeTransfersView) L2BridgeWithdrawalsByAddress(address common.
 	}, nil
 }
 
+func (mbv *MockBridgeTransfersView) L1BridgeDepositsByTokenPair(tokenPair database.TokenPair, cursor string, limit int) (*database.L1BridgeDepositsResponse, error) {
+	return mbv.L1BridgeDepositsByAddress(common.Address{}, cursor, limit)
+}
+
+func (mbv *MockBridgeTransfersView) L2BridgeWithdrawalsByTokenPair(tokenPair database.TokenPair, cursor string, limit int) (*database.L2BridgeWithdrawalsResponse, error) {
+	return mbv.L2BridgeWithdrawalsByAddress(common.Address{}, cursor, limit)
+}
+
 func (mbv *MockBridgeTransfersView) L1TxDepositSum() (float64, error) {
 	return 69, nil
 }
@@ -103,6 +112,43 @@ func (mbv *MockBridgeTransfersView) L2BridgeWithdrawalSum(database.WithdrawFilte
 	return 420, nil
 }
 
+// MockBridgedTokensView mocks the BridgedTokensView interface
+type MockBridgedTokensView struct{}
+
+var (
+	bridgedToken = database.BridgedToken{
+		L1TokenAddress: common.HexToAddress("0x1111111111111111111111111111111111111111"),
+		L2TokenAddress: common.HexToAddress("0x2222222222222222222222222222222222222222"),
+		Name:           "Test Token",
+		Symbol:         "TEST",
+		Decimals:       18,
+		Configured:     true,
+	}
+
+	bridgedTokenSupply = database.BridgedTokenSupply{
+		BridgedToken:           bridgedToken,
+		DepositSum:             big.NewInt(100),
+		InitWithdrawalSum:      big.NewInt(30),
+		ProvenWithdrawalSum:    big.NewInt(20),
+		FinalizedWithdrawalSum: big.NewInt(10),
+	}
+)
+
+func (mtv *MockBridgedTokensView) BridgedTokens() ([]database.BridgedToken, error) {
+	return []database.BridgedToken{bridgedToken}, nil
+}
+
+func (mtv *MockBridgedTokensView) BridgedTokenSupplies() ([]database.BridgedTokenSupply, error) {
+	return []database.BridgedTokenSupply{bridgedTokenSupply}, nil
+}
+
+func (mtv *MockBridgedTokensView) BridgedTokenSupply(l1TokenAddress, l2TokenAddress common.Address) (*database.BridgedTokenSupply, error) {
+	if l1TokenAddress != bridgedToken.L1TokenAddress || l2TokenAddress != bridgedToken.L2TokenAddress {
+		return nil, nil
+	}
+	return &bridgedTokenSupply, nil
+}
+
 func TestHealthz(t *testing.T) {
 	logger := testlog.Logger(t, log.LevelInfo)
 	cfg := &Config{
@@ -184,3 +230,134 @@ func TestL2BridgeWithdrawalsByAddressHandler(t *testing.T) {
 	assert.Equal(t, resp.Items[0].Timestamp, withdrawal.Tx.Timestamp)
 
 }
+
+func TestTokensHandler(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	cfg := &Config{
+		DB:            &TestDBConnector{BridgeTransfers: &MockBridgeTransfersView{}, BridgedTokens: &MockBridgedTokensView{}},
+		HTTPServer:    apiConfig,
+		MetricsServer: metricsConfig,
+	}
+	api, err := NewApi(context.Background(), logger, cfg)
+	require.NoError(t, err)
+	request, err := http.NewRequest("GET", "http://"+api.Addr()+"/api/v0/tokens", nil)
+	assert.Nil(t, err)
+
+	responseRecorder := httptest.NewRecorder()
+	api.router.ServeHTTP(responseRecorder, request)
+
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var resp models.TokensResponse
+	err = json.Unmarshal(responseRecorder.Body.Bytes(), &resp)
+	assert.Nil(t, err)
+
+	require.Len(t, resp.Items, 1)
+	assert.Equal(t, resp.Items[0].L1TokenAddress, bridgedToken.L1TokenAddress.String())
+	assert.Equal(t, resp.Items[0].L2TokenAddress, bridgedToken.L2TokenAddress.String())
+	assert.Equal(t, resp.Items[0].Symbol, bridgedToken.Symbol)
+	assert.Equal(t, resp.Items[0].Decimals, bridgedToken.Decimals)
+	assert.True(t, resp.Items[0].Configured)
+}
+
+func TestTokenSupplyHandlers(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	cfg := &Config{
+		DB:            &TestDBConnector{BridgeTransfers: &MockBridgeTransfersView{}, BridgedTokens: &MockBridgedTokensView{}},
+		HTTPServer:    apiConfig,
+		MetricsServer: metricsConfig,
+	}
+	api, err := NewApi(context.Background(), logger, cfg)
+	require.NoError(t, err)
+
+	request, err := http.NewRequest("GET", "http://"+api.Addr()+"/api/v0/supply/tokens", nil)
+	assert.Nil(t, err)
+
+	responseRecorder := httptest.NewRecorder()
+	api.router.ServeHTTP(responseRecorder, request)
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var resp models.TokenSupplyResponse
+	err = json.Unmarshal(responseRecorder.Body.Bytes(), &resp)
+	assert.Nil(t, err)
+
+	require.Len(t, resp.Items, 1)
+	assert.Equal(t, resp.Items[0].Symbol, bridgedToken.Symbol)
+	assert.Equal(t, resp.Items[0].DepositSum, "100")
+	assert.Equal(t, resp.Items[0].InitWithdrawalSum, "30")
+	assert.Equal(t, resp.Items[0].ProvenWithdrawalSum, "20")
+	assert.Equal(t, resp.Items[0].FinalizedWithdrawalSum, "10")
+	assert.Equal(t, resp.Items[0].L1LockedSupply, "90")
+	assert.Equal(t, resp.Items[0].L2MintedSupply, "70")
+
+	// single token pair
+	url := fmt.Sprintf("http://%s/api/v0/tokens/%s/%s/supply", api.Addr(), bridgedToken.L1TokenAddress, bridgedToken.L2TokenAddress)
+	request, err = http.NewRequest("GET", url, nil)
+	assert.Nil(t, err)
+
+	responseRecorder = httptest.NewRecorder()
+	api.router.ServeHTTP(responseRecorder, request)
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var item models.TokenSupplyItem
+	err = json.Unmarshal(responseRecorder.Body.Bytes(), &item)
+	assert.Nil(t, err)
+	assert.Equal(t, item, resp.Items[0])
+
+	// unregistered token pair
+	url = fmt.Sprintf("http://%s/api/v0/tokens/%s/%s/supply", api.Addr(), bridgedToken.L2TokenAddress, bridgedToken.L1TokenAddress)
+	request, err = http.NewRequest("GET", url, nil)
+	assert.Nil(t, err)
+
+	responseRecorder = httptest.NewRecorder()
+	api.router.ServeHTTP(responseRecorder, request)
+	assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+}
+
+func TestTokenTransfersHandlers(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	cfg := &Config{
+		DB:            &TestDBConnector{BridgeTransfers: &MockBridgeTransfersView{}, BridgedTokens: &MockBridgedTokensView{}},
+		HTTPServer:    apiConfig,
+		MetricsServer: metricsConfig,
+	}
+	api, err := NewApi(context.Background(), logger, cfg)
+	require.NoError(t, err)
+
+	url := fmt.Sprintf("http://%s/api/v0/tokens/%s/%s/deposits", api.Addr(), bridgedToken.L1TokenAddress, bridgedToken.L2TokenAddress)
+	request, err := http.NewRequest("GET", url, nil)
+	assert.Nil(t, err)
+
+	responseRecorder := httptest.NewRecorder()
+	api.router.ServeHTTP(responseRecorder, request)
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var deposits models.DepositResponse
+	err = json.Unmarshal(responseRecorder.Body.Bytes(), &deposits)
+	assert.Nil(t, err)
+	require.Len(t, deposits.Items, 1)
+	assert.Equal(t, deposits.Items[0].Guid, deposit.TransactionSourceHash.String())
+
+	url = fmt.Sprintf("http://%s/api/v0/tokens/%s/%s/withdrawals", api.Addr(), bridgedToken.L1TokenAddress, bridgedToken.L2TokenAddress)
+	request, err = http.NewRequest("GET", url, nil)
+	assert.Nil(t, err)
+
+	responseRecorder = httptest.NewRecorder()
+	api.router.ServeHTTP(responseRecorder, request)
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var withdrawals models.WithdrawalResponse
+	err = json.Unmarshal(responseRecorder.Body.Bytes(), &withdrawals)
+	assert.Nil(t, err)
+	require.Len(t, withdrawals.Items, 1)
+	assert.Equal(t, withdrawals.Items[0].Guid, withdrawal.TransactionWithdrawalHash.String())
+
+	// invalid token address
+	url = fmt.Sprintf("http://%s/api/v0/tokens/%s/%s/deposits", api.Addr(), common.Address{}, bridgedToken.L2TokenAddress)
+	request, err = http.NewRequest("GET", url, nil)
+	assert.Nil(t, err)
+
+	responseRecorder = httptest.NewRecorder()
+	api.router.ServeHTTP(responseRecorder, request)
+	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+}
@@ -13,6 +13,7 @@ import (
 // DB represents the abstract DB access the API has.
 type DB struct {
 	BridgeTransfers database.BridgeTransfersView
+	BridgedTokens   database.BridgedTokensView
 	Closer          func() error
 }
 
@@ -28,17 +29,20 @@ func (cfg *DBConfigConnector) OpenDB(ctx context.Context, log log.Logger) (*DB,
 	}
 	return &DB{
 		BridgeTransfers: db.BridgeTransfers,
+		BridgedTokens:   db.BridgedTokens,
 		Closer:          db.Close,
 	}, nil
 }
 
 type TestDBConnector struct {
 	BridgeTransfers database.BridgeTransfersView
+	BridgedTokens   database.BridgedTokensView
 }
 
 func (tdb *TestDBConnector) OpenDB(ctx context.Context, log log.Logger) (*DB, error) {
 	return &DB{
 		BridgeTransfers: tdb.BridgeTransfers,
+		BridgedTokens:   tdb.BridgedTokens,
 		Closer: func() error {
 			log.Info("API service closed test DB view")
 			return nil
@@ -10,6 +10,13 @@ type QueryParams struct {
 	Cursor  string
 }
 
+type TokenQueryParams struct {
+	L1TokenAddress common.Address
+	L2TokenAddress common.Address
+	Limit          int
+	Cursor         string
+}
+
 // DepositItem ... Deposit item model for API responses
 type DepositItem struct {
 	Guid           string `json:"guid"`
@@ -60,3 +67,34 @@ type BridgeSupplyView struct {
 	ProvenWithdrawSum    float64 `json:"provenSum"`
 	FinalizedWithdrawSum float64 `json:"finalizedSum"`
 }
+
+// TokenItem ... Token registry item model for API responses
+type TokenItem struct {
+	L1TokenAddress string `json:"l1TokenAddress"`
+	L2TokenAddress string `json:"l2TokenAddress"`
+	Name           string `json:"name"`
+	Symbol         string `json:"symbol"`
+	Decimals       uint8  `json:"decimals"`
+	Configured     bool   `json:"configured"`
+}
+
+// TokensResponse ... Data model for API JSON response
+type TokensResponse struct {
+	Items []TokenItem `json:"items"`
+}
+
+// TokenSupplyItem ... Bridged supply of a token pair, denominated in the smallest unit of the token
+type TokenSupplyItem struct {
+	TokenItem
+	DepositSum             string `json:"depositSum"`
+	InitWithdrawalSum      string `json:"initWithdrawalSum"`
+	ProvenWithdrawalSum    string `json:"provenWithdrawalSum"`
+	FinalizedWithdrawalSum string `json:"finalizedWithdrawalSum"`
+	L1LockedSupply         string `json:"l1LockedSupply"`
+	L2MintedSupply         string `json:"l2MintedSupply"`
+}
+
+// TokenSupplyResponse ... Data model for API JSON response
+type TokenSupplyResponse struct {
+	Items []TokenSupplyItem `json:"items"`
+}
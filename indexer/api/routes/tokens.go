@@ -0,0 +1,114 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// TokensHandler ... Handles /api/v0/tokens GET requests
+func (h Routes) TokensHandler(w http.ResponseWriter, r *http.Request) {
+	tokens, err := h.svc.GetTokens()
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		h.logger.Error("error getting tokens", "err", err)
+		return
+	}
+
+	err = jsonResponse(w, tokens, http.StatusOK)
+	if err != nil {
+		h.logger.Error("error writing response", "err", err)
+	}
+}
+
+// TokenSupplyView ... Handles /api/v0/supply/tokens GET requests
+func (h Routes) TokenSupplyView(w http.ResponseWriter, r *http.Request) {
+	view, err := h.svc.GetTokenSupplyInfo()
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		h.logger.Error("error getting token supply info", "err", err)
+		return
+	}
+
+	err = jsonResponse(w, view, http.StatusOK)
+	if err != nil {
+		h.logger.Error("error writing response", "err", err)
+	}
+}
+
+// TokenPairSupplyView ... Handles /api/v0/tokens/{l1Token}/{l2Token}/supply GET requests
+func (h Routes) TokenPairSupplyView(w http.ResponseWriter, r *http.Request) {
+	params, err := h.svc.TokenQueryParams(chi.URLParam(r, "l1Token"), chi.URLParam(r, "l2Token"), "", "")
+	if err != nil {
+		http.Error(w, "invalid query params", http.StatusBadRequest)
+		h.logger.Error("error reading request params", "err", err.Error())
+		return
+	}
+
+	view, err := h.svc.GetTokenPairSupplyInfo(params)
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		h.logger.Error("error getting token supply info", "err", err)
+		return
+	} else if view == nil {
+		http.Error(w, "token not found", http.StatusNotFound)
+		return
+	}
+
+	err = jsonResponse(w, view, http.StatusOK)
+	if err != nil {
+		h.logger.Error("error writing response", "err", err)
+	}
+}
+
+// TokenDepositsHandler ... Handles /api/v0/tokens/{l1Token}/{l2Token}/deposits GET requests
+func (h Routes) TokenDepositsHandler(w http.ResponseWriter, r *http.Request) {
+	cursor := r.URL.Query().Get("cursor")
+	limit := r.URL.Query().Get("limit")
+
+	params, err := h.svc.TokenQueryParams(chi.URLParam(r, "l1Token"), chi.URLParam(r, "l2Token"), cursor, limit)
+	if err != nil {
+		http.Error(w, "invalid query params", http.StatusBadRequest)
+		h.logger.Error("error reading request params", "err", err.Error())
+		return
+	}
+
+	deposits, err := h.svc.GetTokenDeposits(params)
+	if err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		h.logger.Error("error fetching token deposits", "err", err.Error())
+		return
+	}
+
+	resp := h.svc.DepositResponse(deposits)
+	err = jsonResponse(w, resp, http.StatusOK)
+	if err != nil {
+		h.logger.Error("error writing response", "err", err)
+	}
+}
+
+// TokenWithdrawalsHandler ... Handles /api/v0/tokens/{l1Token}/{l2Token}/withdrawals GET requests
+func (h Routes) TokenWithdrawalsHandler(w http.ResponseWriter, r *http.Request) {
+	cursor := r.URL.Query().Get("cursor")
+	limit := r.URL.Query().Get("limit")
+
+	params, err := h.svc.TokenQueryParams(chi.URLParam(r, "l1Token"), chi.URLParam(r, "l2Token"), cursor, limit)
+	if err != nil {
+		http.Error(w, "invalid query params", http.StatusBadRequest)
+		h.logger.Error("error reading request params", "err", err.Error())
+		return
+	}
+
+	withdrawals, err := h.svc.GetTokenWithdrawals(params)
+	if err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		h.logger.Error("error fetching token withdrawals", "err", err.Error())
+		return
+	}
+
+	resp := h.svc.WithdrawResponse(withdrawals)
+	err = jsonResponse(w, resp, http.StatusOK)
+	if err != nil {
+		h.logger.Error("error writing response", "err", err)
+	}
+}
@@ -15,20 +15,29 @@ type Service interface {
 	WithdrawResponse(*database.L2BridgeWithdrawalsResponse) models.WithdrawalResponse
 	GetSupplyInfo() (*models.BridgeSupplyView, error)
 
+	GetTokens() (*models.TokensResponse, error)
+	GetTokenSupplyInfo() (*models.TokenSupplyResponse, error)
+	GetTokenPairSupplyInfo(params *models.TokenQueryParams) (*models.TokenSupplyItem, error)
+	GetTokenDeposits(params *models.TokenQueryParams) (*database.L1BridgeDepositsResponse, error)
+	GetTokenWithdrawals(params *models.TokenQueryParams) (*database.L2BridgeWithdrawalsResponse, error)
+
 	QueryParams(address, cursor, limit string) (*models.QueryParams, error)
+	TokenQueryParams(l1TokenAddress, l2TokenAddress, cursor, limit string) (*models.TokenQueryParams, error)
 }
 
 type HandlerSvc struct {
 	v      *Validator
 	db     database.BridgeTransfersView
+	tokens database.BridgedTokensView
 	logger log.Logger
 }
 
-func New(v *Validator, db database.BridgeTransfersView, l log.Logger) Service {
+func New(v *Validator, db database.BridgeTransfersView, tokens database.BridgedTokensView, l log.Logger) Service {
 	return &HandlerSvc{
 		logger: l,
 		v:      v,
 		db:     db,
+		tokens: tokens,
 	}
 }
 
@@ -59,6 +68,39 @@ func (svc *HandlerSvc) QueryParams(a, c, l string) (*models.QueryParams, error)
 
 }
 
+func (svc *HandlerSvc) TokenQueryParams(l1, l2, c, l string) (*models.TokenQueryParams, error) {
+	l1TokenAddress, err := svc.v.ParseValidateAddress(l1)
+	if err != nil {
+		svc.logger.Error("invalid l1 token address param", "param", l1, "err", err)
+		return nil, err
+	}
+
+	l2TokenAddress, err := svc.v.ParseValidateAddress(l2)
+	if err != nil {
+		svc.logger.Error("invalid l2 token address param", "param", l2, "err", err)
+		return nil, err
+	}
+
+	err = svc.v.ValidateCursor(c)
+	if err != nil {
+		svc.logger.Error("invalid cursor param", "cursor", c, "err", err)
+		return nil, err
+	}
+
+	limit, err := svc.v.ParseValidateLimit(l)
+	if err != nil {
+		svc.logger.Error("invalid query param", "cursor", c, "err", err)
+		return nil, err
+	}
+
+	return &models.TokenQueryParams{
+		L1TokenAddress: l1TokenAddress,
+		L2TokenAddress: l2TokenAddress,
+		Cursor:         c,
+		Limit:          limit,
+	}, nil
+}
+
 func (svc *HandlerSvc) GetWithdrawals(params *models.QueryParams) (*database.L2BridgeWithdrawalsResponse, error) {
 	withdrawals, err := svc.db.L2BridgeWithdrawalsByAddress(params.Address, params.Cursor, params.Limit)
 	if err != nil {
@@ -173,3 +215,96 @@ func (svc *HandlerSvc) GetSupplyInfo() (*models.BridgeSupplyView, error) {
 		FinalizedWithdrawSum: finalizedSum,
 	}, nil
 }
+
+// GetTokens ... Fetch the token registry
+func (svc *HandlerSvc) GetTokens() (*models.TokensResponse, error) {
+	tokens, err := svc.tokens.BridgedTokens()
+	if err != nil {
+		svc.logger.Error("error getting bridged tokens", "err", err)
+		return nil, err
+	}
+
+	items := make([]models.TokenItem, len(tokens))
+	for i, token := range tokens {
+		items[i] = tokenItem(token)
+	}
+
+	return &models.TokensResponse{Items: items}, nil
+}
+
+// GetTokenSupplyInfo ... Fetch the bridge supply info of every registered token
+func (svc *HandlerSvc) GetTokenSupplyInfo() (*models.TokenSupplyResponse, error) {
+	supplies, err := svc.tokens.BridgedTokenSupplies()
+	if err != nil {
+		svc.logger.Error("error getting bridged token supplies", "err", err)
+		return nil, err
+	}
+
+	items := make([]models.TokenSupplyItem, len(supplies))
+	for i := range supplies {
+		items[i] = tokenSupplyItem(&supplies[i])
+	}
+
+	return &models.TokenSupplyResponse{Items: items}, nil
+}
+
+// GetTokenPairSupplyInfo ... Fetch the bridge supply info of a token pair. Returns nil if the token pair is not registered
+func (svc *HandlerSvc) GetTokenPairSupplyInfo(params *models.TokenQueryParams) (*models.TokenSupplyItem, error) {
+	supply, err := svc.tokens.BridgedTokenSupply(params.L1TokenAddress, params.L2TokenAddress)
+	if err != nil {
+		svc.logger.Error("error getting bridged token supply", "err", err, "l1_token", params.L1TokenAddress.String(), "l2_token", params.L2TokenAddress.String())
+		return nil, err
+	} else if supply == nil {
+		return nil, nil
+	}
+
+	item := tokenSupplyItem(supply)
+	return &item, nil
+}
+
+func (svc *HandlerSvc) GetTokenDeposits(params *models.TokenQueryParams) (*database.L1BridgeDepositsResponse, error) {
+	tokenPair := database.TokenPair{LocalTokenAddress: params.L1TokenAddress, RemoteTokenAddress: params.L2TokenAddress}
+	deposits, err := svc.db.L1BridgeDepositsByTokenPair(tokenPair, params.Cursor, params.Limit)
+	if err != nil {
+		svc.logger.Error("error getting token deposits", "err", err.Error(), "l1_token", params.L1TokenAddress.String(), "l2_token", params.L2TokenAddress.String())
+		return nil, err
+	}
+
+	svc.logger.Debug("read token deposits from db", "count", len(deposits.Deposits), "l1_token", params.L1TokenAddress.String())
+	return deposits, nil
+}
+
+func (svc *HandlerSvc) GetTokenWithdrawals(params *models.TokenQueryParams) (*database.L2BridgeWithdrawalsResponse, error) {
+	tokenPair := database.TokenPair{LocalTokenAddress: params.L2TokenAddress, RemoteTokenAddress: params.L1TokenAddress}
+	withdrawals, err := svc.db.L2BridgeWithdrawalsByTokenPair(tokenPair, params.Cursor, params.Limit)
+	if err != nil {
+		svc.logger.Error("error getting token withdrawals", "err", err.Error(), "l1_token", params.L1TokenAddress.String(), "l2_token", params.L2TokenAddress.String())
+		return nil, err
+	}
+
+	svc.logger.Debug("read token withdrawals from db", "count", len(withdrawals.Withdrawals), "l2_token", params.L2TokenAddress.String())
+	return withdrawals, nil
+}
+
+func tokenItem(token database.BridgedToken) models.TokenItem {
+	return models.TokenItem{
+		L1TokenAddress: token.L1TokenAddress.String(),
+		L2TokenAddress: token.L2TokenAddress.String(),
+		Name:           token.Name,
+		Symbol:         token.Symbol,
+		Decimals:       token.Decimals,
+		Configured:     token.Configured,
+	}
+}
+
+func tokenSupplyItem(supply *database.BridgedTokenSupply) models.TokenSupplyItem {
+	return models.TokenSupplyItem{
+		TokenItem:              tokenItem(supply.BridgedToken),
+		DepositSum:             supply.DepositSum.String(),
+		InitWithdrawalSum:      supply.InitWithdrawalSum.String(),
+		ProvenWithdrawalSum:    supply.ProvenWithdrawalSum.String(),
+		FinalizedWithdrawalSum: supply.FinalizedWithdrawalSum.String(),
+		L1LockedSupply:         supply.L1LockedSupply().String(),
+		L2MintedSupply:         supply.L2MintedSupply().String(),
+	}
+}
@@ -31,7 +31,7 @@ func assertFieldsAreSet(t *testing.T, item any) {
 }
 
 func TestWithdrawalResponse(t *testing.T) {
-	svc := service.New(nil, nil, nil)
+	svc := service.New(nil, nil, nil, nil)
 	cdh := common.HexToHash("0x2")
 
 	withdraws := &database.L2BridgeWithdrawalsResponse{
@@ -64,7 +64,7 @@ func TestWithdrawalResponse(t *testing.T) {
 
 func TestDepositResponse(t *testing.T) {
 	cdh := common.HexToHash("0x2")
-	svc := service.New(nil, nil, nil)
+	svc := service.New(nil, nil, nil, nil)
 
 	deposits := &database.L1BridgeDepositsResponse{
 		Deposits: []database.L1BridgeDepositWithTransactionHashes{
@@ -119,10 +119,31 @@ func TestQueryParams(t *testing.T) {
 				require.Equal(t, "", params.Cursor)
 			},
 		},
+		{
+			name: "token pair params",
+			test: func(t *testing.T, svc service.Service) {
+				l1Token, l2Token := common.HexToAddress("0x420"), common.HexToAddress("0x421")
+				params, err := svc.TokenQueryParams(l1Token.String(), l2Token.String(), "", "10")
+				require.NoError(t, err)
+				require.NotNil(t, params)
+				require.Equal(t, l1Token, params.L1TokenAddress)
+				require.Equal(t, l2Token, params.L2TokenAddress)
+				require.Equal(t, 10, params.Limit)
+				require.Equal(t, "", params.Cursor)
+			},
+		},
+		{
+			name: "token pair params missing l2 token",
+			test: func(t *testing.T, svc service.Service) {
+				params, err := svc.TokenQueryParams(common.HexToAddress("0x420").String(), "", "", "")
+				require.Error(t, err)
+				require.Nil(t, params)
+			},
+		},
 	}
 
 	v := new(service.Validator)
-	svc := service.New(v, nil, log.New())
+	svc := service.New(v, nil, nil, log.New())
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -22,6 +22,8 @@ type Config struct {
 	DB            DBConfig     `toml:"db"`
 	HTTPServer    ServerConfig `toml:"http"`
 	MetricsServer ServerConfig `toml:"metrics"`
+
+	Tokens []TokenConfig `toml:"tokens"`
 }
 
 // L1Contracts configures deployed contracts
@@ -119,6 +121,16 @@ type ChainConfig struct {
 	ETLAllowedInactivityWindowSeconds uint `toml:"etl-allowed-inactivity-window-seconds"`
 }
 
+// TokenConfig registers an ERC20 token pair bridged via the StandardBridge, along with its metadata.
+// Token pairs that are not configured are still discovered from the bridge events, without metadata
+type TokenConfig struct {
+	L1Address common.Address `toml:"l1-address"`
+	L2Address common.Address `toml:"l2-address"`
+	Name      string         `toml:"name"`
+	Symbol    string         `toml:"symbol"`
+	Decimals  uint8          `toml:"decimals"`
+}
+
 // RPCsConfig configures the RPC urls
 type RPCsConfig struct {
 	L1RPC string `toml:"l1-rpc"`
@@ -211,6 +223,12 @@ func LoadConfig(log log.Logger, path string) (Config, error) {
 		errs = errors.Join(err, errors.New("`l2-header-buffer-size` unset"))
 	}
 
+	for _, token := range cfg.Tokens {
+		if token.L1Address == (common.Address{}) || token.L2Address == (common.Address{}) {
+			errs = errors.Join(errs, fmt.Errorf("token `%s` must set both `l1-address` and `l2-address`", token.Symbol))
+		}
+	}
+
 	log.Info("loaded chain config", "config", cfg.Chain)
 	return cfg, errs
 }
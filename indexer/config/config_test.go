@@ -321,3 +321,84 @@ func TestThrowsOnUnknownKeys(t *testing.T) {
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "unknown fields in config file")
 }
+
+func TestLoadConfigTokens(t *testing.T) {
+	tmpfile, err := os.CreateTemp("", "test_tokens.toml")
+	require.NoError(t, err)
+	defer os.Remove(tmpfile.Name())
+	defer tmpfile.Close()
+
+	testData := `
+	[chain]
+	preset = 10
+	l1-polling-interval = 1000
+	l2-polling-interval = 1005
+	l1-header-buffer-size = 100
+	l2-header-buffer-size = 105
+
+	[[tokens]]
+	l1-address = "0x1111111111111111111111111111111111111111"
+	l2-address = "0x2222222222222222222222222222222222222222"
+	name = "Test Token"
+	symbol = "TEST"
+	decimals = 18
+
+	[[tokens]]
+	l1-address = "0x3333333333333333333333333333333333333333"
+	l2-address = "0x4444444444444444444444444444444444444444"
+	symbol = "USDT"
+	decimals = 6
+	`
+
+	data := []byte(testData)
+	err = os.WriteFile(tmpfile.Name(), data, 0644)
+	require.NoError(t, err)
+	defer os.Remove(tmpfile.Name())
+
+	err = tmpfile.Close()
+	require.NoError(t, err)
+
+	logger := testlog.Logger(t, log.LevelInfo)
+	conf, err := LoadConfig(logger, tmpfile.Name())
+	require.NoError(t, err)
+
+	require.Len(t, conf.Tokens, 2)
+	require.Equal(t, common.HexToAddress("0x1111111111111111111111111111111111111111"), conf.Tokens[0].L1Address)
+	require.Equal(t, common.HexToAddress("0x2222222222222222222222222222222222222222"), conf.Tokens[0].L2Address)
+	require.Equal(t, "Test Token", conf.Tokens[0].Name)
+	require.Equal(t, "TEST", conf.Tokens[0].Symbol)
+	require.Equal(t, uint8(18), conf.Tokens[0].Decimals)
+	require.Equal(t, "USDT", conf.Tokens[1].Symbol)
+	require.Equal(t, uint8(6), conf.Tokens[1].Decimals)
+}
+
+func TestLoadConfigTokensMissingAddress(t *testing.T) {
+	tmpfile, err := os.CreateTemp("", "test_bad_tokens.toml")
+	require.NoError(t, err)
+	defer os.Remove(tmpfile.Name())
+	defer tmpfile.Close()
+
+	testData := `
+	[chain]
+	l1-polling-interval = 1000
+	l2-polling-interval = 1005
+	l1-header-buffer-size = 100
+	l2-header-buffer-size = 105
+
+	[[tokens]]
+	l1-address = "0x1111111111111111111111111111111111111111"
+	symbol = "TEST"
+	`
+
+	data := []byte(testData)
+	err = os.WriteFile(tmpfile.Name(), data, 0644)
+	require.NoError(t, err)
+	defer os.Remove(tmpfile.Name())
+
+	err = tmpfile.Close()
+	require.NoError(t, err)
+
+	logger := testlog.Logger(t, log.LevelInfo)
+	_, err = LoadConfig(logger, tmpfile.Name())
+	require.ErrorContains(t, err, "token `TEST` must set both `l1-address` and `l2-address`")
+}
@@ -65,11 +65,13 @@ type BridgeTransfersView interface {
 	L1TxDepositSum() (float64, error)
 	L1BridgeDepositWithFilter(BridgeTransfer) (*L1BridgeDeposit, error)
 	L1BridgeDepositsByAddress(common.Address, string, int) (*L1BridgeDepositsResponse, error)
+	L1BridgeDepositsByTokenPair(TokenPair, string, int) (*L1BridgeDepositsResponse, error)
 
 	L2BridgeWithdrawal(common.Hash) (*L2BridgeWithdrawal, error)
 	L2BridgeWithdrawalSum(filter WithdrawFilter) (float64, error)
 	L2BridgeWithdrawalWithFilter(BridgeTransfer) (*L2BridgeWithdrawal, error)
 	L2BridgeWithdrawalsByAddress(common.Address, string, int) (*L2BridgeWithdrawalsResponse, error)
+	L2BridgeWithdrawalsByTokenPair(TokenPair, string, int) (*L2BridgeWithdrawalsResponse, error)
 }
 
 type BridgeTransfersDB interface {
@@ -220,6 +222,51 @@ l1_bridge_deposits.timestamp, cross_domain_message_hash, local_token_address, re
 	return response, nil
 }
 
+// L1BridgeDepositsByTokenPair retrieves a list of StandardBridge deposits of the specified (L1, L2) token pair,
+// coupled with the L1/L2 transaction hashes that complete the bridge transaction.
+func (db *bridgeTransfersDB) L1BridgeDepositsByTokenPair(tokenPair TokenPair, cursor string, limit int) (*L1BridgeDepositsResponse, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be greater than 0")
+	}
+
+	depositsQuery := db.gorm.Model(&L1BridgeDeposit{})
+	depositsQuery = depositsQuery.Where(&tokenPair)
+	if cursor != "" {
+		sourceHash := common.HexToHash(cursor)
+		txDeposit := new(L1TransactionDeposit)
+		result := db.gorm.Model(&L1TransactionDeposit{}).Where(&L1TransactionDeposit{SourceHash: sourceHash}).Take(txDeposit)
+		if result.Error != nil || errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("unable to find transaction with supplied cursor source hash %s: %w", sourceHash, result.Error)
+		}
+		depositsQuery = depositsQuery.Where("l1_bridge_deposits.timestamp <= ?", txDeposit.Tx.Timestamp)
+	}
+
+	depositsQuery = depositsQuery.Joins("INNER JOIN l1_transaction_deposits ON l1_transaction_deposits.source_hash = transaction_source_hash")
+	depositsQuery = depositsQuery.Joins("INNER JOIN l1_contract_events ON l1_contract_events.guid = l1_transaction_deposits.initiated_l1_event_guid")
+	depositsQuery = depositsQuery.Select(`
+l1_bridge_deposits.from_address, l1_bridge_deposits.to_address, l1_bridge_deposits.amount, l1_bridge_deposits.data, transaction_source_hash,
+l2_transaction_hash, l1_contract_events.transaction_hash AS l1_transaction_hash, l1_contract_events.block_hash as l1_block_hash,
+l1_bridge_deposits.timestamp, cross_domain_message_hash, local_token_address, remote_token_address`)
+	depositsQuery = depositsQuery.Order("l1_bridge_deposits.timestamp DESC").Limit(limit + 1)
+
+	deposits := []L1BridgeDepositWithTransactionHashes{}
+	result := depositsQuery.Find(&deposits)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	nextCursor := ""
+	hasNextPage := false
+	if len(deposits) > limit {
+		hasNextPage = true
+		nextCursor = deposits[limit].L1BridgeDeposit.TransactionSourceHash.String()
+		deposits = deposits[:limit]
+	}
+
+	response := &L1BridgeDepositsResponse{Deposits: deposits, Cursor: nextCursor, HasNextPage: hasNextPage}
+	return response, nil
+}
+
 /**
  * Tokens Bridged (Withdrawn) from L2
  */
@@ -390,3 +437,50 @@ l2_bridge_withdrawals.timestamp, cross_domain_message_hash, local_token_address,
 	response := &L2BridgeWithdrawalsResponse{Withdrawals: withdrawals, Cursor: nextCursor, HasNextPage: hasNextPage}
 	return response, nil
 }
+
+// L2BridgeWithdrawalsByTokenPair retrieves a list of StandardBridge withdrawals of the specified (L2, L1) token pair, coupled
+// with the L1/L2 transaction hashes that complete the bridge transaction, including those of the multi-step withdrawal process
+func (db *bridgeTransfersDB) L2BridgeWithdrawalsByTokenPair(tokenPair TokenPair, cursor string, limit int) (*L2BridgeWithdrawalsResponse, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be greater than 0")
+	}
+
+	withdrawalsQuery := db.gorm.Model(&L2BridgeWithdrawal{})
+	withdrawalsQuery = withdrawalsQuery.Where(&tokenPair)
+	if cursor != "" {
+		withdrawalHash := common.HexToHash(cursor)
+		var txWithdrawal L2TransactionWithdrawal
+		result := db.gorm.Model(&L2TransactionWithdrawal{}).Where(&L2TransactionWithdrawal{WithdrawalHash: withdrawalHash}).Take(&txWithdrawal)
+		if result.Error != nil || errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("unable to find transaction with supplied cursor withdrawal hash %s: %w", withdrawalHash, result.Error)
+		}
+		withdrawalsQuery = withdrawalsQuery.Where("l2_bridge_withdrawals.timestamp <= ?", txWithdrawal.Tx.Timestamp)
+	}
+
+	withdrawalsQuery = withdrawalsQuery.Joins("INNER JOIN l2_transaction_withdrawals ON withdrawal_hash = l2_bridge_withdrawals.transaction_withdrawal_hash")
+	withdrawalsQuery = withdrawalsQuery.Joins("INNER JOIN l2_contract_events ON l2_contract_events.guid = l2_transaction_withdrawals.initiated_l2_event_guid")
+	withdrawalsQuery = withdrawalsQuery.Joins("LEFT JOIN l1_contract_events AS proven_l1_events ON proven_l1_events.guid = l2_transaction_withdrawals.proven_l1_event_guid")
+	withdrawalsQuery = withdrawalsQuery.Joins("LEFT JOIN l1_contract_events AS finalized_l1_events ON finalized_l1_events.guid = l2_transaction_withdrawals.finalized_l1_event_guid")
+	withdrawalsQuery = withdrawalsQuery.Select(`
+l2_bridge_withdrawals.from_address, l2_bridge_withdrawals.to_address, l2_bridge_withdrawals.amount, l2_bridge_withdrawals.data, transaction_withdrawal_hash,
+l2_contract_events.transaction_hash AS l2_transaction_hash, l2_contract_events.block_hash as l2_block_hash, proven_l1_events.transaction_hash AS proven_l1_transaction_hash, finalized_l1_events.transaction_hash AS finalized_l1_transaction_hash,
+l2_bridge_withdrawals.timestamp, cross_domain_message_hash, local_token_address, remote_token_address`)
+	withdrawalsQuery = withdrawalsQuery.Order("l2_bridge_withdrawals.timestamp DESC").Limit(limit + 1)
+
+	withdrawals := []L2BridgeWithdrawalWithTransactionHashes{}
+	result := withdrawalsQuery.Find(&withdrawals)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	nextCursor := ""
+	hasNextPage := false
+	if len(withdrawals) > limit {
+		hasNextPage = true
+		nextCursor = withdrawals[limit].L2BridgeWithdrawal.TransactionWithdrawalHash.String()
+		withdrawals = withdrawals[:limit]
+	}
+
+	response := &L2BridgeWithdrawalsResponse{Withdrawals: withdrawals, Cursor: nextCursor, HasNextPage: hasNextPage}
+	return response, nil
+}
@@ -0,0 +1,158 @@
+package database
+
+import (
+	"errors"
+	"math/big"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+/**
+ * Types
+ */
+
+// BridgedToken is an entry of the token registry. Tokens are either configured, in which case
+// the metadata is set, or discovered from the StandardBridge events with empty metadata.
+type BridgedToken struct {
+	L1TokenAddress common.Address `gorm:"primaryKey;serializer:bytes"`
+	L2TokenAddress common.Address `gorm:"primaryKey;serializer:bytes"`
+
+	Name       string
+	Symbol     string
+	Decimals   uint8
+	Configured bool
+}
+
+// BridgedTokenSupply is the sum of the amounts bridged for a token pair, denominated
+// in the smallest unit of the token
+type BridgedTokenSupply struct {
+	BridgedToken `gorm:"embedded"`
+
+	DepositSum             *big.Int `gorm:"serializer:u256"`
+	InitWithdrawalSum      *big.Int `gorm:"serializer:u256"`
+	ProvenWithdrawalSum    *big.Int `gorm:"serializer:u256"`
+	FinalizedWithdrawalSum *big.Int `gorm:"serializer:u256"`
+}
+
+// L1LockedSupply is the amount held by the L1 bridge: deposited minus the finalized withdrawals
+func (s *BridgedTokenSupply) L1LockedSupply() *big.Int {
+	return new(big.Int).Sub(s.DepositSum, s.FinalizedWithdrawalSum)
+}
+
+// L2MintedSupply is the amount minted by the L2 bridge: deposited minus the initiated (burnt) withdrawals
+func (s *BridgedTokenSupply) L2MintedSupply() *big.Int {
+	return new(big.Int).Sub(s.DepositSum, s.InitWithdrawalSum)
+}
+
+type BridgedTokensView interface {
+	BridgedTokens() ([]BridgedToken, error)
+	BridgedTokenSupplies() ([]BridgedTokenSupply, error)
+	BridgedTokenSupply(l1TokenAddress, l2TokenAddress common.Address) (*BridgedTokenSupply, error)
+}
+
+type BridgedTokensDB interface {
+	BridgedTokensView
+
+	StoreBridgedTokens([]BridgedToken) error
+	UpsertBridgedTokens([]BridgedToken) error
+}
+
+/**
+ * Implementation
+ */
+
+type bridgedTokensDB struct {
+	log  log.Logger
+	gorm *gorm.DB
+}
+
+func newBridgedTokensDB(log log.Logger, db *gorm.DB) BridgedTokensDB {
+	return &bridgedTokensDB{log: log.New("table", "bridged_tokens"), gorm: db}
+}
+
+// StoreBridgedTokens registers discovered tokens, leaving the tokens already in the registry untouched
+func (db *bridgedTokensDB) StoreBridgedTokens(tokens []BridgedToken) error {
+	deduped := db.gorm.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "l1_token_address"}, {Name: "l2_token_address"}}, DoNothing: true})
+	result := deduped.Create(&tokens)
+	if result.Error == nil && int(result.RowsAffected) > 0 {
+		db.log.Info("discovered bridged tokens", "size", result.RowsAffected)
+	}
+
+	return result.Error
+}
+
+// UpsertBridgedTokens registers configured tokens, overwriting the metadata of the tokens already in the registry
+func (db *bridgedTokensDB) UpsertBridgedTokens(tokens []BridgedToken) error {
+	upsert := db.gorm.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "l1_token_address"}, {Name: "l2_token_address"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "symbol", "decimals", "configured"}),
+	})
+
+	return upsert.Create(&tokens).Error
+}
+
+func (db *bridgedTokensDB) BridgedTokens() ([]BridgedToken, error) {
+	tokens := []BridgedToken{}
+	result := db.gorm.Order("l1_token_address, l2_token_address").Find(&tokens)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return tokens, nil
+}
+
+func (db *bridgedTokensDB) BridgedTokenSupplies() ([]BridgedTokenSupply, error) {
+	supplies := []BridgedTokenSupply{}
+	result := db.supplyQuery().Order("bridged_tokens.l1_token_address, bridged_tokens.l2_token_address").Find(&supplies)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return supplies, nil
+}
+
+func (db *bridgedTokensDB) BridgedTokenSupply(l1TokenAddress, l2TokenAddress common.Address) (*BridgedTokenSupply, error) {
+	var supply BridgedTokenSupply
+	result := db.supplyQuery().Where(&BridgedToken{L1TokenAddress: l1TokenAddress, L2TokenAddress: l2TokenAddress}).Take(&supply)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &supply, nil
+}
+
+// supplyQuery joins the registered tokens with the sums of the deposits & withdrawals of each token pair.
+// Deposits are keyed by (local, remote) = (l1, l2) and withdrawals by (local, remote) = (l2, l1)
+func (db *bridgedTokensDB) supplyQuery() *gorm.DB {
+	deposits := db.gorm.Model(&L1BridgeDeposit{})
+	deposits = deposits.Select("local_token_address, remote_token_address, SUM(amount) AS amount")
+	deposits = deposits.Group("local_token_address, remote_token_address")
+
+	withdrawals := func(filter string) *gorm.DB {
+		query := db.gorm.Model(&L2BridgeWithdrawal{})
+		query = query.Joins("INNER JOIN l2_transaction_withdrawals ON withdrawal_hash = l2_bridge_withdrawals.transaction_withdrawal_hash")
+		if filter != "" {
+			query = query.Where(filter)
+		}
+		query = query.Select("l2_bridge_withdrawals.local_token_address, l2_bridge_withdrawals.remote_token_address, SUM(l2_bridge_withdrawals.amount) AS amount")
+		return query.Group("l2_bridge_withdrawals.local_token_address, l2_bridge_withdrawals.remote_token_address")
+	}
+
+	query := db.gorm.Model(&BridgedToken{})
+	query = query.Joins("LEFT JOIN (?) AS deposits ON deposits.local_token_address = bridged_tokens.l1_token_address AND deposits.remote_token_address = bridged_tokens.l2_token_address", deposits)
+	query = query.Joins("LEFT JOIN (?) AS initiated ON initiated.local_token_address = bridged_tokens.l2_token_address AND initiated.remote_token_address = bridged_tokens.l1_token_address", withdrawals(""))
+	query = query.Joins("LEFT JOIN (?) AS proven ON proven.local_token_address = bridged_tokens.l2_token_address AND proven.remote_token_address = bridged_tokens.l1_token_address", withdrawals("proven_l1_event_guid IS NOT NULL"))
+	query = query.Joins("LEFT JOIN (?) AS finalized ON finalized.local_token_address = bridged_tokens.l2_token_address AND finalized.remote_token_address = bridged_tokens.l1_token_address", withdrawals("finalized_l1_event_guid IS NOT NULL"))
+	query = query.Select(`
+bridged_tokens.*, COALESCE(deposits.amount, 0) AS deposit_sum, COALESCE(initiated.amount, 0) AS init_withdrawal_sum,
+COALESCE(proven.amount, 0) AS proven_withdrawal_sum, COALESCE(finalized.amount, 0) AS finalized_withdrawal_sum`)
+
+	return query
+}
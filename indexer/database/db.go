@@ -28,6 +28,7 @@ type DB struct {
 	BridgeTransfers    BridgeTransfersDB
 	BridgeMessages     BridgeMessagesDB
 	BridgeTransactions BridgeTransactionsDB
+	BridgedTokens      BridgedTokensDB
 }
 
 // NewDB connects to the configured DB, and provides client-bindings to it.
@@ -81,6 +82,7 @@ func NewDB(ctx context.Context, log log.Logger, dbConfig config.DBConfig) (*DB,
 		BridgeTransfers:    newBridgeTransfersDB(log, gorm),
 		BridgeMessages:     newBridgeMessagesDB(log, gorm),
 		BridgeTransactions: newBridgeTransactionsDB(log, gorm),
+		BridgedTokens:      newBridgedTokensDB(log, gorm),
 	}
 
 	return db, nil
@@ -97,6 +99,7 @@ func (db *DB) Transaction(fn func(db *DB) error) error {
 			BridgeTransfers:    newBridgeTransfersDB(db.log, tx),
 			BridgeMessages:     newBridgeMessagesDB(db.log, tx),
 			BridgeTransactions: newBridgeTransactionsDB(db.log, tx),
+			BridgedTokens:      newBridgedTokensDB(db.log, tx),
 		}
 
 		return fn(txDB)
@@ -0,0 +1,146 @@
+package e2e_tests
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/indexer/bindings"
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	e2etest_utils "github.com/ethereum-optimism/optimism/indexer/e2e_tests/utils"
+	op_e2e "github.com/ethereum-optimism/optimism/op-e2e"
+	e2ebindings "github.com/ethereum-optimism/optimism/op-e2e/bindings"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/receipts"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/transactions"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestE2EBridgedTokensERC20Supply(t *testing.T) {
+	testSuite := createE2ETestSuite(t)
+
+	aliceAddr := testSuite.OpCfg.Secrets.Addresses().Alice
+	l1Opts, err := bind.NewKeyedTransactorWithChainID(testSuite.OpCfg.Secrets.Alice, testSuite.OpCfg.L1ChainIDBig())
+	require.NoError(t, err)
+	l2Opts, err := bind.NewKeyedTransactorWithChainID(testSuite.OpCfg.Secrets.Alice, testSuite.OpCfg.L2ChainIDBig())
+	require.NoError(t, err)
+
+	// (1) Deploy & fund WETH on L1, and its OptimismMintableERC20 on L2
+	l1TokenAddr, tx, l1Token, err := e2ebindings.DeployWETH(l1Opts, testSuite.L1Client)
+	require.NoError(t, err)
+	_, err = wait.ForReceiptOK(context.Background(), testSuite.L1Client, tx.Hash())
+	require.NoError(t, err)
+
+	l1Opts.Value = big.NewInt(params.Ether)
+	tx, err = l1Token.Deposit(l1Opts)
+	require.NoError(t, err)
+	_, err = wait.ForReceiptOK(context.Background(), testSuite.L1Client, tx.Hash())
+	require.NoError(t, err)
+	l1Opts.Value = nil
+
+	tokenFactory, err := e2ebindings.NewOptimismMintableERC20Factory(predeploys.OptimismMintableERC20FactoryAddr, testSuite.L2Client)
+	require.NoError(t, err)
+	tx, err = tokenFactory.CreateOptimismMintableERC20(l2Opts, l1TokenAddr, "L2-WETH", "L2-WETH")
+	require.NoError(t, err)
+	createReceipt, err := wait.ForReceiptOK(context.Background(), testSuite.L2Client, tx.Hash())
+	require.NoError(t, err)
+	created, err := receipts.FindLog(createReceipt.Logs, tokenFactory.ParseOptimismMintableERC20Created)
+	require.NoError(t, err)
+	l2TokenAddr := created.LocalToken
+
+	// (2) Bridge 100 to L2
+	tx, err = l1Token.Approve(l1Opts, testSuite.OpCfg.L1Deployments.L1StandardBridgeProxy, new(big.Int).SetUint64(math.MaxUint64))
+	require.NoError(t, err)
+	_, err = wait.ForReceiptOK(context.Background(), testSuite.L1Client, tx.Hash())
+	require.NoError(t, err)
+
+	l1StandardBridge, err := bindings.NewL1StandardBridge(testSuite.OpCfg.L1Deployments.L1StandardBridgeProxy, testSuite.L1Client)
+	require.NoError(t, err)
+	depositTx, err := transactions.PadGasEstimate(l1Opts, 1.1, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return l1StandardBridge.BridgeERC20(opts, l1TokenAddr, l2TokenAddr, big.NewInt(100), 200_000, []byte{})
+	})
+	require.NoError(t, err)
+	depositReceipt, err := wait.ForReceiptOK(context.Background(), testSuite.L1Client, depositTx.Hash())
+	require.NoError(t, err)
+	depositInfo, err := e2etest_utils.ParseDepositInfo(depositReceipt)
+	require.NoError(t, err)
+	_, err = wait.ForReceiptOK(context.Background(), testSuite.L2Client, types.NewTx(depositInfo.DepositTx).Hash())
+	require.NoError(t, err)
+
+	// (3) Withdraw 40 back to L1
+	l2StandardBridge, err := bindings.NewL2StandardBridge(predeploys.L2StandardBridgeAddr, testSuite.L2Client)
+	require.NoError(t, err)
+	withdrawTx, err := transactions.PadGasEstimate(l2Opts, 1.1, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return l2StandardBridge.Withdraw(opts, l2TokenAddr, big.NewInt(40), 200_000, []byte{})
+	})
+	require.NoError(t, err)
+	withdrawReceipt, err := wait.ForReceiptOK(context.Background(), testSuite.L2Client, withdrawTx.Hash())
+	require.NoError(t, err)
+
+	// wait for processor catchup
+	require.NoError(t, wait.For(context.Background(), 500*time.Millisecond, func() (bool, error) {
+		l1Header := testSuite.Indexer.BridgeProcessor.LastL1Header
+		l2Header := testSuite.Indexer.BridgeProcessor.LastL2Header
+		return l1Header != nil && l1Header.Number.Uint64() >= depositReceipt.BlockNumber.Uint64() &&
+			l2Header != nil && l2Header.Number.Uint64() >= withdrawReceipt.BlockNumber.Uint64(), nil
+	}))
+
+	// The token pair is discovered from the bridge events
+	tokens, err := testSuite.DB.BridgedTokens.BridgedTokens()
+	require.NoError(t, err)
+	require.Contains(t, tokens, database.BridgedToken{L1TokenAddress: l1TokenAddr, L2TokenAddress: l2TokenAddr})
+
+	supply, err := testSuite.DB.BridgedTokens.BridgedTokenSupply(l1TokenAddr, l2TokenAddr)
+	require.NoError(t, err)
+	require.NotNil(t, supply)
+	require.Equal(t, uint64(100), supply.DepositSum.Uint64())
+	require.Equal(t, uint64(40), supply.InitWithdrawalSum.Uint64())
+	require.Equal(t, uint64(0), supply.ProvenWithdrawalSum.Uint64())
+	require.Equal(t, uint64(0), supply.FinalizedWithdrawalSum.Uint64())
+	require.Equal(t, uint64(100), supply.L1LockedSupply().Uint64())
+	require.Equal(t, uint64(60), supply.L2MintedSupply().Uint64())
+
+	// Transfers are listed by token pair, keyed by (local, remote) token of their origin chain
+	deposits, err := testSuite.DB.BridgeTransfers.L1BridgeDepositsByTokenPair(database.TokenPair{LocalTokenAddress: l1TokenAddr, RemoteTokenAddress: l2TokenAddr}, "", 10)
+	require.NoError(t, err)
+	require.Len(t, deposits.Deposits, 1)
+	require.False(t, deposits.HasNextPage)
+	require.Equal(t, depositTx.Hash(), deposits.Deposits[0].L1TransactionHash)
+	require.Equal(t, uint64(100), deposits.Deposits[0].L1BridgeDeposit.Tx.Amount.Uint64())
+	require.Equal(t, aliceAddr, deposits.Deposits[0].L1BridgeDeposit.Tx.FromAddress)
+
+	withdrawals, err := testSuite.DB.BridgeTransfers.L2BridgeWithdrawalsByTokenPair(database.TokenPair{LocalTokenAddress: l2TokenAddr, RemoteTokenAddress: l1TokenAddr}, "", 10)
+	require.NoError(t, err)
+	require.Len(t, withdrawals.Withdrawals, 1)
+	require.False(t, withdrawals.HasNextPage)
+	require.Equal(t, withdrawTx.Hash(), withdrawals.Withdrawals[0].L2TransactionHash)
+	require.Equal(t, uint64(40), withdrawals.Withdrawals[0].L2BridgeWithdrawal.Tx.Amount.Uint64())
+	require.Equal(t, aliceAddr, withdrawals.Withdrawals[0].L2BridgeWithdrawal.Tx.FromAddress)
+
+	// Other token pairs have no transfers
+	deposits, err = testSuite.DB.BridgeTransfers.L1BridgeDepositsByTokenPair(database.TokenPair{LocalTokenAddress: predeploys.LegacyERC20ETHAddr, RemoteTokenAddress: l2TokenAddr}, "", 10)
+	require.NoError(t, err)
+	require.Empty(t, deposits.Deposits)
+
+	// (4) Finalizing the withdrawal releases the tokens locked on L1
+	_, finalizeReceipt, _, _ := op_e2e.ProveAndFinalizeWithdrawal(t, *testSuite.OpCfg, testSuite.OpSys, "sequencer", testSuite.OpCfg.Secrets.Alice, withdrawReceipt)
+	require.NoError(t, wait.For(context.Background(), 500*time.Millisecond, func() (bool, error) {
+		l1Header := testSuite.Indexer.BridgeProcessor.LastFinalizedL1Header
+		return l1Header != nil && l1Header.Number.Uint64() >= finalizeReceipt.BlockNumber.Uint64(), nil
+	}))
+
+	supply, err = testSuite.DB.BridgedTokens.BridgedTokenSupply(l1TokenAddr, l2TokenAddr)
+	require.NoError(t, err)
+	require.Equal(t, uint64(40), supply.ProvenWithdrawalSum.Uint64())
+	require.Equal(t, uint64(40), supply.FinalizedWithdrawalSum.Uint64())
+	require.Equal(t, uint64(60), supply.L1LockedSupply().Uint64())
+	require.Equal(t, uint64(60), supply.L2MintedSupply().Uint64())
+}
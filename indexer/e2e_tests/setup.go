@@ -140,7 +140,7 @@ func createE2ETestSuite(t *testing.T, cfgOpt ...ConfigOpts) E2ETestSuite {
 	// API Configuration and Start
 	apiLog := testlog.Logger(t, log.LevelInfo).New("role", "indexer_api")
 	apiCfg := &api.Config{
-		DB:            &api.TestDBConnector{BridgeTransfers: db.BridgeTransfers}, // reuse the same DB
+		DB:            &api.TestDBConnector{BridgeTransfers: db.BridgeTransfers, BridgedTokens: db.BridgedTokens}, // reuse the same DB
 		HTTPServer:    config.ServerConfig{Host: "127.0.0.1", Port: 0},
 		MetricsServer: config.ServerConfig{Host: "127.0.0.1", Port: 0},
 	}
@@ -152,6 +152,9 @@ func (ix *Indexer) initFromConfig(ctx context.Context, cfg *config.Config) error
 	if err := ix.initDB(ctx, cfg.DB); err != nil {
 		return fmt.Errorf("failed to init DB: %w", err)
 	}
+	if err := ix.initTokenRegistry(cfg.Tokens); err != nil {
+		return fmt.Errorf("failed to init token registry: %w", err)
+	}
 	if err := ix.initL1ETL(cfg.Chain); err != nil {
 		return fmt.Errorf("failed to init L1 ETL: %w", err)
 	}
@@ -206,6 +209,31 @@ func (ix *Indexer) initDB(ctx context.Context, cfg config.DBConfig) error {
 	return nil
 }
 
+// initTokenRegistry registers the configured tokens. Any other bridged token is discovered by the bridge processor
+func (ix *Indexer) initTokenRegistry(tokens []config.TokenConfig) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	bridgedTokens := make([]database.BridgedToken, len(tokens))
+	for i, token := range tokens {
+		bridgedTokens[i] = database.BridgedToken{
+			L1TokenAddress: token.L1Address,
+			L2TokenAddress: token.L2Address,
+			Name:           token.Name,
+			Symbol:         token.Symbol,
+			Decimals:       token.Decimals,
+			Configured:     true,
+		}
+	}
+	if err := ix.DB.BridgedTokens.UpsertBridgedTokens(bridgedTokens); err != nil {
+		return err
+	}
+
+	ix.log.Info("registered configured tokens", "size", len(bridgedTokens))
+	return nil
+}
+
 func (ix *Indexer) initL1ETL(chainConfig config.ChainConfig) error {
 	l1Cfg := etl.Config{
 		LoopIntervalMsec:               chainConfig.L1PollingInterval,
@@ -236,3 +236,26 @@ CREATE TABLE IF NOT EXISTS l2_bridge_withdrawals (
 CREATE INDEX IF NOT EXISTS l2_bridge_withdrawals_timestamp ON l2_bridge_withdrawals(timestamp);
 CREATE INDEX IF NOT EXISTS l2_bridge_withdrawals_cross_domain_message_hash ON l2_bridge_withdrawals(cross_domain_message_hash);
 CREATE INDEX IF NOT EXISTS l2_bridge_withdrawals_from_address ON l2_bridge_withdrawals(from_address);
+
+-- Token registry of the ERC20 token pairs bridged via the StandardBridge. Tokens are either
+-- configured with their metadata, or discovered from the bridge events without any metadata.
+CREATE TABLE IF NOT EXISTS bridged_tokens (
+    l1_token_address VARCHAR NOT NULL,
+    l2_token_address VARCHAR NOT NULL,
+
+    name       VARCHAR NOT NULL,
+    symbol     VARCHAR NOT NULL,
+    decimals   SMALLINT NOT NULL CHECK (decimals >= 0),
+    configured BOOLEAN NOT NULL,
+
+    PRIMARY KEY (l1_token_address, l2_token_address)
+);
+
+-- Discover the tokens bridged prior to the registry. ETH is excluded
+INSERT INTO bridged_tokens (l1_token_address, l2_token_address, name, symbol, decimals, configured)
+    SELECT DISTINCT local_token_address, remote_token_address, '', '', 0, FALSE FROM l1_bridge_deposits
+        WHERE local_token_address != '0xdeaddeaddeaddeaddeaddeaddeaddeaddead0000'
+    UNION
+    SELECT DISTINCT remote_token_address, local_token_address, '', '', 0, FALSE FROM l2_bridge_withdrawals
+        WHERE local_token_address != '0xdeaddeaddeaddeaddeaddeaddeaddeaddead0000'
+ON CONFLICT DO NOTHING;
@@ -102,6 +102,7 @@ func L1ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metrics L1M
 	}
 
 	bridgedTokens := make(map[common.Address]int)
+	tokenPairs := make([]database.TokenPair, len(initiatedBridges))
 	bridgeDeposits := make([]database.L1BridgeDeposit, len(initiatedBridges))
 	for i := range initiatedBridges {
 		initiatedBridge := initiatedBridges[i]
@@ -122,6 +123,7 @@ func L1ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metrics L1M
 		}
 
 		bridgedTokens[initiatedBridge.BridgeTransfer.TokenPair.LocalTokenAddress]++
+		tokenPairs[i] = initiatedBridge.BridgeTransfer.TokenPair
 
 		initiatedBridge.BridgeTransfer.CrossDomainMessageHash = &sentMessage.BridgeMessage.MessageHash
 		bridgeDeposits[i] = database.L1BridgeDeposit{
@@ -133,6 +135,9 @@ func L1ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metrics L1M
 		if err := db.BridgeTransfers.StoreL1BridgeDeposits(bridgeDeposits); err != nil {
 			return err
 		}
+		if err := storeBridgedTokens(db, tokenPairs, true); err != nil {
+			return err
+		}
 		for tokenAddr, size := range bridgedTokens {
 			metrics.RecordL1InitiatedBridgeTransfers(tokenAddr, size)
 		}
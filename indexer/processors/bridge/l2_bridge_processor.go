@@ -96,6 +96,7 @@ func L2ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metrics L2M
 	}
 
 	bridgedTokens := make(map[common.Address]int)
+	tokenPairs := make([]database.TokenPair, len(initiatedBridges))
 	bridgeWithdrawals := make([]database.L2BridgeWithdrawal, len(initiatedBridges))
 	for i := range initiatedBridges {
 		initiatedBridge := initiatedBridges[i]
@@ -116,6 +117,7 @@ func L2ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metrics L2M
 		}
 
 		bridgedTokens[initiatedBridge.BridgeTransfer.TokenPair.LocalTokenAddress]++
+		tokenPairs[i] = initiatedBridge.BridgeTransfer.TokenPair
 
 		initiatedBridge.BridgeTransfer.CrossDomainMessageHash = &sentMessage.BridgeMessage.MessageHash
 		bridgeWithdrawals[i] = database.L2BridgeWithdrawal{
@@ -127,6 +129,9 @@ func L2ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metrics L2M
 		if err := db.BridgeTransfers.StoreL2BridgeWithdrawals(bridgeWithdrawals); err != nil {
 			return err
 		}
+		if err := storeBridgedTokens(db, tokenPairs, false); err != nil {
+			return err
+		}
 		for tokenAddr, size := range bridgedTokens {
 			metrics.RecordL2InitiatedBridgeTransfers(tokenAddr, size)
 		}
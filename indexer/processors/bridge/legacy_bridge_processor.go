@@ -102,6 +102,7 @@ func LegacyL1ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metri
 	}
 
 	bridgedTokens := make(map[common.Address]int)
+	tokenPairs := make([]database.TokenPair, len(initiatedBridges))
 	bridgeDeposits := make([]database.L1BridgeDeposit, len(initiatedBridges))
 	for i := range initiatedBridges {
 		initiatedBridge := initiatedBridges[i]
@@ -125,6 +126,7 @@ func LegacyL1ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metri
 
 		initiatedBridge.BridgeTransfer.CrossDomainMessageHash = &sentMessage.BridgeMessage.MessageHash
 		bridgedTokens[initiatedBridge.BridgeTransfer.TokenPair.LocalTokenAddress]++
+		tokenPairs[i] = initiatedBridge.BridgeTransfer.TokenPair
 		bridgeDeposits[i] = database.L1BridgeDeposit{
 			TransactionSourceHash: ctcTxDeposit.TxHash,
 			BridgeTransfer:        initiatedBridge.BridgeTransfer,
@@ -134,6 +136,9 @@ func LegacyL1ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metri
 		if err := db.BridgeTransfers.StoreL1BridgeDeposits(bridgeDeposits); err != nil {
 			return err
 		}
+		if err := storeBridgedTokens(db, tokenPairs, true); err != nil {
+			return err
+		}
 		for tokenAddr, size := range bridgedTokens {
 			metrics.RecordL1InitiatedBridgeTransfers(tokenAddr, size)
 		}
@@ -232,6 +237,7 @@ func LegacyL2ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metri
 	}
 
 	bridgedTokens := make(map[common.Address]int)
+	tokenPairs := make([]database.TokenPair, len(initiatedBridges))
 	l2BridgeWithdrawals := make([]database.L2BridgeWithdrawal, len(initiatedBridges))
 	for i := range initiatedBridges {
 		initiatedBridge := initiatedBridges[i]
@@ -247,6 +253,7 @@ func LegacyL2ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metri
 		}
 
 		bridgedTokens[initiatedBridge.BridgeTransfer.TokenPair.LocalTokenAddress]++
+		tokenPairs[i] = initiatedBridge.BridgeTransfer.TokenPair
 		initiatedBridge.BridgeTransfer.CrossDomainMessageHash = &sentMessage.BridgeMessage.MessageHash
 		l2BridgeWithdrawals[i] = database.L2BridgeWithdrawal{
 			TransactionWithdrawalHash: sentMessage.WithdrawalHash,
@@ -257,6 +264,9 @@ func LegacyL2ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metri
 		if err := db.BridgeTransfers.StoreL2BridgeWithdrawals(l2BridgeWithdrawals); err != nil {
 			return err
 		}
+		if err := storeBridgedTokens(db, tokenPairs, false); err != nil {
+			return err
+		}
 		for tokenAddr, size := range bridgedTokens {
 			metrics.RecordL2InitiatedBridgeTransfers(tokenAddr, size)
 		}
@@ -1,8 +1,35 @@
 package bridge
 
-import "github.com/ethereum/go-ethereum/common"
+import (
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum/go-ethereum/common"
+)
 
 type logKey struct {
 	BlockHash common.Hash
 	LogIndex  uint64
 }
+
+// storeBridgedTokens registers the ERC20 token pairs of the initiated bridge transfers in the token registry.
+// The pairs are oriented from the initiating chain, L1 for deposits and L2 for withdrawals.
+func storeBridgedTokens(db *database.DB, tokenPairs []database.TokenPair, initiatedOnL1 bool) error {
+	seen := make(map[database.TokenPair]bool)
+	tokens := []database.BridgedToken{}
+	for _, tokenPair := range tokenPairs {
+		if tokenPair == database.ETHTokenPair || seen[tokenPair] {
+			continue
+		}
+		seen[tokenPair] = true
+
+		if initiatedOnL1 {
+			tokens = append(tokens, database.BridgedToken{L1TokenAddress: tokenPair.LocalTokenAddress, L2TokenAddress: tokenPair.RemoteTokenAddress})
+		} else {
+			tokens = append(tokens, database.BridgedToken{L1TokenAddress: tokenPair.RemoteTokenAddress, L2TokenAddress: tokenPair.LocalTokenAddress})
+		}
+	}
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	return db.BridgedTokens.StoreBridgedTokens(tokens)
+}
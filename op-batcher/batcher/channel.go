@@ -4,10 +4,13 @@ import (
 	"fmt"
 	"math"
 
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -23,6 +26,8 @@ type channel struct {
 	channelBuilder *ChannelBuilder
 	// Set of unconfirmed txID -> tx data. For tx resubmission
 	pendingTransactions map[string]txData
+	// Set of unconfirmed txID -> published tx versions. For resuming confirmation tracking after a restart
+	publishedTransactions map[string]*publishedTx
 	// Set of confirmed txID -> inclusion block. For determining if the channel is timed out
 	confirmedTransactions map[string]eth.BlockID
 
@@ -46,10 +51,31 @@ func newChannel(log log.Logger, metr metrics.Metricer, cfg ChannelConfig, rollup
 		cfg:                   cfg,
 		channelBuilder:        cb,
 		pendingTransactions:   make(map[string]txData),
+		publishedTransactions: make(map[string]*publishedTx),
 		confirmedTransactions: make(map[string]eth.BlockID),
 	}, nil
 }
 
+// publishedTx tracks the nonce and hashes of all published versions of a pending tx.
+type publishedTx struct {
+	nonce  uint64
+	hashes []common.Hash
+}
+
+// TxPublished records the hash of a published version of a pending transaction.
+func (s *channel) TxPublished(id string, tx *types.Transaction) {
+	if _, ok := s.pendingTransactions[id]; !ok {
+		s.log.Warn("unknown transaction marked as published", "id", id, "tx", tx.Hash())
+		return
+	}
+	p, ok := s.publishedTransactions[id]
+	if !ok || p.nonce != tx.Nonce() {
+		p = &publishedTx{nonce: tx.Nonce()}
+		s.publishedTransactions[id] = p
+	}
+	p.hashes = append(p.hashes, tx.Hash())
+}
+
 // TxFailed records a transaction as failed. It will attempt to resubmit the data
 // in the failed transaction.
 func (s *channel) TxFailed(id string) {
@@ -60,6 +86,7 @@ func (s *channel) TxFailed(id string) {
 		// and re-queue them.
 		s.channelBuilder.PushFrames(data.Frames()...)
 		delete(s.pendingTransactions, id)
+		delete(s.publishedTransactions, id)
 	} else {
 		s.log.Warn("unknown transaction marked as failed", "id", id)
 	}
@@ -81,6 +108,7 @@ func (s *channel) TxConfirmed(id string, inclusionBlock eth.BlockID) (bool, []*t
 		return false, nil
 	}
 	delete(s.pendingTransactions, id)
+	delete(s.publishedTransactions, id)
 	s.confirmedTransactions[id] = inclusionBlock
 	s.confirmedTxUpdated = true
 	s.channelBuilder.FramePublished(inclusionBlock.Number)
@@ -217,10 +245,41 @@ func (s *channel) PendingFrames() int {
 	return s.channelBuilder.PendingFrames()
 }
 
+func (s *channel) PendingBytes() int {
+	return s.channelBuilder.PendingBytes()
+}
+
 func (s *channel) OutputFrames() error {
 	return s.channelBuilder.OutputFrames()
 }
 
+// Info returns a description of the channel for the admin API.
+func (s *channel) Info() rpc.ChannelInfo {
+	info := rpc.ChannelInfo{
+		ID:            s.ID(),
+		DAType:        flags.CalldataType,
+		Blocks:        make([]eth.BlockID, 0, len(s.channelBuilder.Blocks())),
+		InputBytes:    s.InputBytes(),
+		OutputBytes:   s.OutputBytes(),
+		TotalFrames:   s.TotalFrames(),
+		PendingFrames: s.PendingFrames(),
+		PendingTxs:    len(s.pendingTransactions),
+		ConfirmedTxs:  len(s.confirmedTransactions),
+		Timeout:       s.Timeout(),
+		Full:          s.IsFull(),
+	}
+	if s.cfg.MultiFrameTxs {
+		info.DAType = flags.BlobsType
+	}
+	for _, b := range s.channelBuilder.Blocks() {
+		info.Blocks = append(info.Blocks, eth.ToBlockID(b))
+	}
+	if err := s.FullErr(); err != nil {
+		info.FullReason = err.Error()
+	}
+	return info
+}
+
 // LatestL1Origin returns the latest L1 block origin from all the L2 blocks that have been added to the channel
 func (c *channel) LatestL1Origin() eth.BlockID {
 	return c.channelBuilder.LatestL1Origin()
@@ -383,6 +383,16 @@ func (c *ChannelBuilder) PendingFrames() int {
 	return len(c.frames)
 }
 
+// PendingBytes returns the amount of compressed data that is not yet submitted:
+// the data of all pending frames, plus the data ready in the compression pipeline.
+func (c *ChannelBuilder) PendingBytes() int {
+	n := c.co.ReadyBytes()
+	for _, f := range c.frames {
+		n += len(f.data)
+	}
+	return n
+}
+
 // NextFrame returns the next available frame.
 // HasFrame must be called prior to check if there's a next frame available.
 // Panics if called when there's no next frame.
@@ -4,10 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"slices"
 	"sync"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/flags"
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -16,7 +18,11 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 )
 
-var ErrReorg = errors.New("block does not extend existing chain")
+var (
+	ErrReorg           = errors.New("block does not extend existing chain")
+	ErrNoOpenChannel   = errors.New("no open channel")
+	ErrChannelNotFound = errors.New("channel not found")
+)
 
 // channelManager stores a contiguous set of blocks & turns them into channels.
 // Upon receiving tx confirmation (or a tx failure), it does channel error handling.
@@ -49,6 +55,11 @@ type channelManager struct {
 	// if set to true, prevents production of any new channel frames
 	closed bool
 
+	// optional journal of the channel queue, for resuming submission after a restart
+	journal *StateJournal
+	// last block of the latest fully submitted channel
+	lastSubmittedBlock eth.BlockID
+
 	isVolta   bool
 	isFourier bool
 }
@@ -76,6 +87,141 @@ func (s *channelManager) Clear(l1OriginLastClosedChannel eth.BlockID) {
 	s.currentChannel = nil
 	s.channelQueue = nil
 	s.txChannels = make(map[string]*channel)
+	s.lastSubmittedBlock = eth.BlockID{}
+	s.persist()
+}
+
+// SetJournal enables journaling of the channel queue on every change.
+// It should only be set after restoring any previously journaled state.
+func (s *channelManager) SetJournal(journal *StateJournal) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.journal = journal
+	s.persist()
+}
+
+// persist writes the closed channels of the channel queue to the journal, if enabled.
+// Open channels can't be restored, so their blocks will be reloaded and resubmitted after a restart.
+func (s *channelManager) persist() {
+	if s.journal == nil {
+		return
+	}
+	state := &journalState{
+		MaxFrameSize:              s.cfg.MaxFrameSize,
+		MultiFrameTxs:             s.cfg.MultiFrameTxs,
+		L1OriginLastClosedChannel: s.l1OriginLastClosedChannel,
+		LastSubmittedBlock:        s.lastSubmittedBlock,
+	}
+	for _, ch := range s.channelQueue {
+		if !ch.IsFull() {
+			continue
+		}
+		state.Channels = append(state.Channels, ch.journal())
+	}
+	if len(s.blocks) > 0 && s.blocks[0].NumberU64() <= s.lastJournaledBlock(state) {
+		// blocks of a timed out channel are queued again, behind newer channels:
+		// the journal can't describe this state, so don't resume from it after a restart
+		state.LastSubmittedBlock = eth.BlockID{}
+		state.Channels = nil
+	}
+	if err := s.journal.Write(state); err != nil {
+		s.log.Error("Failed to write state journal", "err", err)
+	}
+}
+
+func (s *channelManager) lastJournaledBlock(state *journalState) uint64 {
+	last := state.LastSubmittedBlock.Number
+	for _, jc := range state.Channels {
+		if n := len(jc.Blocks); n > 0 && jc.Blocks[n-1].Number > last {
+			last = jc.Blocks[n-1].Number
+		}
+	}
+	return last
+}
+
+// Restore resumes the given journaled channels, which must be restored with the blocks of the same index.
+// It returns the last L2 block that is either part of a restored channel or was already fully submitted,
+// from which on blocks should be loaded into the state.
+func (s *channelManager) Restore(state *journalState, channels []*journalChannel, blocks [][]*types.Block) (eth.BlockID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if state.MaxFrameSize != s.cfg.MaxFrameSize || state.MultiFrameTxs != s.cfg.MultiFrameTxs {
+		return eth.BlockID{}, fmt.Errorf("journaled frames of size %d (multi-frame txs: %v) don't match the current config",
+			state.MaxFrameSize, state.MultiFrameTxs)
+	}
+	restored := make([]*channel, 0, len(channels))
+	for i, jc := range channels {
+		ch, err := restoreChannel(s.log, s.metr, s.cfg, s.rollupCfg, jc, blocks[i])
+		if err != nil {
+			return eth.BlockID{}, err
+		}
+		restored = append(restored, ch)
+	}
+
+	last := state.LastSubmittedBlock
+	for _, ch := range restored {
+		s.channelQueue = append(s.channelQueue, ch)
+		for id := range ch.pendingTransactions {
+			s.txChannels[id] = ch
+		}
+		if b := ch.channelBuilder.blocks; len(b) > 0 && b[len(b)-1].NumberU64() > last.Number {
+			last = eth.ToBlockID(b[len(b)-1])
+		}
+		s.log.Info("Restored channel", "id", ch.ID(), "blocks", len(ch.channelBuilder.blocks),
+			"pending_frames", ch.PendingFrames(), "pending_txs", len(ch.pendingTransactions), "confirmed_txs", len(ch.confirmedTransactions))
+	}
+	if state.L1OriginLastClosedChannel.Number > s.l1OriginLastClosedChannel.Number {
+		s.l1OriginLastClosedChannel = state.L1OriginLastClosedChannel
+	}
+	s.lastSubmittedBlock = state.LastSubmittedBlock
+	s.tip = last.Hash
+	return last, nil
+}
+
+// restoredTx is a pending transaction of a restored channel, which is not tracked by the tx manager.
+type restoredTx struct {
+	id        txID
+	published *publishedTx
+}
+
+// PendingTxs returns all pending transactions. It should only be used right after Restore,
+// before any new transactions got queued for sending.
+func (s *channelManager) PendingTxs() []restoredTx {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var txs []restoredTx
+	for _, ch := range s.channelQueue {
+		for id, td := range ch.pendingTransactions {
+			var published *publishedTx
+			if p, ok := ch.publishedTransactions[id]; ok {
+				published = &publishedTx{nonce: p.nonce, hashes: slices.Clone(p.hashes)}
+			}
+			txs = append(txs, restoredTx{id: td.ID(), published: published})
+		}
+	}
+	return txs
+}
+
+// IsPendingTx returns whether the transaction is still pending.
+func (s *channelManager) IsPendingTx(id txID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.txChannels[id.String()]
+	return ok
+}
+
+// TxPublished records the hash of a published version of a transaction,
+// so its confirmation can still be tracked after a restart.
+func (s *channelManager) TxPublished(_id txID, tx *types.Transaction) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := _id.String()
+	if channel, ok := s.txChannels[id]; ok {
+		channel.TxPublished(id, tx)
+		s.persist()
+	} else {
+		s.log.Warn("transaction from unknown channel marked as published", "id", id, "tx", tx.Hash())
+	}
 }
 
 // TxFailed records a transaction as failed. It will attempt to resubmit the data
@@ -91,6 +237,7 @@ func (s *channelManager) TxFailed(_id txID) {
 			s.log.Info("Channel has no submitted transactions, clearing for shutdown", "chID", channel.ID())
 			s.removePendingChannel(channel)
 		}
+		s.persist()
 	} else {
 		s.log.Warn("transaction from unknown channel marked as failed", "id", id)
 	}
@@ -110,7 +257,11 @@ func (s *channelManager) TxConfirmed(_id txID, inclusionBlock eth.BlockID) {
 		s.blocks = append(blocks, s.blocks...)
 		if done {
 			s.removePendingChannel(channel)
+			if len(blocks) == 0 {
+				s.recordSubmittedChannel(channel)
+			}
 		}
+		s.persist()
 	} else {
 		s.log.Warn("transaction from unknown channel marked as confirmed", "id", id)
 	}
@@ -118,6 +269,17 @@ func (s *channelManager) TxConfirmed(_id txID, inclusionBlock eth.BlockID) {
 	s.log.Debug("marked transaction as confirmed", "id", id, "block", inclusionBlock)
 }
 
+// recordSubmittedChannel tracks the last block of the latest fully submitted channel.
+func (s *channelManager) recordSubmittedChannel(channel *channel) {
+	blocks := channel.channelBuilder.Blocks()
+	if len(blocks) == 0 {
+		return
+	}
+	if last := blocks[len(blocks)-1]; last.NumberU64() > s.lastSubmittedBlock.Number {
+		s.lastSubmittedBlock = eth.ToBlockID(last)
+	}
+}
+
 // removePendingChannel removes the given completed channel from the manager's state.
 func (s *channelManager) removePendingChannel(channel *channel) {
 	if s.currentChannel == channel {
@@ -169,7 +331,11 @@ func (s *channelManager) TxData(l1Head eth.BlockID) (txData, error) {
 
 	// Short circuit if there is pending tx data or the channel manager is closed.
 	if dataPending || s.closed {
-		return s.nextTxData(firstWithTxData)
+		tx, err := s.nextTxData(firstWithTxData)
+		if err == nil {
+			s.persist()
+		}
+		return tx, err
 	}
 
 	// No pending tx data, so we have to add new blocks to the channel
@@ -196,7 +362,11 @@ func (s *channelManager) TxData(l1Head eth.BlockID) (txData, error) {
 		return txData{}, err
 	}
 
-	return s.nextTxData(s.currentChannel)
+	tx, err := s.nextTxData(s.currentChannel)
+	if err == nil {
+		s.persist()
+	}
+	return tx, err
 }
 
 // ensureChannelWithSpace ensures currentChannel is populated with a channel that has
@@ -230,6 +400,17 @@ func (s *channelManager) ensureChannelWithSpace(l1Head eth.BlockID) error {
 	return nil
 }
 
+// PendingBytes returns the amount of compressed channel data that is not yet submitted, across all channels.
+func (s *channelManager) PendingBytes() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for _, ch := range s.channelQueue {
+		n += ch.PendingBytes()
+	}
+	return n
+}
+
 func (s *channelManager) SwitchDAType(targetDAType flags.DataAvailabilityType) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -245,6 +426,74 @@ func (s *channelManager) SwitchDAType(targetDAType flags.DataAvailabilityType) {
 	}
 }
 
+// ChannelInfos returns a description of all channels in the channel queue, in submission order.
+func (s *channelManager) ChannelInfos() []rpc.ChannelInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	infos := make([]rpc.ChannelInfo, 0, len(s.channelQueue))
+	for _, ch := range s.channelQueue {
+		infos = append(infos, ch.Info())
+	}
+	return infos
+}
+
+// PendingBlocks returns the IDs of all blocks that are not added to a channel yet.
+func (s *channelManager) PendingBlocks() []eth.BlockID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]eth.BlockID, 0, len(s.blocks))
+	for _, b := range s.blocks {
+		ids = append(ids, eth.ToBlockID(b))
+	}
+	return ids
+}
+
+// ForceCloseChannel closes the current channel and outputs all of its remaining frames,
+// so they get submitted without waiting for the channel to fill up or time out.
+func (s *channelManager) ForceCloseChannel() (rpc.ChannelInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.currentChannel == nil || s.currentChannel.IsFull() {
+		return rpc.ChannelInfo{}, ErrNoOpenChannel
+	}
+	s.log.Info("Force-closing channel", "id", s.currentChannel.ID())
+	s.currentChannel.Close()
+	if err := s.outputFrames(); err != nil {
+		return rpc.ChannelInfo{}, fmt.Errorf("outputting frames of force-closed channel: %w", err)
+	}
+	s.persist()
+	return s.currentChannel.Info(), nil
+}
+
+// DropChannel removes the given channel from the channel queue, e.g. if its submission got stuck.
+// Its blocks are requeued, like the blocks of a timed out channel, so they get submitted in a new channel.
+// Results of transactions of the dropped channel that are still in flight are ignored.
+func (s *channelManager) DropChannel(id derive.ChannelID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var channel *channel
+	for _, ch := range s.channelQueue {
+		if ch.ID() == id {
+			channel = ch
+			break
+		}
+	}
+	if channel == nil {
+		return ErrChannelNotFound
+	}
+	for txID, ch := range s.txChannels {
+		if ch == channel {
+			delete(s.txChannels, txID)
+		}
+	}
+	s.removePendingChannel(channel)
+	s.blocks = append(channel.channelBuilder.Blocks(), s.blocks...)
+	s.log.Warn("Dropped channel", "id", id, "blocks", len(channel.channelBuilder.Blocks()),
+		"pending_txs", len(channel.pendingTransactions), "confirmed_txs", len(channel.confirmedTransactions))
+	s.persist()
+	return nil
+}
+
 // registerL1Block registers the given block at the pending channel.
 func (s *channelManager) registerL1Block(l1Head eth.BlockID) {
 	s.currentChannel.CheckTimeout(l1Head.Number)
@@ -443,6 +692,7 @@ func (s *channelManager) Close() error {
 		}
 	}
 	s.log.Info("Reviewed all pending channels on close", "remaining", len(s.channelQueue))
+	s.persist()
 
 	if s.currentChannel == nil {
 		return nil
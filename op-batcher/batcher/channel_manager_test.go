@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
@@ -483,3 +484,49 @@ func TestChannelManager_ChannelCreation(t *testing.T) {
 		})
 	}
 }
+
+func TestChannelManager_ForceCloseAndDropChannel(t *testing.T) {
+	require := require.New(t)
+	l := testlog.Logger(t, log.LevelCrit)
+	cfg := channelManagerTestConfig(120_000, derive.SingularBatchType)
+	m := NewChannelManager(l, metrics.NoopMetrics, cfg, &defaultTestRollupConfig)
+	m.Clear(eth.BlockID{})
+
+	_, err := m.ForceCloseChannel()
+	require.ErrorIs(err, ErrNoOpenChannel)
+
+	a := newMiniL2Block(0)
+	require.NoError(m.AddL2Block(a))
+	require.Equal([]eth.BlockID{eth.ToBlockID(a)}, m.PendingBlocks())
+
+	// the channel is far from full, so no frames are ready yet
+	_, err = m.TxData(eth.BlockID{})
+	require.ErrorIs(err, io.EOF)
+	require.Empty(m.PendingBlocks())
+	infos := m.ChannelInfos()
+	require.Len(infos, 1)
+	require.False(infos[0].Full)
+	require.Equal([]eth.BlockID{eth.ToBlockID(a)}, infos[0].Blocks)
+	require.Equal(flags.CalldataType, infos[0].DAType)
+
+	info, err := m.ForceCloseChannel()
+	require.NoError(err)
+	require.True(info.Full)
+	require.Equal(1, info.PendingFrames)
+	_, err = m.ForceCloseChannel()
+	require.ErrorIs(err, ErrNoOpenChannel)
+
+	txdata, err := m.TxData(eth.BlockID{})
+	require.NoError(err)
+	require.Equal(1, m.ChannelInfos()[0].PendingTxs)
+
+	require.NoError(m.DropChannel(info.ID))
+	require.Empty(m.ChannelInfos())
+	require.Empty(m.txChannels)
+	require.Equal([]eth.BlockID{eth.ToBlockID(a)}, m.PendingBlocks())
+	require.ErrorIs(m.DropChannel(info.ID), ErrChannelNotFound)
+
+	// results of in-flight txs of the dropped channel are ignored
+	m.TxConfirmed(txdata.ID(), eth.BlockID{Number: 1})
+	require.Empty(m.ChannelInfos())
+}
@@ -88,6 +88,16 @@ type CLIConfig struct {
 	// the data availability type to use for posting batches, e.g. blobs vs calldata.
 	DataAvailabilityType flags.DataAvailabilityType
 
+	// DASwitchCheckInterval, DASwitchHysteresis and DASwitchConfirmations configure the cost model
+	// that drives DA type switching when DataAvailabilityType is auto.
+	DASwitchCheckInterval time.Duration
+	DASwitchHysteresis    float64
+	DASwitchConfirmations int
+
+	// StateJournal is the directory of the journal of in-flight channels. Journaling is disabled if empty.
+	// The journal resumes the pending transactions of the channels itself, so it excludes the txmgr journal.
+	StateJournal string
+
 	// TestUseMaxTxSizeForBlobs allows to set the blob size with MaxL1TxSize.
 	// Should only be used for testing purposes.
 	TestUseMaxTxSizeForBlobs bool
@@ -143,6 +153,11 @@ func (c *CLIConfig) Check() error {
 	if !flags.ValidDataAvailabilityType(c.DataAvailabilityType) {
 		return fmt.Errorf("unknown data availability type: %q", c.DataAvailabilityType)
 	}
+	if c.DataAvailabilityType == flags.AutoType {
+		if err := c.DACostConfig().Check(); err != nil {
+			return err
+		}
+	}
 	if err := c.MetricsConfig.Check(); err != nil {
 		return err
 	}
@@ -152,12 +167,24 @@ func (c *CLIConfig) Check() error {
 	if err := c.TxMgrConfig.Check(); err != nil {
 		return err
 	}
+	if c.StateJournal != "" && c.TxMgrConfig.JournalPath != "" {
+		return errors.New("the state journal resumes the pending transactions itself, the txmgr journal must be disabled")
+	}
 	if err := c.RPC.Check(); err != nil {
 		return err
 	}
 	return nil
 }
 
+// DACostConfig returns the configuration of the cost model that drives DA type switching.
+func (c *CLIConfig) DACostConfig() *DACostConfig {
+	return &DACostConfig{
+		CheckInterval: c.DASwitchCheckInterval,
+		Hysteresis:    c.DASwitchHysteresis,
+		Confirmations: c.DASwitchConfirmations,
+	}
+}
+
 // NewConfig parses the Config from the provided flags or environment variables.
 func NewConfig(ctx *cli.Context) *CLIConfig {
 	return &CLIConfig{
@@ -182,6 +209,10 @@ func NewConfig(ctx *cli.Context) *CLIConfig {
 		BatchType:                    ctx.Uint(flags.BatchTypeFlag.Name),
 		DataAvailabilityType:         flags.DataAvailabilityType(ctx.String(flags.DataAvailabilityTypeFlag.Name)),
 		ActiveSequencerCheckDuration: ctx.Duration(flags.ActiveSequencerCheckDurationFlag.Name),
+		DASwitchCheckInterval:        ctx.Duration(flags.DASwitchCheckIntervalFlag.Name),
+		DASwitchHysteresis:           ctx.Float64(flags.DASwitchHysteresisFlag.Name),
+		DASwitchConfirmations:        ctx.Int(flags.DASwitchConfirmationsFlag.Name),
+		StateJournal:                 ctx.String(flags.StateJournalFlag.Name),
 		TxMgrConfig:                  txmgr.ReadCLIConfig(ctx),
 		LogConfig:                    oplog.ReadCLIConfig(ctx),
 		MetricsConfig:                opmetrics.ReadCLIConfig(ctx),
@@ -113,6 +113,14 @@ func TestBatcherConfig(t *testing.T) {
 			},
 			errString: "invalid ApproxComprRatio 4.2 for ratio compressor",
 		},
+		{
+			name: "state journal with txmgr journal",
+			override: func(c *batcher.CLIConfig) {
+				c.StateJournal = "journal"
+				c.TxMgrConfig.JournalPath = "txmgr-journal.json"
+			},
+			errString: "the txmgr journal must be disabled",
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,151 @@
+package batcher
+
+import (
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// Decisions of the DA cost model, reported in metrics and logs.
+const (
+	// DADecisionIdle means there is no pending data to base a decision on.
+	DADecisionIdle = "idle"
+	// DADecisionKeep means the current DA type is the cheapest.
+	DADecisionKeep = "keep"
+	// DADecisionHysteresis means the other DA type is cheaper, but not by enough to be worth a switch.
+	DADecisionHysteresis = "hysteresis"
+	// DADecisionConfirming means the other DA type is cheaper, but not yet for enough consecutive evaluations.
+	DADecisionConfirming = "confirming"
+	// DADecisionSwitch means the batcher should switch to the other DA type.
+	DADecisionSwitch = "switch"
+)
+
+// DACostConfig configures the cost model that drives automatic DA type switching.
+type DACostConfig struct {
+	// CheckInterval is the interval between two evaluations of the cost model.
+	CheckInterval time.Duration
+	// Hysteresis is the fraction by which the other DA type must be cheaper than the current one
+	// to count towards a switch, e.g. 0.1 requires it to be at least 10% cheaper.
+	Hysteresis float64
+	// Confirmations is the number of consecutive evaluations that must favor the other DA type before switching.
+	Confirmations int
+}
+
+func (c *DACostConfig) Check() error {
+	if c.CheckInterval <= 0 {
+		return errors.New("DA switch check interval must be positive")
+	}
+	if c.Hysteresis < 0 || c.Hysteresis >= 1 {
+		return errors.New("DA switch hysteresis must be in [0, 1)")
+	}
+	if c.Confirmations < 1 {
+		return errors.New("DA switch confirmations must be at least 1")
+	}
+	return nil
+}
+
+// daCostEstimate is the estimated L1 cost of submitting the pending data with either DA type.
+type daCostEstimate struct {
+	PendingBytes int
+	GasPrice     *big.Int
+	BlobBaseFee  *big.Int
+	CalldataCost *big.Int
+	BlobCost     *big.Int
+}
+
+// Cheapest returns the DA type with the lowest estimated cost.
+func (e *daCostEstimate) Cheapest() flags.DataAvailabilityType {
+	if e.CalldataCost.Cmp(e.BlobCost) < 0 {
+		return flags.CalldataType
+	}
+	return flags.BlobsType
+}
+
+// Cost returns the estimated cost of the given DA type.
+func (e *daCostEstimate) Cost(daType flags.DataAvailabilityType) *big.Int {
+	if daType == flags.CalldataType {
+		return e.CalldataCost
+	}
+	return e.BlobCost
+}
+
+// estimateDACost estimates the L1 cost of submitting pendingBytes of compressed frame data,
+// given the BSC gas price and blob base fee, and the max number of blobs per blob tx.
+//
+// Calldata is charged per byte, at the calldata floor price since frame data is incompressible.
+// Blobs are charged per blob, so a partially filled blob costs as much as a full one.
+func estimateDACost(pendingBytes int, gasPrice *big.Int, blobBaseFee *big.Int, blobsPerTx int) *daCostEstimate {
+	n := uint64(pendingBytes)
+
+	calldataTxs := ceilDiv(n, CallDataMaxTxSize-1-derive.FrameV0OverHeadSize)
+	calldataGas := calldataTxs*params.TxGas + n*params.TxTokenPerNonZeroByte*params.TxCostFloorPerToken
+	calldataCost := new(big.Int).Mul(new(big.Int).SetUint64(calldataGas), gasPrice)
+
+	if blobsPerTx < 1 {
+		blobsPerTx = 1
+	}
+	blobs := ceilDiv(n, eth.MaxBlobDataSize-1-derive.FrameV0OverHeadSize)
+	blobTxs := ceilDiv(blobs, uint64(blobsPerTx))
+	blobCost := new(big.Int).Mul(new(big.Int).SetUint64(blobTxs*params.TxGas), gasPrice)
+	blobCost.Add(blobCost, new(big.Int).Mul(new(big.Int).SetUint64(blobs*params.BlobTxBlobGasPerBlob), blobBaseFee))
+
+	return &daCostEstimate{
+		PendingBytes: pendingBytes,
+		GasPrice:     gasPrice,
+		BlobBaseFee:  blobBaseFee,
+		CalldataCost: calldataCost,
+		BlobCost:     blobCost,
+	}
+}
+
+func ceilDiv(a, b uint64) uint64 {
+	return (a + b - 1) / b
+}
+
+// daCostModel decides when to switch DA types, applying hysteresis to the cost estimates
+// and requiring several consecutive evaluations in favor of the other DA type.
+type daCostModel struct {
+	cfg DACostConfig
+	// number of consecutive evaluations in favor of the other DA type
+	confirmations int
+}
+
+func newDACostModel(cfg DACostConfig) *daCostModel {
+	return &daCostModel{cfg: cfg}
+}
+
+// Decide returns whether the batcher should switch away from the current DA type, with the reason for the decision.
+func (m *daCostModel) Decide(current flags.DataAvailabilityType, est *daCostEstimate) string {
+	if est.PendingBytes == 0 {
+		return DADecisionIdle
+	}
+	cheapest := est.Cheapest()
+	if cheapest == current {
+		m.confirmations = 0
+		return DADecisionKeep
+	}
+	// the other DA type must be cheaper by at least the hysteresis fraction of the current cost
+	currentCost := new(big.Float).SetInt(est.Cost(current))
+	threshold := new(big.Float).Mul(currentCost, big.NewFloat(1-m.cfg.Hysteresis))
+	if new(big.Float).SetInt(est.Cost(cheapest)).Cmp(threshold) > 0 {
+		m.confirmations = 0
+		return DADecisionHysteresis
+	}
+	m.confirmations++
+	if m.confirmations < m.cfg.Confirmations {
+		return DADecisionConfirming
+	}
+	m.confirmations = 0
+	return DADecisionSwitch
+}
+
+// Reset forgets any evaluations in favor of the other DA type, e.g. after a forced switch.
+func (m *daCostModel) Reset() {
+	m.confirmations = 0
+}
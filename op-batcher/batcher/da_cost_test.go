@@ -0,0 +1,97 @@
+package batcher
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
+)
+
+func TestEstimateDACost(t *testing.T) {
+	gasPrice := big.NewInt(1_000_000_000)
+	blobBaseFee := big.NewInt(1)
+
+	t.Run("small", func(t *testing.T) {
+		est := estimateDACost(1000, gasPrice, blobBaseFee, 6)
+		calldataGas := params.TxGas + 1000*params.TxTokenPerNonZeroByte*params.TxCostFloorPerToken
+		require.Equal(t, new(big.Int).Mul(big.NewInt(int64(calldataGas)), gasPrice), est.CalldataCost)
+		blobCost := new(big.Int).Mul(big.NewInt(int64(params.TxGas)), gasPrice)
+		blobCost.Add(blobCost, big.NewInt(params.BlobTxBlobGasPerBlob))
+		require.Equal(t, blobCost, est.BlobCost)
+		require.Equal(t, flags.BlobsType, est.Cheapest())
+	})
+
+	t.Run("expensive blobs", func(t *testing.T) {
+		est := estimateDACost(1000, gasPrice, big.NewInt(1_000_000_000_000), 6)
+		require.Equal(t, flags.CalldataType, est.Cheapest())
+	})
+
+	t.Run("blobs per tx", func(t *testing.T) {
+		// 7 blobs need two txs with 6 blobs per tx, and seven with a single blob per tx
+		pending := 7 * 100_000
+		multi := estimateDACost(pending, gasPrice, blobBaseFee, 6)
+		single := estimateDACost(pending, gasPrice, blobBaseFee, 1)
+		diff := new(big.Int).Sub(single.BlobCost, multi.BlobCost)
+		require.Equal(t, new(big.Int).Mul(big.NewInt(5*int64(params.TxGas)), gasPrice), diff)
+	})
+}
+
+func TestDACostModelDecide(t *testing.T) {
+	cfg := DACostConfig{
+		CheckInterval: time.Second,
+		Hysteresis:    0.1,
+		Confirmations: 2,
+	}
+	estimate := func(calldataCost, blobCost int64) *daCostEstimate {
+		return &daCostEstimate{
+			PendingBytes: 1000,
+			CalldataCost: big.NewInt(calldataCost),
+			BlobCost:     big.NewInt(blobCost),
+		}
+	}
+
+	t.Run("idle", func(t *testing.T) {
+		m := newDACostModel(cfg)
+		est := estimate(10, 100)
+		est.PendingBytes = 0
+		require.Equal(t, DADecisionIdle, m.Decide(flags.BlobsType, est))
+	})
+
+	t.Run("keep", func(t *testing.T) {
+		m := newDACostModel(cfg)
+		require.Equal(t, DADecisionKeep, m.Decide(flags.BlobsType, estimate(100, 10)))
+	})
+
+	t.Run("hysteresis", func(t *testing.T) {
+		m := newDACostModel(cfg)
+		// calldata is cheaper, but by less than 10%
+		require.Equal(t, DADecisionHysteresis, m.Decide(flags.BlobsType, estimate(95, 100)))
+		require.Equal(t, DADecisionHysteresis, m.Decide(flags.BlobsType, estimate(95, 100)))
+	})
+
+	t.Run("switch after confirmations", func(t *testing.T) {
+		m := newDACostModel(cfg)
+		require.Equal(t, DADecisionConfirming, m.Decide(flags.BlobsType, estimate(50, 100)))
+		require.Equal(t, DADecisionSwitch, m.Decide(flags.BlobsType, estimate(50, 100)))
+		// confirmations start over after a switch
+		require.Equal(t, DADecisionConfirming, m.Decide(flags.BlobsType, estimate(50, 100)))
+	})
+
+	t.Run("keep resets confirmations", func(t *testing.T) {
+		m := newDACostModel(cfg)
+		require.Equal(t, DADecisionConfirming, m.Decide(flags.CalldataType, estimate(100, 50)))
+		require.Equal(t, DADecisionKeep, m.Decide(flags.CalldataType, estimate(50, 100)))
+		require.Equal(t, DADecisionConfirming, m.Decide(flags.CalldataType, estimate(100, 50)))
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		m := newDACostModel(cfg)
+		require.Equal(t, DADecisionConfirming, m.Decide(flags.BlobsType, estimate(50, 100)))
+		m.Reset()
+		require.Equal(t, DADecisionConfirming, m.Decide(flags.BlobsType, estimate(50, 100)))
+	})
+}
@@ -8,11 +8,13 @@ import (
 	"io"
 	"math/big"
 	_ "net/http/pprof"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
@@ -23,9 +25,11 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-batcher/flags"
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	plasma "github.com/ethereum-optimism/optimism/op-plasma"
+	"github.com/ethereum-optimism/optimism/op-service/bsc"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
@@ -34,16 +38,20 @@ import (
 const LimitLoadBlocksOneTime uint64 = 30
 
 // Auto DA params
-const DATypeSwitchThrehold int = 5
 const CallDataMaxTxSize uint64 = 120000
-const ApproximateGasPerCallDataTx int64 = 1934892
 const MaxBlobsNumberPerTx int64 = 6
 
+// JournalResumeTimeout is how long to wait for the restored pending transactions of a previous run to confirm,
+// before their frames are resubmitted.
+const JournalResumeTimeout = 5 * time.Minute
+
 var ErrBatcherNotRunning = errors.New("batcher is not running")
 
 type L1Client interface {
 	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
 	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
 	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
 }
 
@@ -67,6 +75,8 @@ type DriverSetup struct {
 	ChannelConfig    ChannelConfig
 	PlasmaDA         *plasma.DAClient
 	AutoSwitchDA     bool
+	// Journal is optional, and allows to resume the submission of channels after a restart
+	Journal *StateJournal
 }
 
 // BatchSubmitter encapsulates a service responsible for submitting L2 tx
@@ -162,6 +172,31 @@ func (l *BatchSubmitter) StopBatchSubmitting(ctx context.Context) error {
 	return nil
 }
 
+func (l *BatchSubmitter) PendingChannels() []rpc.ChannelInfo {
+	return l.state.ChannelInfos()
+}
+
+func (l *BatchSubmitter) PendingBlocks() []eth.BlockID {
+	return l.state.PendingBlocks()
+}
+
+func (l *BatchSubmitter) ForceCloseChannel() (*rpc.ChannelInfo, error) {
+	info, err := l.state.ForceCloseChannel()
+	if err != nil {
+		return nil, err
+	}
+	l.Log.Info("Force-closed channel via admin RPC", "id", info.ID)
+	return &info, nil
+}
+
+func (l *BatchSubmitter) DropChannel(id derive.ChannelID) error {
+	if err := l.state.DropChannel(id); err != nil {
+		return err
+	}
+	l.Log.Warn("Dropped channel via admin RPC", "id", id)
+	return nil
+}
+
 // loadBlocksIntoState loads all blocks since the previous stored block
 // It does the following:
 // 1. Fetch the sync status of the sequencer
@@ -292,6 +327,8 @@ func (l *BatchSubmitter) loop() {
 		}
 	}
 
+	l.restoreState(l.shutdownCtx)
+
 	receiptsCh := make(chan txmgr.TxReceipt[txID])
 	queue := txmgr.NewQueue[txID](l.killCtx, l.Txmgr, l.Config.MaxPendingTransactions)
 
@@ -320,33 +357,30 @@ func (l *BatchSubmitter) loop() {
 		go func() {
 			economicDAType := flags.BlobsType
 			l.Metr.RecordAutoChoosedDAType(economicDAType)
-			switchCount := 0
-			economicDATicker := time.NewTicker(5 * time.Second)
+			costModel := newDACostModel(l.Config.DACost)
+			economicDATicker := time.NewTicker(l.Config.DACost.CheckInterval)
 			defer economicDATicker.Stop()
 			addressReservedErrorTicker := time.NewTicker(time.Second)
 			defer addressReservedErrorTicker.Stop()
 			for {
 				select {
 				case <-economicDATicker.C:
-					newEconomicDAType, err := l.getEconomicDAType(l.shutdownCtx)
+					est, err := l.estimateDACost(l.shutdownCtx)
 					if err != nil {
-						l.Log.Error("Failed to get economic DA type", "err", err)
+						l.Log.Error("Failed to estimate DA cost", "err", err)
 						continue
 					}
-					if newEconomicDAType != economicDAType {
-						switchCount++
-					} else {
-						switchCount = 0
-					}
-					threhold := DATypeSwitchThrehold
-					if economicDAType == flags.CalldataType {
-						threhold = 20 * DATypeSwitchThrehold
-					}
-					if switchCount >= threhold {
-						l.Log.Info("start economic switch", "from type", economicDAType.String(), "to type", newEconomicDAType.String())
+					decision := costModel.Decide(economicDAType, est)
+					l.Metr.RecordDATypeDecision(economicDAType, est.Cheapest(), decision, est.PendingBytes)
+					l.Log.Debug("Evaluated DA cost", "current", economicDAType.String(), "cheapest", est.Cheapest().String(), "decision", decision,
+						"pending_bytes", est.PendingBytes, "gas_price", est.GasPrice, "blob_base_fee", est.BlobBaseFee,
+						"calldata_cost", est.CalldataCost, "blob_cost", est.BlobCost)
+					if decision == DADecisionSwitch {
+						newEconomicDAType := est.Cheapest()
+						l.Log.Info("start economic switch", "from type", economicDAType.String(), "to type", newEconomicDAType.String(),
+							"pending_bytes", est.PendingBytes, "calldata_cost", est.CalldataCost, "blob_cost", est.BlobCost)
 						start := time.Now()
 						economicDAType = newEconomicDAType
-						switchCount = 0
 						economicDATypeCh <- economicDAType
 						<-waitSwitchDACh
 						l.Log.Info("finish economic switch", "duration", time.Since(start))
@@ -365,7 +399,7 @@ func (l *BatchSubmitter) loop() {
 						} else {
 							l.Log.Crit("invalid DA type in economic switch loop", "invalid type", economicDAType.String())
 						}
-						switchCount = 0
+						costModel.Reset()
 						start := time.Now()
 						economicDATypeCh <- economicDAType
 						<-waitSwitchDACh
@@ -456,35 +490,27 @@ func (l *BatchSubmitter) loop() {
 	}
 }
 
-func (l *BatchSubmitter) getEconomicDAType(ctx context.Context) (flags.DataAvailabilityType, error) {
-	sCtx, sCancel := context.WithTimeout(ctx, l.Config.NetworkTimeout)
-	defer sCancel()
-	gasPrice, err := l.L1Client.SuggestGasTipCap(sCtx)
-	if err != nil {
-		return "", fmt.Errorf("getEconomicDAType failed to fetch the suggested gas tip cap: %w", err)
-	}
-	calldataCost := big.NewInt(0).Mul(big.NewInt(MaxBlobsNumberPerTx*ApproximateGasPerCallDataTx), gasPrice)
-
-	hCtx, hCancel := context.WithTimeout(ctx, l.Config.NetworkTimeout)
-	defer hCancel()
-	header, err := l.L1Client.HeaderByNumber(hCtx, nil)
+// estimateDACost estimates the cost of submitting the pending channel data with either DA type,
+// based on the gas price and blob base fee of the latest L1 block.
+func (l *BatchSubmitter) estimateDACost(ctx context.Context) (*daCostEstimate, error) {
+	cCtx, cancel := context.WithTimeout(ctx, l.Config.NetworkTimeout)
+	defer cancel()
+	block, err := l.L1Client.BlockByNumber(cCtx, nil)
 	if err != nil {
-		return "", fmt.Errorf("getEconomicDAType failed to fetch the latest header: %w", err)
+		return nil, fmt.Errorf("estimateDACost failed to fetch the latest block: %w", err)
 	}
+	header := block.Header()
 	if header.ExcessBlobGas == nil {
-		return "", fmt.Errorf("getEconomicDAType fetched header with nil ExcessBlobGas: %v", header)
+		return nil, fmt.Errorf("estimateDACost fetched header with nil ExcessBlobGas: %v", header)
 	}
-	blobGasPrice := eip4844.CalcBlobFee(*header.ExcessBlobGas)
-	blobCost := big.NewInt(0).Add(big.NewInt(0).Mul(big.NewInt(int64(params.TxGas)), gasPrice), big.NewInt(0).Mul(big.NewInt(params.MaxBlobGasPerBlock), blobGasPrice))
+	// BSC has no EIP-1559 base fee, so the gas price is derived from the transactions of the latest block.
+	gasPrice := bsc.BaseFeeByTransactions(block.Transactions())
+	blobBaseFee := eip4844.CalcBlobFee(*header.ExcessBlobGas)
 
-	l.Metr.RecordEstimatedCalldataTypeFee(calldataCost)
-	l.Metr.RecordEstimatedBlobTypeFee(blobCost)
-	if calldataCost.Cmp(blobCost) < 0 {
-		l.Log.Info("Economic DA type is calldata", "gas price", gasPrice, "calldata cost", calldataCost, "blob gas price", blobGasPrice, "blob cost", blobCost)
-		return flags.CalldataType, nil
-	}
-	l.Log.Info("Economic DA type is blobs", "gas price", gasPrice, "calldata cost", calldataCost, "blob gas price", blobGasPrice, "blob cost", blobCost)
-	return flags.BlobsType, nil
+	est := estimateDACost(l.state.PendingBytes(), gasPrice, blobBaseFee, l.ChannelConfig.TargetNumFrames)
+	l.Metr.RecordEstimatedCalldataTypeFee(est.CalldataCost)
+	l.Metr.RecordEstimatedBlobTypeFee(est.BlobCost)
+	return est, nil
 }
 
 func (l *BatchSubmitter) switchDAType(targetDAType flags.DataAvailabilityType) {
@@ -504,6 +530,165 @@ func (l *BatchSubmitter) switchDAType(targetDAType flags.DataAvailabilityType) {
 	}
 }
 
+// restoreState restores the channels journaled by a previous run, if journaling is enabled,
+// and resumes tracking the confirmation of their pending transactions.
+// If the journal can't be restored, the batcher starts from the safe head as usual.
+func (l *BatchSubmitter) restoreState(ctx context.Context) {
+	if l.Journal == nil {
+		return
+	}
+	// start journaling after the journaled state is restored, or discarded
+	defer l.state.SetJournal(l.Journal)
+
+	state, err := l.Journal.Load()
+	if err != nil {
+		l.Log.Error("Failed to load state journal, starting from the safe head", "err", err)
+		return
+	} else if state == nil {
+		return
+	}
+	last, err := l.restoreJournaledChannels(ctx, state)
+	if err != nil {
+		l.Log.Error("Failed to restore state journal, starting from the safe head", "err", err)
+		return
+	}
+	if last != (eth.BlockID{}) {
+		l.Log.Info("Restored state journal", "channels", len(state.Channels), "last_stored", last)
+		l.lastStoredBlock = last
+	}
+	// only the restored transactions are resolved, not the ones queued by this run meanwhile
+	restored := l.state.PendingTxs()
+	// resolving the pending transactions may take until JournalResumeTimeout, so it doesn't block the main loop
+	l.wg.Add(1)
+	go func() {
+		defer l.wg.Done()
+		l.resumePendingTxs(ctx, restored, time.Now().Add(JournalResumeTimeout))
+	}()
+}
+
+// restoreJournaledChannels restores the journaled channels that the safe head didn't pass yet,
+// and returns the last L2 block that doesn't need to be loaded into the state again.
+func (l *BatchSubmitter) restoreJournaledChannels(ctx context.Context, state *journalState) (eth.BlockID, error) {
+	rollupClient, err := l.EndpointProvider.RollupClient(ctx)
+	if err != nil {
+		return eth.BlockID{}, fmt.Errorf("getting rollup client: %w", err)
+	}
+	l2Client, err := l.EndpointProvider.EthClient(ctx)
+	if err != nil {
+		return eth.BlockID{}, fmt.Errorf("getting L2 client: %w", err)
+	}
+	cCtx, cancel := context.WithTimeout(ctx, l.Config.NetworkTimeout)
+	syncStatus, err := rollupClient.SyncStatus(cCtx)
+	cancel()
+	if err != nil {
+		return eth.BlockID{}, fmt.Errorf("failed to get sync status: %w", err)
+	}
+	safe := syncStatus.SafeL2
+
+	fetchBlock := func(id eth.BlockID) (*types.Block, error) {
+		cCtx, cancel := context.WithTimeout(ctx, l.Config.NetworkTimeout)
+		defer cancel()
+		block, err := l2Client.BlockByNumber(cCtx, new(big.Int).SetUint64(id.Number))
+		if err != nil {
+			return nil, fmt.Errorf("getting L2 block %d: %w", id.Number, err)
+		}
+		if block.Hash() != id.Hash {
+			return nil, fmt.Errorf("journaled L2 block %s was reorged out", id)
+		}
+		return block, nil
+	}
+
+	if submitted := state.LastSubmittedBlock; submitted.Number > safe.Number {
+		if _, err := fetchBlock(submitted); err != nil {
+			return eth.BlockID{}, err
+		}
+	} else {
+		state.LastSubmittedBlock = eth.BlockID{}
+	}
+	var (
+		channels []*journalChannel
+		blocks   [][]*types.Block
+	)
+	for _, jc := range state.Channels {
+		if len(jc.Blocks) == 0 || jc.Blocks[len(jc.Blocks)-1].Number <= safe.Number {
+			l.Log.Info("Skipping journaled channel that is already safe", "id", jc.ID)
+			continue
+		}
+		chBlocks := make([]*types.Block, 0, len(jc.Blocks))
+		for _, id := range jc.Blocks {
+			block, err := fetchBlock(id)
+			if err != nil {
+				return eth.BlockID{}, err
+			}
+			chBlocks = append(chBlocks, block)
+		}
+		channels = append(channels, jc)
+		blocks = append(blocks, chBlocks)
+	}
+	return l.state.Restore(state, channels, blocks)
+}
+
+// resumePendingTxs resolves the pending transactions of the restored channels, which were sent by a previous run.
+// Transactions that got included are marked as confirmed. Transactions that were never published, or got replaced
+// by another transaction with the same nonce, are marked as failed, so their frames get resubmitted.
+// Transactions that are still pending after the deadline are marked as failed as well.
+func (l *BatchSubmitter) resumePendingTxs(ctx context.Context, txs []restoredTx, deadline time.Time) {
+	for {
+		// transactions may have been resolved otherwise meanwhile, e.g. if their channel got cleared
+		txs = slices.DeleteFunc(txs, func(tx restoredTx) bool { return !l.state.IsPendingTx(tx.id) })
+		if len(txs) == 0 {
+			return
+		}
+		l.Log.Info("Resuming pending transactions of restored channels", "count", len(txs))
+		for _, tx := range txs {
+			if err := l.resumePendingTx(ctx, tx, time.Now().After(deadline)); err != nil {
+				l.Log.Warn("Failed to resume pending transaction, will retry", "tx_id", tx.id.String(), "err", err)
+			}
+		}
+		select {
+		case <-time.After(l.Config.PollInterval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (l *BatchSubmitter) resumePendingTx(ctx context.Context, tx restoredTx, expired bool) error {
+	if tx.published == nil {
+		l.Log.Info("Restored transaction was never published", "tx_id", tx.id.String())
+		l.state.TxFailed(tx.id)
+		return nil
+	}
+	// The nonce is read before the receipts, so a transaction that gets included in between
+	// is found by its receipt, instead of being taken for replaced.
+	cCtx, cancel := context.WithTimeout(ctx, l.Config.NetworkTimeout)
+	nonce, err := l.L1Client.NonceAt(cCtx, l.Txmgr.From(), nil)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("getting nonce: %w", err)
+	}
+	for _, hash := range tx.published.hashes {
+		cCtx, cancel := context.WithTimeout(ctx, l.Config.NetworkTimeout)
+		receipt, err := l.L1Client.TransactionReceipt(cCtx, hash)
+		cancel()
+		if errors.Is(err, ethereum.NotFound) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("getting receipt of tx %s: %w", hash, err)
+		}
+		l.recordConfirmedTx(tx.id, receipt)
+		return nil
+	}
+	if nonce > tx.published.nonce {
+		l.Log.Info("Restored transaction was replaced", "tx_id", tx.id.String(), "nonce", tx.published.nonce)
+		l.state.TxFailed(tx.id)
+	} else if expired {
+		l.Log.Warn("Restored transaction is still pending, resubmitting", "tx_id", tx.id.String(), "nonce", tx.published.nonce)
+		l.state.TxFailed(tx.id)
+	}
+	return nil
+}
+
 // waitNodeSync Check to see if there was a batcher tx sent recently that
 // still needs more block confirmations before being considered finalized
 func (l *BatchSubmitter) waitNodeSync() error {
@@ -710,7 +895,11 @@ func (l *BatchSubmitter) sendTransaction(ctx context.Context, txdata txData, que
 		candidate.GasLimit = intrinsicGas
 	}
 
-	queue.Send(txdata.ID(), *candidate, receiptsCh)
+	id := txdata.ID()
+	candidate.Published = func(tx *types.Transaction) {
+		l.state.TxPublished(id, tx)
+	}
+	queue.Send(id, *candidate, receiptsCh)
 	return nil
 }
 
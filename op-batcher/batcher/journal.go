@@ -0,0 +1,414 @@
+package batcher
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+const journalVersion = 1
+
+var (
+	ErrRestored           = errors.New("channel restored from journal")
+	errRestoredChannelOut = errors.New("restored channel does not accept new data")
+)
+
+// StateJournal persists the in-flight channels of the channelManager to a directory,
+// so a restarted batcher can resume their submission instead of rebuilding them from the safe head.
+// The frames of a channel are written once, when the channel is first journaled. The submission state
+// of each channel and the channel queue are kept in separate files, so a change only rewrites the files it affects.
+type StateJournal struct {
+	dir string
+
+	// written is the last written content of the state files, so unchanged files are not written again
+	written map[string][]byte
+	// framesWritten tracks the channels of which the frames are journaled
+	framesWritten map[derive.ChannelID]bool
+}
+
+const journalStateFile = "state.json"
+
+func NewStateJournal(dir string) (*StateJournal, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create state journal directory: %w", err)
+	}
+	return &StateJournal{
+		dir:           dir,
+		written:       make(map[string][]byte),
+		framesWritten: make(map[derive.ChannelID]bool),
+	}, nil
+}
+
+// journalState is a snapshot of the channelManager state that can be restored.
+type journalState struct {
+	Version int `json:"version"`
+	// MaxFrameSize and MultiFrameTxs are the frame config of the journaled channels.
+	// The journal is discarded if the DA type was switched since.
+	MaxFrameSize  uint64 `json:"maxFrameSize"`
+	MultiFrameTxs bool   `json:"multiFrameTxs"`
+	// L1OriginLastClosedChannel is the latest L1 origin of all closed channels.
+	L1OriginLastClosedChannel eth.BlockID `json:"l1OriginLastClosedChannel"`
+	// LastSubmittedBlock is the last L2 block of the latest channel that got fully submitted.
+	LastSubmittedBlock eth.BlockID `json:"lastSubmittedBlock"`
+	// ChannelIDs are the IDs of the closed channels that are not fully submitted yet, in submission order.
+	ChannelIDs []derive.ChannelID `json:"channels"`
+	// Channels are the journaled channels of ChannelIDs, which are stored in their own files.
+	Channels []*journalChannel `json:"-"`
+}
+
+type journalChannel struct {
+	ID             derive.ChannelID `json:"id"`
+	Blocks         []eth.BlockID    `json:"blocks"`
+	LatestL1Origin eth.BlockID      `json:"latestL1Origin"`
+	Timeout        uint64           `json:"timeout"`
+	InputBytes     int              `json:"inputBytes"`
+	OutputBytes    int              `json:"outputBytes"`
+	TotalFrames    int              `json:"totalFrames"`
+	// Frames are the frames that are not handed out for submission yet.
+	Frames       []journalFrame       `json:"frames"`
+	PendingTxs   []journalPendingTx   `json:"pendingTxs"`
+	ConfirmedTxs []journalConfirmedTx `json:"confirmedTxs"`
+}
+
+// journalFrame is a frame of a journaled channel. The data of the frames is stored along with
+// the other frames of the channel, the submission state of the channel only refers to their number.
+type journalFrame struct {
+	Number uint16        `json:"number"`
+	Data   hexutil.Bytes `json:"data,omitempty"`
+}
+
+type journalPendingTx struct {
+	Frames []journalFrame `json:"frames"`
+	// Nonce and Hashes are only set if the tx got published.
+	Nonce  uint64        `json:"nonce"`
+	Hashes []common.Hash `json:"hashes"`
+}
+
+type journalConfirmedTx struct {
+	ID             string      `json:"id"`
+	InclusionBlock eth.BlockID `json:"inclusionBlock"`
+}
+
+func channelFile(id derive.ChannelID) string {
+	return id.String() + ".json"
+}
+
+func framesFile(id derive.ChannelID) string {
+	return id.String() + ".frames.json"
+}
+
+// Load reads the journaled state. It returns nil if there is no journal yet.
+func (j *StateJournal) Load() (*journalState, error) {
+	path := filepath.Join(j.dir, journalStateFile)
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	state, err := jsonutil.LoadJSON[journalState](path)
+	if err != nil {
+		return nil, err
+	}
+	if state.Version != journalVersion {
+		return nil, fmt.Errorf("unsupported journal version %d", state.Version)
+	}
+	for _, id := range state.ChannelIDs {
+		jc, err := jsonutil.LoadJSON[journalChannel](filepath.Join(j.dir, channelFile(id)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load channel %s: %w", id, err)
+		}
+		frames, err := jsonutil.LoadJSON[[]journalFrame](filepath.Join(j.dir, framesFile(id)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load frames of channel %s: %w", id, err)
+		}
+		if err := jc.setFrameData(*frames); err != nil {
+			return nil, err
+		}
+		j.framesWritten[id] = true
+		state.Channels = append(state.Channels, jc)
+	}
+	return state, nil
+}
+
+// Write replaces the journal with the given state, only writing the files that changed.
+func (j *StateJournal) Write(state *journalState) error {
+	state.Version = journalVersion
+	state.ChannelIDs = make([]derive.ChannelID, 0, len(state.Channels))
+	current := make(map[derive.ChannelID]bool, len(state.Channels))
+	for _, jc := range state.Channels {
+		state.ChannelIDs = append(state.ChannelIDs, jc.ID)
+		current[jc.ID] = true
+		if !j.framesWritten[jc.ID] {
+			if err := jsonutil.WriteJSON(filepath.Join(j.dir, framesFile(jc.ID)), jc.allFrames(), 0o600); err != nil {
+				return fmt.Errorf("failed to write frames of channel %s: %w", jc.ID, err)
+			}
+			j.framesWritten[jc.ID] = true
+		}
+		if err := j.writeIfChanged(channelFile(jc.ID), jc.withoutFrameData()); err != nil {
+			return fmt.Errorf("failed to write channel %s: %w", jc.ID, err)
+		}
+	}
+	// the channel files are in place before the channel queue refers to them
+	if err := j.writeIfChanged(journalStateFile, state); err != nil {
+		return err
+	}
+	for id := range j.framesWritten {
+		if current[id] {
+			continue
+		}
+		for _, name := range []string{channelFile(id), framesFile(id)} {
+			if err := os.Remove(filepath.Join(j.dir, name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("failed to remove journaled channel %s: %w", id, err)
+			}
+			delete(j.written, name)
+		}
+		delete(j.framesWritten, id)
+	}
+	return nil
+}
+
+// writeIfChanged atomically writes the JSON encoding of v to the given file of the journal,
+// unless it is the same as the last written one.
+func (j *StateJournal) writeIfChanged(name string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", name, err)
+	}
+	if prev, ok := j.written[name]; ok && bytes.Equal(prev, data) {
+		return nil
+	}
+	f, err := ioutil.NewAtomicWriterCompressed(filepath.Join(j.dir, name), 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", name, err)
+	}
+	defer f.Close()
+	if _, err := f.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	j.written[name] = data
+	return nil
+}
+
+// allFrames returns all frames of the channel that are not confirmed yet.
+func (jc *journalChannel) allFrames() []journalFrame {
+	frames := slices.Clone(jc.Frames)
+	for _, ptx := range jc.PendingTxs {
+		frames = append(frames, ptx.Frames...)
+	}
+	sort.Slice(frames, func(i, k int) bool { return frames[i].Number < frames[k].Number })
+	return frames
+}
+
+// withoutFrameData returns a copy of the channel, of which the frames only carry their number.
+func (jc *journalChannel) withoutFrameData() *journalChannel {
+	stripped := *jc
+	stripped.Frames = frameNumbers(jc.Frames)
+	stripped.PendingTxs = make([]journalPendingTx, 0, len(jc.PendingTxs))
+	for _, ptx := range jc.PendingTxs {
+		ptx.Frames = frameNumbers(ptx.Frames)
+		stripped.PendingTxs = append(stripped.PendingTxs, ptx)
+	}
+	return &stripped
+}
+
+func frameNumbers(frames []journalFrame) []journalFrame {
+	out := make([]journalFrame, 0, len(frames))
+	for _, f := range frames {
+		out = append(out, journalFrame{Number: f.Number})
+	}
+	return out
+}
+
+// setFrameData fills in the data of the frames of the channel.
+func (jc *journalChannel) setFrameData(frames []journalFrame) error {
+	data := make(map[uint16]hexutil.Bytes, len(frames))
+	for _, f := range frames {
+		data[f.Number] = f.Data
+	}
+	fill := func(frames []journalFrame) error {
+		for i := range frames {
+			d, ok := data[frames[i].Number]
+			if !ok {
+				return fmt.Errorf("missing data of frame %d of channel %s", frames[i].Number, jc.ID)
+			}
+			frames[i].Data = d
+		}
+		return nil
+	}
+	if err := fill(jc.Frames); err != nil {
+		return err
+	}
+	for _, ptx := range jc.PendingTxs {
+		if err := fill(ptx.Frames); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func toJournalFrames(frames []frameData) []journalFrame {
+	out := make([]journalFrame, 0, len(frames))
+	for _, f := range frames {
+		out = append(out, journalFrame{Number: f.id.frameNumber, Data: f.data})
+	}
+	return out
+}
+
+func fromJournalFrames(id derive.ChannelID, frames []journalFrame) []frameData {
+	out := make([]frameData, 0, len(frames))
+	for _, f := range frames {
+		out = append(out, frameData{id: frameID{chID: id, frameNumber: f.Number}, data: f.Data})
+	}
+	return out
+}
+
+// journal returns the snapshot of a closed channel.
+func (s *channel) journal() *journalChannel {
+	cb := s.channelBuilder
+	jc := &journalChannel{
+		ID:             s.ID(),
+		Blocks:         make([]eth.BlockID, 0, len(cb.blocks)),
+		LatestL1Origin: cb.latestL1Origin,
+		Timeout:        cb.timeout,
+		InputBytes:     cb.InputBytes(),
+		OutputBytes:    cb.outputBytes,
+		TotalFrames:    cb.numFrames,
+		Frames:         toJournalFrames(cb.frames),
+	}
+	for _, b := range cb.blocks {
+		jc.Blocks = append(jc.Blocks, eth.ToBlockID(b))
+	}
+	ids := make([]string, 0, len(s.pendingTransactions))
+	for id := range s.pendingTransactions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		ptx := journalPendingTx{Frames: toJournalFrames(s.pendingTransactions[id].frames)}
+		if p, ok := s.publishedTransactions[id]; ok {
+			ptx.Nonce = p.nonce
+			ptx.Hashes = p.hashes
+		}
+		jc.PendingTxs = append(jc.PendingTxs, ptx)
+	}
+	for id, inclusionBlock := range s.confirmedTransactions {
+		jc.ConfirmedTxs = append(jc.ConfirmedTxs, journalConfirmedTx{ID: id, InclusionBlock: inclusionBlock})
+	}
+	sort.Slice(jc.ConfirmedTxs, func(i, k int) bool { return jc.ConfirmedTxs[i].ID < jc.ConfirmedTxs[k].ID })
+	return jc
+}
+
+// restoreChannel rebuilds a closed channel from its snapshot. The blocks must match the journaled block IDs.
+func restoreChannel(log log.Logger, metr metrics.Metricer, cfg ChannelConfig, rollupCfg *rollup.Config, jc *journalChannel, blocks []*types.Block) (*channel, error) {
+	if len(blocks) != len(jc.Blocks) {
+		return nil, fmt.Errorf("channel %s has %d blocks, got %d", jc.ID, len(jc.Blocks), len(blocks))
+	}
+	for i, b := range blocks {
+		if eth.ToBlockID(b) != jc.Blocks[i] {
+			return nil, fmt.Errorf("channel %s block %d mismatch: expected %s, got %s", jc.ID, i, jc.Blocks[i], eth.ToBlockID(b))
+		}
+	}
+	cb := &ChannelBuilder{
+		cfg:            cfg,
+		rollupCfg:      *rollupCfg,
+		timeout:        jc.Timeout,
+		timeoutReason:  ErrRestored,
+		co:             &restoredChannelOut{id: jc.ID, inputBytes: jc.InputBytes},
+		blocks:         blocks,
+		latestL1Origin: jc.LatestL1Origin,
+		frames:         fromJournalFrames(jc.ID, jc.Frames),
+		numFrames:      jc.TotalFrames,
+		outputBytes:    jc.OutputBytes,
+	}
+	cb.setFullErr(ErrRestored)
+	ch := &channel{
+		log:                   log,
+		metr:                  metr,
+		cfg:                   cfg,
+		channelBuilder:        cb,
+		pendingTransactions:   make(map[string]txData),
+		publishedTransactions: make(map[string]*publishedTx),
+		confirmedTransactions: make(map[string]eth.BlockID),
+	}
+	for _, ptx := range jc.PendingTxs {
+		td := txData{frames: fromJournalFrames(jc.ID, ptx.Frames)}
+		id := td.ID().String()
+		ch.pendingTransactions[id] = td
+		if len(ptx.Hashes) > 0 {
+			ch.publishedTransactions[id] = &publishedTx{nonce: ptx.Nonce, hashes: ptx.Hashes}
+		}
+	}
+	for _, confirmed := range jc.ConfirmedTxs {
+		ch.confirmedTransactions[confirmed.ID] = confirmed.InclusionBlock
+		ch.confirmedTxUpdated = true
+	}
+	return ch, nil
+}
+
+// restoredChannelOut stands in for the ChannelOut of a restored channel: it is closed,
+// and all of its frames were output before the channel got journaled.
+type restoredChannelOut struct {
+	id         derive.ChannelID
+	inputBytes int
+}
+
+var _ derive.ChannelOut = (*restoredChannelOut)(nil)
+
+func (co *restoredChannelOut) ID() derive.ChannelID {
+	return co.id
+}
+
+func (co *restoredChannelOut) Reset() error {
+	return errRestoredChannelOut
+}
+
+func (co *restoredChannelOut) AddBlock(*rollup.Config, *types.Block) error {
+	return errRestoredChannelOut
+}
+
+func (co *restoredChannelOut) AddSingularBatch(*rollup.Config, *derive.SingularBatch, uint64) error {
+	return errRestoredChannelOut
+}
+
+func (co *restoredChannelOut) InputBytes() int {
+	return co.inputBytes
+}
+
+func (co *restoredChannelOut) ReadyBytes() int {
+	return 0
+}
+
+func (co *restoredChannelOut) Flush() error {
+	return nil
+}
+
+func (co *restoredChannelOut) FullErr() error {
+	return ErrRestored
+}
+
+func (co *restoredChannelOut) Close() error {
+	return nil
+}
+
+func (co *restoredChannelOut) OutputFrame(*bytes.Buffer, uint64) (uint16, error) {
+	return 0, io.EOF
+}
@@ -0,0 +1,173 @@
+package batcher
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr/mocks"
+)
+
+func TestChannelManagerJournalRestore(t *testing.T) {
+	require := require.New(t)
+	log := testlog.Logger(t, log.LevelCrit)
+	cfg := channelManagerTestConfig(120_000, derive.SingularBatchType)
+	cfg.CompressorConfig.TargetOutputSize = 1 // full on first block
+	cfg.ChannelTimeout = 100
+	dir := filepath.Join(t.TempDir(), "journal")
+	journal, err := NewStateJournal(dir)
+	require.NoError(err)
+
+	m := NewChannelManager(log, metrics.NoopMetrics, cfg, &rollup.Config{})
+	m.Clear(eth.BlockID{})
+	m.SetJournal(journal)
+
+	a := newMiniL2BlockWithNumberParent(0, big.NewInt(1), common.Hash{})
+	require.NoError(m.AddL2Block(a))
+	txdata, err := m.TxData(eth.BlockID{})
+	require.NoError(err)
+	tx := types.NewTx(&types.DynamicFeeTx{Nonce: 7})
+
+	// the frames are written once, the submission state is updated in place
+	chID := txdata.frames[0].id.chID
+	frames, err := os.ReadFile(filepath.Join(dir, framesFile(chID)))
+	require.NoError(err)
+	m.TxPublished(txdata.ID(), tx)
+	framesAfter, err := os.ReadFile(filepath.Join(dir, framesFile(chID)))
+	require.NoError(err)
+	require.Equal(frames, framesAfter)
+
+	restored, err := NewStateJournal(dir)
+	require.NoError(err)
+	state, err := restored.Load()
+	require.NoError(err)
+	require.Len(state.Channels, 1)
+	require.Equal(hexutil.Bytes(txdata.frames[0].data), state.Channels[0].PendingTxs[0].Frames[0].Data)
+	require.Len(state.Channels[0].PendingTxs, 1)
+	require.Equal([]common.Hash{tx.Hash()}, state.Channels[0].PendingTxs[0].Hashes)
+
+	// frames sized for another DA type can't be restored
+	blobCfg := cfg
+	blobCfg.MultiFrameTxs = true
+	_, err = NewChannelManager(log, metrics.NoopMetrics, blobCfg, &rollup.Config{}).Restore(state, state.Channels, [][]*types.Block{{a}})
+	require.Error(err)
+
+	// restored blocks must match the journal
+	_, err = NewChannelManager(log, metrics.NoopMetrics, cfg, &rollup.Config{}).Restore(state, state.Channels, [][]*types.Block{{newMiniL2Block(0)}})
+	require.Error(err)
+
+	r := NewChannelManager(log, metrics.NoopMetrics, cfg, &rollup.Config{})
+	r.Clear(eth.BlockID{})
+	last, err := r.Restore(state, state.Channels, [][]*types.Block{{a}})
+	require.NoError(err)
+	r.SetJournal(restored)
+	require.Equal(eth.ToBlockID(a), last)
+
+	txs := r.PendingTxs()
+	require.Len(txs, 1)
+	require.Equal(txdata.ID().String(), txs[0].id.String())
+	require.Equal(uint64(7), txs[0].published.nonce)
+	require.Equal([]common.Hash{tx.Hash()}, txs[0].published.hashes)
+
+	// the frames of a failed restored tx get resubmitted
+	r.TxFailed(txs[0].id)
+	resubmitted, err := r.TxData(eth.BlockID{})
+	require.NoError(err)
+	require.Equal(txdata.CallData(), resubmitted.CallData())
+
+	r.TxConfirmed(resubmitted.ID(), eth.BlockID{Number: 1})
+	require.Empty(r.channelQueue)
+	require.Equal(eth.ToBlockID(a), r.lastSubmittedBlock)
+	// the files of the submitted channel are removed
+	entries, err := os.ReadDir(dir)
+	require.NoError(err)
+	require.Len(entries, 1)
+	require.Equal(journalStateFile, entries[0].Name())
+
+	// blocks must extend the restored chain
+	require.ErrorIs(r.AddL2Block(newMiniL2BlockWithNumberParent(0, big.NewInt(2), common.Hash{0xff})), ErrReorg)
+}
+
+// resumeL1Client is an L1 client on which the restored transactions are neither mined nor replaced.
+type resumeL1Client struct {
+	L1Client
+	nonce uint64
+}
+
+func (c *resumeL1Client) NonceAt(context.Context, common.Address, *big.Int) (uint64, error) {
+	return c.nonce, nil
+}
+
+func (c *resumeL1Client) TransactionReceipt(context.Context, common.Hash) (*types.Receipt, error) {
+	return nil, ethereum.NotFound
+}
+
+func TestResumePendingTxsOnlyRestored(t *testing.T) {
+	require := require.New(t)
+	log := testlog.Logger(t, log.LevelCrit)
+	cfg := channelManagerTestConfig(120_000, derive.SingularBatchType)
+	cfg.CompressorConfig.TargetOutputSize = 1 // full on first block
+	cfg.ChannelTimeout = 100
+	journal, err := NewStateJournal(filepath.Join(t.TempDir(), "journal"))
+	require.NoError(err)
+
+	m := NewChannelManager(log, metrics.NoopMetrics, cfg, &rollup.Config{})
+	m.Clear(eth.BlockID{})
+	m.SetJournal(journal)
+	a := newMiniL2BlockWithNumberParent(0, big.NewInt(1), common.Hash{})
+	require.NoError(m.AddL2Block(a))
+	txdata, err := m.TxData(eth.BlockID{})
+	require.NoError(err)
+	m.TxPublished(txdata.ID(), types.NewTx(&types.DynamicFeeTx{Nonce: 7}))
+	state, err := journal.Load()
+	require.NoError(err)
+
+	txmgr := new(mocks.TxManager)
+	txmgr.On("From").Return(common.Address{})
+	l := NewBatchSubmitter(DriverSetup{
+		Log:           log,
+		Metr:          metrics.NoopMetrics,
+		RollupConfig:  &rollup.Config{},
+		Config:        BatcherConfig{PollInterval: time.Millisecond},
+		Txmgr:         txmgr,
+		L1Client:      &resumeL1Client{nonce: 7},
+		ChannelConfig: cfg,
+	})
+	l.state.Clear(eth.BlockID{})
+	_, err = l.state.Restore(state, state.Channels, [][]*types.Block{{a}})
+	require.NoError(err)
+	restored := l.state.PendingTxs()
+	require.Len(restored, 1)
+
+	// a new tx gets queued while the restored tx is still pending
+	require.NoError(l.state.AddL2Block(newMiniL2BlockWithNumberParent(0, big.NewInt(2), a.Hash())))
+	queued, err := l.state.TxData(eth.BlockID{})
+	require.NoError(err)
+
+	// the restored tx is still pending, so it isn't resolved before the deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	l.resumePendingTxs(ctx, restored, time.Now().Add(time.Hour))
+	require.True(l.state.IsPendingTx(restored[0].id))
+	require.True(l.state.IsPendingTx(queued.ID()))
+
+	// after the deadline only the restored tx is failed, the queued tx stays pending
+	l.resumePendingTxs(context.Background(), restored, time.Now())
+	require.False(l.state.IsPendingTx(restored[0].id))
+	require.True(l.state.IsPendingTx(queued.ID()))
+}
@@ -45,6 +45,9 @@ type BatcherConfig struct {
 
 	WaitNodeSync        bool
 	CheckRecentTxsDepth int
+
+	// DACost configures the cost model that drives DA type switching, if the DA type is auto.
+	DACost DACostConfig
 }
 
 // BatcherService represents a full batch-submitter instance and its resources,
@@ -101,6 +104,7 @@ func (bs *BatcherService) initFromCLIConfig(ctx context.Context, version string,
 	bs.NetworkTimeout = cfg.TxMgrConfig.NetworkTimeout
 	bs.CheckRecentTxsDepth = cfg.CheckRecentTxsDepth
 	bs.WaitNodeSync = cfg.WaitNodeSync
+	bs.DACost = *cfg.DACostConfig()
 	if err := bs.initRPCClients(ctx, cfg); err != nil {
 		return err
 	}
@@ -124,7 +128,9 @@ func (bs *BatcherService) initFromCLIConfig(ctx context.Context, version string,
 	if err := bs.initPlasmaDA(cfg); err != nil {
 		return fmt.Errorf("failed to init plasma DA: %w", err)
 	}
-	bs.initDriver(cfg)
+	if err := bs.initDriver(cfg); err != nil {
+		return fmt.Errorf("failed to init driver: %w", err)
+	}
 	if err := bs.initRPCServer(cfg); err != nil {
 		return fmt.Errorf("failed to start RPC server: %w", err)
 	}
@@ -297,7 +303,14 @@ func (bs *BatcherService) initMetricsServer(cfg *CLIConfig) error {
 	return nil
 }
 
-func (bs *BatcherService) initDriver(cfg *CLIConfig) {
+func (bs *BatcherService) initDriver(cfg *CLIConfig) error {
+	var journal *StateJournal
+	if cfg.StateJournal != "" {
+		var err error
+		if journal, err = NewStateJournal(cfg.StateJournal); err != nil {
+			return err
+		}
+	}
 	bs.driver = NewBatchSubmitter(DriverSetup{
 		Log:              bs.Log,
 		Metr:             bs.Metrics,
@@ -309,7 +322,9 @@ func (bs *BatcherService) initDriver(cfg *CLIConfig) {
 		ChannelConfig:    bs.ChannelConfig,
 		PlasmaDA:         bs.PlasmaDA,
 		AutoSwitchDA:     cfg.DataAvailabilityType == flags.AutoType,
+		Journal:          journal,
 	})
+	return nil
 }
 
 func (bs *BatcherService) initRPCServer(cfg *CLIConfig) error {
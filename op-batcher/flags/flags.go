@@ -151,6 +151,33 @@ var (
 		Value:   false,
 		EnvVars: prefixEnvVars("WAIT_NODE_SYNC"),
 	}
+	DASwitchCheckIntervalFlag = &cli.DurationFlag{
+		Name:    "da-switch-check-interval",
+		Usage:   "The interval between evaluations of the DA cost model, when the data availability type is auto.",
+		Value:   5 * time.Second,
+		EnvVars: prefixEnvVars("DA_SWITCH_CHECK_INTERVAL"),
+	}
+	DASwitchHysteresisFlag = &cli.Float64Flag{
+		Name: "da-switch-hysteresis",
+		Usage: "The fraction by which the other data availability type must be cheaper than the current one " +
+			"to count towards a switch, when the data availability type is auto.",
+		Value:   0.1,
+		EnvVars: prefixEnvVars("DA_SWITCH_HYSTERESIS"),
+	}
+	DASwitchConfirmationsFlag = &cli.IntFlag{
+		Name: "da-switch-confirmations",
+		Usage: "The number of consecutive DA cost model evaluations that must favor the other data availability type " +
+			"before switching, when the data availability type is auto.",
+		Value:   3,
+		EnvVars: prefixEnvVars("DA_SWITCH_CONFIRMATIONS"),
+	}
+	StateJournalFlag = &cli.StringFlag{
+		Name: "state-journal",
+		Usage: "Directory of the journal of in-flight channels and their transactions, which allows to resume " +
+			"their submission after a restart instead of resubmitting them. Disabled if empty. " +
+			"Can't be combined with the txmgr journal.",
+		EnvVars: prefixEnvVars("STATE_JOURNAL"),
+	}
 	// Legacy Flags
 	SequencerHDPathFlag = txmgr.SequencerHDPathFlag
 )
@@ -178,6 +205,10 @@ var optionalFlags = []cli.Flag{
 	DataAvailabilityTypeFlag,
 	ActiveSequencerCheckDurationFlag,
 	CompressionAlgoFlag,
+	DASwitchCheckIntervalFlag,
+	DASwitchHysteresisFlag,
+	DASwitchConfirmationsFlag,
+	StateJournalFlag,
 }
 
 func init() {
@@ -57,6 +57,7 @@ type Metricer interface {
 	RecordAutoSwitchTimeDuration(duration time.Duration)
 	RecordEstimatedCalldataTypeFee(fee *big.Int)
 	RecordEstimatedBlobTypeFee(fee *big.Int)
+	RecordDATypeDecision(current, cheapest flags.DataAvailabilityType, decision string, pendingBytes int)
 
 	Document() []opmetrics.DocumentedMetric
 }
@@ -99,6 +100,8 @@ type Metrics struct {
 	autoSwitchTimeDuration   prometheus.Gauge
 	estimatedCalldataTypeFee prometheus.Gauge
 	estimatedBlobTypeFee     prometheus.Gauge
+	daTypeDecisions          *prometheus.CounterVec
+	daPendingBytes           prometheus.Gauge
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -230,12 +233,22 @@ func NewMetrics(procName string) *Metrics {
 		estimatedCalldataTypeFee: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: ns,
 			Name:      "estimated_calldata_type_fee",
-			Help:      "Current estimated fee in gwei of calldata type(6 txes) by auto switch routine",
+			Help:      "Current estimated fee in gwei of submitting the pending data with calldata, by auto switch routine",
 		}),
 		estimatedBlobTypeFee: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: ns,
 			Name:      "estimated_blob_type_fee",
-			Help:      "Current estimated fee in gwei of blob type(1 tx with 6 blobs) by auto switch routine",
+			Help:      "Current estimated fee in gwei of submitting the pending data with blobs, by auto switch routine",
+		}),
+		daTypeDecisions: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "da_type_decisions_total",
+			Help:      "Number of DA type decisions by auto switch routine, by current DA type, cheapest DA type and decision",
+		}, []string{"current", "cheapest", "decision"}),
+		daPendingBytes: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "da_pending_bytes",
+			Help:      "Compressed channel data pending submission, as evaluated by auto switch routine",
 		}),
 
 		batcherTxEvs: opmetrics.NewEventVec(factory, ns, "", "batcher_tx", "BatcherTx", []string{"stage"}),
@@ -392,6 +405,11 @@ func (m *Metrics) RecordEstimatedBlobTypeFee(fee *big.Int) {
 	m.estimatedBlobTypeFee.Set(float64(fee.Uint64()) / params.GWei)
 }
 
+func (m *Metrics) RecordDATypeDecision(current, cheapest flags.DataAvailabilityType, decision string, pendingBytes int) {
+	m.daTypeDecisions.WithLabelValues(current.String(), cheapest.String(), decision).Inc()
+	m.daPendingBytes.Set(float64(pendingBytes))
+}
+
 // estimateBatchSize estimates the size of the batch
 func estimateBatchSize(block *types.Block) uint64 {
 	size := uint64(70) // estimated overhead of batch metadata
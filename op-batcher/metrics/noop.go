@@ -56,6 +56,8 @@ func (*noopMetrics) RecordReservedErrorSwitchCount()                           {
 func (*noopMetrics) RecordAutoSwitchTimeDuration(duration time.Duration)       {}
 func (*noopMetrics) RecordEstimatedCalldataTypeFee(fee *big.Int)               {}
 func (*noopMetrics) RecordEstimatedBlobTypeFee(fee *big.Int)                   {}
+func (*noopMetrics) RecordDATypeDecision(current, cheapest flags.DataAvailabilityType, decision string, pendingBytes int) {
+}
 
 func (m *noopMetrics) RecordL1UrlSwitchEvt(url string) {
 }
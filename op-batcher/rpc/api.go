@@ -6,6 +6,9 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/rpc"
 )
@@ -13,6 +16,31 @@ import (
 type BatcherDriver interface {
 	StartBatchSubmitting() error
 	StopBatchSubmitting(ctx context.Context) error
+	PendingChannels() []ChannelInfo
+	PendingBlocks() []eth.BlockID
+	ForceCloseChannel() (*ChannelInfo, error)
+	DropChannel(id derive.ChannelID) error
+}
+
+// ChannelInfo describes a channel in the channel pipeline of the batcher.
+type ChannelInfo struct {
+	ID derive.ChannelID `json:"id"`
+	// DAType is the data availability type the frames of the channel are sized for.
+	DAType flags.DataAvailabilityType `json:"daType"`
+	// Blocks are the L2 blocks that got added to the channel.
+	Blocks      []eth.BlockID `json:"blocks"`
+	InputBytes  int           `json:"inputBytes"`
+	OutputBytes int           `json:"outputBytes"`
+	// TotalFrames is the number of frames created yet, PendingFrames the number of frames not handed out for sending.
+	TotalFrames   int `json:"totalFrames"`
+	PendingFrames int `json:"pendingFrames"`
+	PendingTxs    int `json:"pendingTxs"`
+	ConfirmedTxs  int `json:"confirmedTxs"`
+	// Timeout is the L1 block number at which the channel times out, or 0 if there is no timeout yet.
+	Timeout uint64 `json:"timeout"`
+	// Full is true if the channel is closed for new blocks, with FullReason being the reason.
+	Full       bool   `json:"full"`
+	FullReason string `json:"fullReason,omitempty"`
 }
 
 type adminAPI struct {
@@ -41,3 +69,23 @@ func (a *adminAPI) StartBatcher(_ context.Context) error {
 func (a *adminAPI) StopBatcher(ctx context.Context) error {
 	return a.b.StopBatchSubmitting(ctx)
 }
+
+// PendingChannels returns all channels that are not fully submitted yet, in submission order.
+func (a *adminAPI) PendingChannels(_ context.Context) ([]ChannelInfo, error) {
+	return a.b.PendingChannels(), nil
+}
+
+// PendingBlocks returns the L2 blocks that are loaded into the batcher, but not added to a channel yet.
+func (a *adminAPI) PendingBlocks(_ context.Context) ([]eth.BlockID, error) {
+	return a.b.PendingBlocks(), nil
+}
+
+// ForceCloseChannel closes the current channel, so its remaining frames get submitted right away.
+func (a *adminAPI) ForceCloseChannel(_ context.Context) (*ChannelInfo, error) {
+	return a.b.ForceCloseChannel()
+}
+
+// DropChannel drops a channel from the channel pipeline. Its blocks get resubmitted in a new channel.
+func (a *adminAPI) DropChannel(_ context.Context, id derive.ChannelID) error {
+	return a.b.DropChannel(id)
+}
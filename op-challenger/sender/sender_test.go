@@ -170,5 +170,13 @@ func (s *stubTxMgr) BlockNumber(_ context.Context) (uint64, error) {
 	panic("unsupported")
 }
 
+func (s *stubTxMgr) Cancel(_ context.Context, _ uint64) (*types.Receipt, error) {
+	panic("unsupported")
+}
+
+func (s *stubTxMgr) Replace(_ context.Context, _ uint64, _ txmgr.TxCandidate) (*types.Receipt, error) {
+	panic("unsupported")
+}
+
 func (s *stubTxMgr) Close() {
 }
@@ -3,12 +3,17 @@ package conductor
 import (
 	"fmt"
 	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/pkg/errors"
 	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-conductor/flags"
+	"github.com/ethereum-optimism/optimism/op-conductor/health"
 	opnode "github.com/ethereum-optimism/optimism/op-node"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
@@ -33,6 +38,15 @@ type Config struct {
 	// RaftBootstrap is true if this node should bootstrap a new raft cluster.
 	RaftBootstrap bool
 
+	// RaftSnapshotInterval is how often raft checks if it should take a snapshot.
+	RaftSnapshotInterval time.Duration
+
+	// RaftSnapshotThreshold is the number of logs committed since the last snapshot that triggers a new one.
+	RaftSnapshotThreshold uint64
+
+	// RaftTrailingLogs is the number of logs kept after a snapshot, so followers can catch up without installing the snapshot.
+	RaftTrailingLogs uint64
+
 	// NodeRPC is the HTTP provider URL for op-node.
 	NodeRPC string
 
@@ -106,21 +120,42 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*Config, error) {
 		return nil, errors.Wrap(err, "failed to load rollup config")
 	}
 
+	weights, err := parseCheckWeights(ctx.StringSlice(flags.HealthCheckWeights.Name))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid health check weights")
+	}
+	gracePeriods, err := parseCheckGracePeriods(ctx.StringSlice(flags.HealthCheckGracePeriods.Name))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid health check grace periods")
+	}
+
 	return &Config{
-		ConsensusAddr:  ctx.String(flags.ConsensusAddr.Name),
-		ConsensusPort:  ctx.Int(flags.ConsensusPort.Name),
-		RaftBootstrap:  ctx.Bool(flags.RaftBootstrap.Name),
-		RaftServerID:   ctx.String(flags.RaftServerID.Name),
-		RaftStorageDir: ctx.String(flags.RaftStorageDir.Name),
-		NodeRPC:        ctx.String(flags.NodeRPC.Name),
-		ExecutionRPC:   ctx.String(flags.ExecutionRPC.Name),
-		Paused:         ctx.Bool(flags.Paused.Name),
+		ConsensusAddr:         ctx.String(flags.ConsensusAddr.Name),
+		ConsensusPort:         ctx.Int(flags.ConsensusPort.Name),
+		RaftBootstrap:         ctx.Bool(flags.RaftBootstrap.Name),
+		RaftServerID:          ctx.String(flags.RaftServerID.Name),
+		RaftStorageDir:        ctx.String(flags.RaftStorageDir.Name),
+		RaftSnapshotInterval:  ctx.Duration(flags.RaftSnapshotInterval.Name),
+		RaftSnapshotThreshold: ctx.Uint64(flags.RaftSnapshotThreshold.Name),
+		RaftTrailingLogs:      ctx.Uint64(flags.RaftTrailingLogs.Name),
+		NodeRPC:               ctx.String(flags.NodeRPC.Name),
+		ExecutionRPC:          ctx.String(flags.ExecutionRPC.Name),
+		Paused:                ctx.Bool(flags.Paused.Name),
 		HealthCheck: HealthCheckConfig{
-			Interval:       ctx.Uint64(flags.HealthCheckInterval.Name),
-			UnsafeInterval: ctx.Uint64(flags.HealthCheckUnsafeInterval.Name),
-			SafeEnabled:    ctx.Bool(flags.HealthCheckSafeEnabled.Name),
-			SafeInterval:   ctx.Uint64(flags.HealthCheckSafeInterval.Name),
-			MinPeerCount:   ctx.Uint64(flags.HealthCheckMinPeerCount.Name),
+			Interval:          ctx.Uint64(flags.HealthCheckInterval.Name),
+			UnsafeInterval:    ctx.Uint64(flags.HealthCheckUnsafeInterval.Name),
+			SafeEnabled:       ctx.Bool(flags.HealthCheckSafeEnabled.Name),
+			SafeInterval:      ctx.Uint64(flags.HealthCheckSafeInterval.Name),
+			MinPeerCount:      ctx.Uint64(flags.HealthCheckMinPeerCount.Name),
+			MaxBlockDeficit:   ctx.Uint64(flags.HealthCheckMaxBlockDeficit.Name),
+			Checks:            ctx.StringSlice(flags.HealthCheckChecks.Name),
+			Weights:           weights,
+			GracePeriods:      gracePeriods,
+			UnhealthyWeight:   ctx.Uint64(flags.HealthCheckUnhealthyWeight.Name),
+			L1RPC:             ctx.String(flags.HealthCheckL1RPC.Name),
+			BatcherRPC:        ctx.String(flags.HealthCheckBatcherRPC.Name),
+			MaxBatcherBacklog: ctx.Uint64(flags.HealthCheckMaxBatcherBacklog.Name),
+			MaxTxPoolSize:     ctx.Uint64(flags.HealthCheckMaxTxPoolSize.Name),
 		},
 		RollupCfg:      *rollupCfg,
 		RPCEnableProxy: ctx.Bool(flags.RPCEnableProxy.Name),
@@ -147,6 +182,34 @@ type HealthCheckConfig struct {
 
 	// MinPeerCount is the minimum number of peers required for the sequencer to be healthy.
 	MinPeerCount uint64
+
+	// MaxBlockDeficit is the number of blocks the unsafe head may fall behind the block time schedule,
+	// which follows the millisecond block intervals since Volta.
+	MaxBlockDeficit uint64
+
+	// Checks are the additional checks to enable, see health.AdditionalChecks.
+	Checks []string
+
+	// Weights are the weights of the checks by name, a failing check weighs 1 by default.
+	Weights map[string]uint64
+
+	// GracePeriods are how long the checks may be failing before they weigh, by name.
+	GracePeriods map[string]time.Duration
+
+	// UnhealthyWeight is the total weight of failing checks at which the sequencer is unhealthy.
+	UnhealthyWeight uint64
+
+	// L1RPC is the L1 RPC checked by the l1_rpc check.
+	L1RPC string
+
+	// BatcherRPC is the batcher admin RPC queried by the batcher_backlog check.
+	BatcherRPC string
+
+	// MaxBatcherBacklog is the maximum number of blocks pending in the batcher.
+	MaxBatcherBacklog uint64
+
+	// MaxTxPoolSize is the maximum number of pending and queued txs in the sequencer txpool.
+	MaxTxPoolSize uint64
 }
 
 func (c *HealthCheckConfig) Check() error {
@@ -159,5 +222,63 @@ func (c *HealthCheckConfig) Check() error {
 	if c.MinPeerCount == 0 {
 		return fmt.Errorf("missing minimum peer count")
 	}
+	if c.MaxBlockDeficit == 0 {
+		return fmt.Errorf("missing max block deficit")
+	}
+	for _, name := range c.Checks {
+		if !slices.Contains(health.AdditionalChecks, name) {
+			return fmt.Errorf("unknown health check %s", name)
+		}
+	}
+	for name := range c.Weights {
+		if !slices.Contains(health.BuiltinChecks, name) && !slices.Contains(health.AdditionalChecks, name) {
+			return fmt.Errorf("weight of unknown health check %s", name)
+		}
+	}
+	for name := range c.GracePeriods {
+		if !slices.Contains(health.BuiltinChecks, name) && !slices.Contains(health.AdditionalChecks, name) {
+			return fmt.Errorf("grace period of unknown health check %s", name)
+		}
+	}
+	if slices.Contains(c.Checks, health.CheckL1RPC) && c.L1RPC == "" {
+		return fmt.Errorf("missing l1 rpc for the %s health check", health.CheckL1RPC)
+	}
+	if slices.Contains(c.Checks, health.CheckBatcherBacklog) && c.BatcherRPC == "" {
+		return fmt.Errorf("missing batcher rpc for the %s health check", health.CheckBatcherBacklog)
+	}
 	return nil
 }
+
+// parseCheckWeights parses the check=weight pairs of the health check weights flag.
+func parseCheckWeights(pairs []string) (map[string]uint64, error) {
+	weights := make(map[string]uint64)
+	for _, pair := range pairs {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("expected check=weight, got %q", pair)
+		}
+		weight, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid weight of %s: %w", name, err)
+		}
+		weights[name] = weight
+	}
+	return weights, nil
+}
+
+// parseCheckGracePeriods parses the check=duration pairs of the health check grace periods flag.
+func parseCheckGracePeriods(pairs []string) (map[string]time.Duration, error) {
+	gracePeriods := make(map[string]time.Duration)
+	for _, pair := range pairs {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("expected check=duration, got %q", pair)
+		}
+		gracePeriod, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid grace period of %s: %w", name, err)
+		}
+		gracePeriods[name] = gracePeriod
+	}
+	return gracePeriods, nil
+}
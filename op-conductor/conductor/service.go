@@ -150,7 +150,17 @@ func (c *OpConductor) initConsensus(ctx context.Context) error {
 	}
 
 	serverAddr := fmt.Sprintf("%s:%d", c.cfg.ConsensusAddr, c.cfg.ConsensusPort)
-	cons, err := consensus.NewRaftConsensus(c.log, c.cfg.RaftServerID, serverAddr, c.cfg.RaftStorageDir, c.cfg.RaftBootstrap, &c.cfg.RollupCfg)
+	raftConsensusConfig := &consensus.RaftConsensusConfig{
+		ServerID:          c.cfg.RaftServerID,
+		ServerAddr:        serverAddr,
+		StorageDir:        c.cfg.RaftStorageDir,
+		Bootstrap:         c.cfg.RaftBootstrap,
+		RollupCfg:         &c.cfg.RollupCfg,
+		SnapshotInterval:  c.cfg.RaftSnapshotInterval,
+		SnapshotThreshold: c.cfg.RaftSnapshotThreshold,
+		TrailingLogs:      c.cfg.RaftTrailingLogs,
+	}
+	cons, err := consensus.NewRaftConsensus(c.log, raftConsensusConfig)
 	if err != nil {
 		return errors.Wrap(err, "failed to create raft consensus")
 	}
@@ -176,6 +186,11 @@ func (c *OpConductor) initHealthMonitor(ctx context.Context) error {
 	}
 	p2p := opp2p.NewClient(pc)
 
+	registry, err := c.initHealthCheckRegistry(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to create health check registry")
+	}
+
 	c.hmon = health.NewSequencerHealthMonitor(
 		c.log,
 		c.metrics,
@@ -183,16 +198,80 @@ func (c *OpConductor) initHealthMonitor(ctx context.Context) error {
 		c.cfg.HealthCheck.UnsafeInterval,
 		c.cfg.HealthCheck.SafeInterval,
 		c.cfg.HealthCheck.MinPeerCount,
+		c.cfg.HealthCheck.MaxBlockDeficit,
 		c.cfg.HealthCheck.SafeEnabled,
 		&c.cfg.RollupCfg,
 		node,
 		p2p,
+		registry,
 	)
 	c.healthUpdateCh = c.hmon.Subscribe()
 
 	return nil
 }
 
+func (c *OpConductor) initHealthCheckRegistry(ctx context.Context) (*health.Registry, error) {
+	hc := c.cfg.HealthCheck
+	registry := health.NewRegistry(hc.UnhealthyWeight)
+	for name, weight := range hc.Weights {
+		opts := registry.Options(name)
+		opts.Weight = weight
+		registry.SetOptions(name, opts)
+	}
+	for name, gracePeriod := range hc.GracePeriods {
+		opts := registry.Options(name)
+		opts.GracePeriod = gracePeriod
+		registry.SetOptions(name, opts)
+	}
+
+	// the execution sync and txpool checks share the execution rpc client.
+	var ec opclient.RPC
+	executionRPC := func() (opclient.RPC, error) {
+		if ec != nil {
+			return ec, nil
+		}
+		var err error
+		ec, err = opclient.NewRPC(ctx, c.log, c.cfg.ExecutionRPC)
+		return ec, err
+	}
+
+	for _, name := range hc.Checks {
+		var check health.HealthCheck
+		switch name {
+		case health.CheckExecutionSync, health.CheckTxPoolSize:
+			ec, err := executionRPC()
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to create execution rpc client")
+			}
+			if name == health.CheckExecutionSync {
+				check = health.NewExecutionSyncCheck(c.log, ec)
+			} else {
+				check = health.NewTxPoolCheck(c.log, ec, hc.MaxTxPoolSize)
+			}
+		// the l1 and batcher rpcs are dialed without the connection check of opclient.NewRPC,
+		// so an unreachable endpoint is reported by their checks rather than failing the startup.
+		case health.CheckL1RPC:
+			lc, err := rpc.DialContext(ctx, hc.L1RPC)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to create l1 rpc client")
+			}
+			check = health.NewL1RPCCheck(c.log, opclient.NewBaseRPCClient(lc))
+		case health.CheckBatcherBacklog:
+			bc, err := rpc.DialContext(ctx, hc.BatcherRPC)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to create batcher rpc client")
+			}
+			check = health.NewBatcherBacklogCheck(c.log, opclient.NewBaseRPCClient(bc), hc.MaxBatcherBacklog)
+		default:
+			return nil, fmt.Errorf("unknown health check %s", name)
+		}
+		if err := registry.Register(check); err != nil {
+			return nil, err
+		}
+	}
+	return registry, nil
+}
+
 func (oc *OpConductor) initRPCServer(ctx context.Context) error {
 	server := oprpc.NewServer(
 		oc.cfg.RPC.ListenAddr,
@@ -484,6 +563,11 @@ func (oc *OpConductor) SequencerHealthy(_ context.Context) bool {
 	return oc.healthy.Load()
 }
 
+// SequencerHealthReport returns the per-check results of the latest sequencer health check.
+func (oc *OpConductor) SequencerHealthReport(_ context.Context) *health.HealthReport {
+	return oc.hmon.LatestReport()
+}
+
 // ClusterMembership returns current cluster's membership information.
 func (oc *OpConductor) ClusterMembership(_ context.Context) ([]*consensus.ServerInfo, error) {
 	return oc.cons.ClusterMembership()
@@ -494,6 +578,16 @@ func (oc *OpConductor) LatestUnsafePayload(_ context.Context) (*eth.ExecutionPay
 	return oc.cons.LatestUnsafePayload()
 }
 
+// TakeSnapshot snapshots the raft FSM and compacts the raft log.
+func (oc *OpConductor) TakeSnapshot(_ context.Context) error {
+	return oc.cons.TakeSnapshot()
+}
+
+// RaftStorageStats returns the size of the raft storage along with the raft log indexes.
+func (oc *OpConductor) RaftStorageStats(_ context.Context) (*consensus.StorageStats, error) {
+	return oc.cons.StorageStats()
+}
+
 func (oc *OpConductor) loop() {
 	defer oc.wg.Done()
 
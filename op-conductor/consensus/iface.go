@@ -32,6 +32,23 @@ type ServerInfo struct {
 	Suffrage ServerSuffrage `json:"suffrage"`
 }
 
+// StorageStats defines the size of the raft storage, along with the raft log indexes.
+type StorageStats struct {
+	// LogStoreSize is the size in bytes of the raft log store.
+	LogStoreSize uint64 `json:"logStoreSize"`
+	// StableStoreSize is the size in bytes of the raft stable store.
+	StableStoreSize uint64 `json:"stableStoreSize"`
+	// SnapshotsSize is the size in bytes of the retained snapshots.
+	SnapshotsSize uint64 `json:"snapshotsSize"`
+
+	// LastIndex is the index of the last log entry, either stored or snapshotted.
+	LastIndex uint64 `json:"lastIndex"`
+	// AppliedIndex is the index of the last log entry applied to the FSM.
+	AppliedIndex uint64 `json:"appliedIndex"`
+	// LastSnapshotIndex is the index of the last log entry included in a snapshot.
+	LastSnapshotIndex uint64 `json:"lastSnapshotIndex"`
+}
+
 // Consensus defines the consensus interface for leadership election.
 //
 //go:generate mockery --name Consensus --output mocks/ --with-expecter=true
@@ -64,6 +81,11 @@ type Consensus interface {
 	// LatestUnsafeBlock returns the latest unsafe payload from FSM in a strongly consistent fashion.
 	LatestUnsafePayload() (*eth.ExecutionPayloadEnvelope, error)
 
+	// TakeSnapshot snapshots the FSM and compacts the log entries that precede the snapshot.
+	TakeSnapshot() error
+	// StorageStats returns the size of the consensus storage.
+	StorageStats() (*StorageStats, error)
+
 	// Shutdown shuts down the consensus protocol client.
 	Shutdown() error
 }
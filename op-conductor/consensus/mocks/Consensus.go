@@ -597,6 +597,108 @@ func (_c *Consensus_Shutdown_Call) RunAndReturn(run func() error) *Consensus_Shu
 	return _c
 }
 
+// StorageStats provides a mock function with given fields:
+func (_m *Consensus) StorageStats() (*consensus.StorageStats, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for StorageStats")
+	}
+
+	var r0 *consensus.StorageStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*consensus.StorageStats, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *consensus.StorageStats); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*consensus.StorageStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Consensus_StorageStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StorageStats'
+type Consensus_StorageStats_Call struct {
+	*mock.Call
+}
+
+// StorageStats is a helper method to define mock.On call
+func (_e *Consensus_Expecter) StorageStats() *Consensus_StorageStats_Call {
+	return &Consensus_StorageStats_Call{Call: _e.mock.On("StorageStats")}
+}
+
+func (_c *Consensus_StorageStats_Call) Run(run func()) *Consensus_StorageStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Consensus_StorageStats_Call) Return(_a0 *consensus.StorageStats, _a1 error) *Consensus_StorageStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Consensus_StorageStats_Call) RunAndReturn(run func() (*consensus.StorageStats, error)) *Consensus_StorageStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TakeSnapshot provides a mock function with given fields:
+func (_m *Consensus) TakeSnapshot() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for TakeSnapshot")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Consensus_TakeSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TakeSnapshot'
+type Consensus_TakeSnapshot_Call struct {
+	*mock.Call
+}
+
+// TakeSnapshot is a helper method to define mock.On call
+func (_e *Consensus_Expecter) TakeSnapshot() *Consensus_TakeSnapshot_Call {
+	return &Consensus_TakeSnapshot_Call{Call: _e.mock.On("TakeSnapshot")}
+}
+
+func (_c *Consensus_TakeSnapshot_Call) Run(run func()) *Consensus_TakeSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Consensus_TakeSnapshot_Call) Return(_a0 error) *Consensus_TakeSnapshot_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Consensus_TakeSnapshot_Call) RunAndReturn(run func() error) *Consensus_TakeSnapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // TransferLeader provides a mock function with given fields:
 func (_m *Consensus) TransferLeader() error {
 	ret := _m.Called()
@@ -6,6 +6,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"strconv"
 	"time"
 
 	"github.com/ethereum/go-ethereum/log"
@@ -27,17 +28,44 @@ type RaftConsensus struct {
 	rollupCfg *rollup.Config
 
 	serverID raft.ServerID
+	baseDir  string
 	r        *raft.Raft
 
 	unsafeTracker *unsafeHeadTracker
 }
 
+// RaftConsensusConfig is the configuration of a RaftConsensus.
+type RaftConsensusConfig struct {
+	ServerID   string
+	ServerAddr string
+	StorageDir string
+	Bootstrap  bool
+	RollupCfg  *rollup.Config
+
+	// SnapshotInterval is how often raft checks if it should take a snapshot, 0 keeps the raft default.
+	SnapshotInterval time.Duration
+	// SnapshotThreshold is the number of logs committed since the last snapshot that triggers a new one, 0 keeps the raft default.
+	SnapshotThreshold uint64
+	// TrailingLogs is the number of logs kept after a snapshot, 0 keeps the raft default.
+	TrailingLogs uint64
+}
+
 // NewRaftConsensus creates a new RaftConsensus instance.
-func NewRaftConsensus(log log.Logger, serverID, serverAddr, storageDir string, bootstrap bool, rollupCfg *rollup.Config) (*RaftConsensus, error) {
+func NewRaftConsensus(log log.Logger, cfg *RaftConsensusConfig) (*RaftConsensus, error) {
 	rc := raft.DefaultConfig()
-	rc.LocalID = raft.ServerID(serverID)
+	rc.LocalID = raft.ServerID(cfg.ServerID)
+	// the FSM only tracks the latest unsafe payload, so logs can be compacted aggressively.
+	if cfg.SnapshotInterval != 0 {
+		rc.SnapshotInterval = cfg.SnapshotInterval
+	}
+	if cfg.SnapshotThreshold != 0 {
+		rc.SnapshotThreshold = cfg.SnapshotThreshold
+	}
+	if cfg.TrailingLogs != 0 {
+		rc.TrailingLogs = cfg.TrailingLogs
+	}
 
-	baseDir := filepath.Join(storageDir, serverID)
+	baseDir := filepath.Join(cfg.StorageDir, cfg.ServerID)
 	if _, err := os.Stat(baseDir); os.IsNotExist(err) {
 		if err := os.MkdirAll(baseDir, 0o755); err != nil {
 			return nil, fmt.Errorf("error creating storage dir: %w", err)
@@ -62,7 +90,7 @@ func NewRaftConsensus(log log.Logger, serverID, serverAddr, storageDir string, b
 		return nil, fmt.Errorf(`raft.NewFileSnapshotStore(%q): %w`, baseDir, err)
 	}
 
-	addr, err := net.ResolveTCPAddr("tcp", serverAddr)
+	addr, err := net.ResolveTCPAddr("tcp", cfg.ServerAddr)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to resolve tcp address")
 	}
@@ -85,18 +113,18 @@ func NewRaftConsensus(log log.Logger, serverID, serverAddr, storageDir string, b
 
 	// If bootstrap = true, start raft in bootstrap mode, this will allow the current node to elect itself as leader when there's no other participants
 	// and allow other nodes to join the cluster.
-	if bootstrap {
-		cfg := raft.Configuration{
+	if cfg.Bootstrap {
+		raftCfg := raft.Configuration{
 			Servers: []raft.Server{
 				{
 					ID:       rc.LocalID,
-					Address:  raft.ServerAddress(serverAddr),
+					Address:  raft.ServerAddress(cfg.ServerAddr),
 					Suffrage: raft.Voter,
 				},
 			},
 		}
 
-		f := r.BootstrapCluster(cfg)
+		f := r.BootstrapCluster(raftCfg)
 		if err := f.Error(); err != nil {
 			return nil, errors.Wrap(err, "failed to bootstrap raft cluster")
 		}
@@ -105,9 +133,10 @@ func NewRaftConsensus(log log.Logger, serverID, serverAddr, storageDir string, b
 	return &RaftConsensus{
 		log:           log,
 		r:             r,
-		serverID:      raft.ServerID(serverID),
+		serverID:      raft.ServerID(cfg.ServerID),
+		baseDir:       baseDir,
 		unsafeTracker: fsm,
-		rollupCfg:     rollupCfg,
+		rollupCfg:     cfg.RollupCfg,
 	}, nil
 }
 
@@ -248,3 +277,64 @@ func (rc *RaftConsensus) ClusterMembership() ([]*ServerInfo, error) {
 	}
 	return servers, nil
 }
+
+// TakeSnapshot implements Consensus, it snapshots the FSM and compacts the raft log.
+func (rc *RaftConsensus) TakeSnapshot() error {
+	if err := rc.r.Snapshot().Error(); err != nil {
+		// Expected error if nothing got committed since the last snapshot
+		if errors.Is(err, raft.ErrNothingNewToSnapshot) {
+			return nil
+		}
+
+		rc.log.Error("failed to take snapshot", "err", err)
+		return err
+	}
+	return nil
+}
+
+// StorageStats implements Consensus, it returns the size of the raft storage and the raft log indexes.
+func (rc *RaftConsensus) StorageStats() (*StorageStats, error) {
+	logStoreSize, err := dirSize(filepath.Join(rc.baseDir, "raft-log.db"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get log store size")
+	}
+	stableStoreSize, err := dirSize(filepath.Join(rc.baseDir, "raft-stable.db"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get stable store size")
+	}
+	snapshotsSize, err := dirSize(filepath.Join(rc.baseDir, "snapshots"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get snapshots size")
+	}
+	lastSnapshotIndex, err := strconv.ParseUint(rc.r.Stats()["last_snapshot_index"], 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse last snapshot index")
+	}
+
+	return &StorageStats{
+		LogStoreSize:      logStoreSize,
+		StableStoreSize:   stableStoreSize,
+		SnapshotsSize:     snapshotsSize,
+		LastIndex:         rc.r.LastIndex(),
+		AppliedIndex:      rc.r.AppliedIndex(),
+		LastSnapshotIndex: lastSnapshotIndex,
+	}, nil
+}
+
+// dirSize returns the total size in bytes of the files under path, which may also be a single file.
+func dirSize(path string) (uint64, error) {
+	var size uint64
+	err := filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			size += uint64(info.Size())
+		}
+		return nil
+	})
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	return size, err
+}
@@ -18,19 +18,21 @@ import (
 
 func TestCommitAndRead(t *testing.T) {
 	log := testlog.Logger(t, log.LevelInfo)
-	serverID := "SequencerA"
-	serverAddr := "127.0.0.1:0"
-	bootstrap := true
 	now := uint64(time.Now().Unix())
-	rollupCfg := &rollup.Config{
-		CanyonTime: &now,
+	raftConsensusConfig := &RaftConsensusConfig{
+		ServerID:   "SequencerA",
+		ServerAddr: "127.0.0.1:0",
+		StorageDir: "/tmp/sequencerA",
+		Bootstrap:  true,
+		RollupCfg: &rollup.Config{
+			CanyonTime: &now,
+		},
 	}
-	storageDir := "/tmp/sequencerA"
-	if err := os.RemoveAll(storageDir); err != nil {
+	if err := os.RemoveAll(raftConsensusConfig.StorageDir); err != nil {
 		t.Fatal(err)
 	}
 
-	cons, err := NewRaftConsensus(log, serverID, serverAddr, storageDir, bootstrap, rollupCfg)
+	cons, err := NewRaftConsensus(log, raftConsensusConfig)
 	require.NoError(t, err)
 
 	// wait till it became leader
@@ -74,3 +76,61 @@ func TestCommitAndRead(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, payload, unsafeHead)
 }
+
+func TestSnapshotAndStorageStats(t *testing.T) {
+	log := testlog.Logger(t, log.LevelInfo)
+	raftConsensusConfig := &RaftConsensusConfig{
+		ServerID:          "SequencerA",
+		ServerAddr:        "127.0.0.1:0",
+		StorageDir:        t.TempDir(),
+		Bootstrap:         true,
+		RollupCfg:         &rollup.Config{},
+		SnapshotInterval:  time.Hour,
+		SnapshotThreshold: 1 << 20,
+		TrailingLogs:      1,
+	}
+
+	cons, err := NewRaftConsensus(log, raftConsensusConfig)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, cons.Shutdown()) })
+
+	// wait till it became leader
+	<-cons.LeaderCh()
+
+	hash := common.HexToHash("0x12345")
+	for i := 1; i <= 3; i++ {
+		payload := &eth.ExecutionPayloadEnvelope{
+			ParentBeaconBlockRoot: &hash,
+			ExecutionPayload: &eth.ExecutionPayload{
+				BlockNumber:   hexutil.Uint64(i),
+				Transactions:  []eth.Data{},
+				ExtraData:     []byte{},
+				Withdrawals:   &types.Withdrawals{},
+				ExcessBlobGas: new(hexutil.Uint64),
+				BlobGasUsed:   new(hexutil.Uint64),
+			},
+		}
+		require.NoError(t, cons.CommitUnsafePayload(payload))
+	}
+
+	stats, err := cons.StorageStats()
+	require.NoError(t, err)
+	require.Zero(t, stats.LastSnapshotIndex)
+	require.Zero(t, stats.SnapshotsSize)
+	require.NotZero(t, stats.LogStoreSize)
+	require.NotZero(t, stats.StableStoreSize)
+	require.Equal(t, stats.LastIndex, stats.AppliedIndex)
+
+	require.NoError(t, cons.TakeSnapshot())
+	stats, err = cons.StorageStats()
+	require.NoError(t, err)
+	require.Equal(t, stats.LastIndex, stats.LastSnapshotIndex)
+	require.NotZero(t, stats.SnapshotsSize)
+
+	// nothing new to snapshot is not an error
+	require.NoError(t, cons.TakeSnapshot())
+
+	unsafeHead, err := cons.LatestUnsafePayload()
+	require.NoError(t, err)
+	require.Equal(t, hexutil.Uint64(3), unsafeHead.ExecutionPayload.BlockNumber)
+}
@@ -2,6 +2,7 @@ package flags
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/urfave/cli/v2"
 
@@ -44,6 +45,26 @@ var (
 		Usage:   "Directory to store raft data",
 		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "RAFT_STORAGE_DIR"),
 	}
+	// the raft FSM only keeps the latest unsafe payload, so the defaults below snapshot and compact
+	// the raft log a lot more often than the raft library defaults (120s, 8192 and 10240 logs).
+	RaftSnapshotInterval = &cli.DurationFlag{
+		Name:    "raft.snapshot-interval",
+		Usage:   "Interval to check if a snapshot should be taken of the raft FSM",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "RAFT_SNAPSHOT_INTERVAL"),
+		Value:   10 * time.Second,
+	}
+	RaftSnapshotThreshold = &cli.Uint64Flag{
+		Name:    "raft.snapshot-threshold",
+		Usage:   "Number of logs committed since the last snapshot that triggers a new snapshot",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "RAFT_SNAPSHOT_THRESHOLD"),
+		Value:   1024,
+	}
+	RaftTrailingLogs = &cli.Uint64Flag{
+		Name:    "raft.trailing-logs",
+		Usage:   "Number of logs to keep after a snapshot, so lagging followers can catch up without installing the snapshot",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "RAFT_TRAILING_LOGS"),
+		Value:   512,
+	}
 	NodeRPC = &cli.StringFlag{
 		Name:    "node.rpc",
 		Usage:   "HTTP provider URL for op-node",
@@ -81,6 +102,55 @@ var (
 		Usage:   "Minimum number of peers required to be considered healthy",
 		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_MIN_PEER_COUNT"),
 	}
+	HealthCheckMaxBlockDeficit = &cli.Uint64Flag{
+		Name:    "healthcheck.max-block-deficit",
+		Usage:   "Number of blocks the unsafe head may fall behind the expected block production before being considered unhealthy",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_MAX_BLOCK_DEFICIT"),
+		Value:   1,
+	}
+	HealthCheckChecks = &cli.StringSliceFlag{
+		Name:    "healthcheck.checks",
+		Usage:   "Additional health checks to enable: execution_sync, l1_rpc, batcher_backlog, txpool_size",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_CHECKS"),
+	}
+	HealthCheckWeights = &cli.StringSliceFlag{
+		Name:    "healthcheck.weights",
+		Usage:   "Weights of the failing health checks, as check=weight pairs. Checks weigh 1 by default",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_WEIGHTS"),
+	}
+	HealthCheckGracePeriods = &cli.StringSliceFlag{
+		Name:    "healthcheck.grace-periods",
+		Usage:   "How long the health checks may be failing before they weigh, as check=duration pairs",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_GRACE_PERIODS"),
+	}
+	HealthCheckUnhealthyWeight = &cli.Uint64Flag{
+		Name:    "healthcheck.unhealthy-weight",
+		Usage:   "Total weight of the failing health checks at which the sequencer is unhealthy",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_UNHEALTHY_WEIGHT"),
+		Value:   1,
+	}
+	HealthCheckL1RPC = &cli.StringFlag{
+		Name:    "healthcheck.l1-rpc",
+		Usage:   "L1 RPC checked by the l1_rpc health check",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_L1_RPC"),
+	}
+	HealthCheckBatcherRPC = &cli.StringFlag{
+		Name:    "healthcheck.batcher-rpc",
+		Usage:   "Batcher admin RPC queried by the batcher_backlog health check",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_BATCHER_RPC"),
+	}
+	HealthCheckMaxBatcherBacklog = &cli.Uint64Flag{
+		Name:    "healthcheck.max-batcher-backlog",
+		Usage:   "Maximum number of blocks pending in the batcher, for the batcher_backlog health check",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_MAX_BATCHER_BACKLOG"),
+		Value:   7200,
+	}
+	HealthCheckMaxTxPoolSize = &cli.Uint64Flag{
+		Name:    "healthcheck.max-txpool-size",
+		Usage:   "Maximum number of pending and queued txs in the sequencer txpool, for the txpool_size health check",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_MAX_TXPOOL_SIZE"),
+		Value:   10000,
+	}
 	Paused = &cli.BoolFlag{
 		Name:    "paused",
 		Usage:   "Whether the conductor is paused",
@@ -111,8 +181,20 @@ var optionalFlags = []cli.Flag{
 	Paused,
 	RPCEnableProxy,
 	RaftBootstrap,
+	RaftSnapshotInterval,
+	RaftSnapshotThreshold,
+	RaftTrailingLogs,
 	HealthCheckSafeEnabled,
 	HealthCheckSafeInterval,
+	HealthCheckMaxBlockDeficit,
+	HealthCheckChecks,
+	HealthCheckWeights,
+	HealthCheckGracePeriods,
+	HealthCheckUnhealthyWeight,
+	HealthCheckL1RPC,
+	HealthCheckBatcherRPC,
+	HealthCheckMaxBatcherBacklog,
+	HealthCheckMaxTxPoolSize,
 }
 
 func init() {
@@ -0,0 +1,132 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// Names of the built-in checks, always run by the SequencerHealthMonitor.
+const (
+	CheckUnsafeProgress = "unsafe_progress"
+	CheckUnsafeLag      = "unsafe_lag"
+	CheckSafeLag        = "safe_lag"
+	CheckPeerCount      = "peer_count"
+)
+
+var BuiltinChecks = []string{
+	CheckUnsafeProgress,
+	CheckUnsafeLag,
+	CheckSafeLag,
+	CheckPeerCount,
+}
+
+// CheckResult is the result of a single check of a health check round.
+type CheckResult struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	// Value is the measured value, e.g. the missing blocks, the lag in milliseconds or the peer count.
+	Value uint64 `json:"value"`
+	// Threshold is the configured limit the value is compared against.
+	Threshold uint64 `json:"threshold"`
+	// Weight is the weight the check adds to the combined verdict when it is failing.
+	Weight uint64 `json:"weight"`
+	// Grace is true if the check is failing, but not for longer than its grace period yet.
+	Grace bool `json:"grace,omitempty"`
+}
+
+// HealthCheck is a single criterion of the sequencer health.
+type HealthCheck interface {
+	// Name returns the unique name of the check, used to configure it and to report its results.
+	Name() string
+	// Check runs the check at the given time in milliseconds, with the sync status fetched for the health check round.
+	// A nil result means the check doesn't apply to this round.
+	// An error from a built-in check means the sequencer endpoints can't be reached, which fails the round regardless
+	// of the weights. An error from an additional check is reported as a failing result, subject to its weight and grace period.
+	Check(ctx context.Context, status *eth.SyncStatus, now uint64) (*CheckResult, error)
+}
+
+// CheckOptions defines how the result of a HealthCheck weighs on the combined verdict.
+type CheckOptions struct {
+	// Weight is added to the unhealthy weight of the round when the check is failing.
+	Weight uint64
+	// GracePeriod is how long the check may be failing before its weight is added.
+	GracePeriod time.Duration
+}
+
+var DefaultCheckOptions = CheckOptions{Weight: 1}
+
+// Registry holds the additional health checks run by a SequencerHealthMonitor,
+// along with the options of all checks, built-in ones included.
+// The sequencer is unhealthy once the total weight of the failing checks reaches the unhealthy weight.
+type Registry struct {
+	unhealthyWeight uint64
+	checks          []HealthCheck
+	options         map[string]CheckOptions
+}
+
+// NewRegistry creates a new Registry, an unhealthy weight of 0 is treated as 1.
+func NewRegistry(unhealthyWeight uint64) *Registry {
+	if unhealthyWeight == 0 {
+		unhealthyWeight = 1
+	}
+	return &Registry{
+		unhealthyWeight: unhealthyWeight,
+		options:         make(map[string]CheckOptions),
+	}
+}
+
+// Register adds a check, its name must not be taken by a built-in or a registered check.
+func (r *Registry) Register(check HealthCheck) error {
+	for _, name := range BuiltinChecks {
+		if name == check.Name() {
+			return fmt.Errorf("health check %s is built-in", name)
+		}
+	}
+	for _, c := range r.checks {
+		if c.Name() == check.Name() {
+			return fmt.Errorf("health check %s is already registered", c.Name())
+		}
+	}
+	r.checks = append(r.checks, check)
+	return nil
+}
+
+// SetOptions sets the options of the named check, which doesn't need to be registered yet.
+func (r *Registry) SetOptions(name string, opts CheckOptions) {
+	r.options[name] = opts
+}
+
+// Options returns the options of the named check, DefaultCheckOptions if none were set.
+func (r *Registry) Options(name string) CheckOptions {
+	if opts, ok := r.options[name]; ok {
+		return opts
+	}
+	return DefaultCheckOptions
+}
+
+// Checks returns the registered checks, in registration order.
+func (r *Registry) Checks() []HealthCheck {
+	return r.checks
+}
+
+// UnhealthyWeight returns the total weight of failing checks at which the sequencer is unhealthy.
+func (r *Registry) UnhealthyWeight() uint64 {
+	return r.unhealthyWeight
+}
+
+// checkFunc adapts a function to a HealthCheck.
+type checkFunc struct {
+	name string
+	fn   func(ctx context.Context, status *eth.SyncStatus, now uint64) (*CheckResult, error)
+}
+
+func (c *checkFunc) Name() string {
+	return c.name
+}
+
+func (c *checkFunc) Check(ctx context.Context, status *eth.SyncStatus, now uint64) (*CheckResult, error) {
+	return c.fn(ctx, status, now)
+}
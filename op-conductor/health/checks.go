@@ -0,0 +1,175 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// Names of the additional checks, which can be registered in a Registry.
+const (
+	CheckExecutionSync  = "execution_sync"
+	CheckL1RPC          = "l1_rpc"
+	CheckBatcherBacklog = "batcher_backlog"
+	CheckTxPoolSize     = "txpool_size"
+)
+
+var AdditionalChecks = []string{
+	CheckExecutionSync,
+	CheckL1RPC,
+	CheckBatcherBacklog,
+	CheckTxPoolSize,
+}
+
+// remoteCheckTimeout bounds the calls to endpoints other than the sequencer,
+// so an unresponsive endpoint doesn't stall the health check rounds.
+const remoteCheckTimeout = 2 * time.Second
+
+// ExecutionSyncCheck checks that the execution engine of the sequencer is not syncing.
+type ExecutionSyncCheck struct {
+	log log.Logger
+	rpc client.RPC
+}
+
+var _ HealthCheck = (*ExecutionSyncCheck)(nil)
+
+func NewExecutionSyncCheck(log log.Logger, rpc client.RPC) *ExecutionSyncCheck {
+	return &ExecutionSyncCheck{log: log, rpc: rpc}
+}
+
+func (c *ExecutionSyncCheck) Name() string {
+	return CheckExecutionSync
+}
+
+// Check reports the number of blocks the execution engine is behind while it is syncing,
+// an unreachable execution engine is unhealthy.
+func (c *ExecutionSyncCheck) Check(ctx context.Context, _ *eth.SyncStatus, _ uint64) (*CheckResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, remoteCheckTimeout)
+	defer cancel()
+	var raw json.RawMessage
+	if err := c.rpc.CallContext(ctx, &raw, "eth_syncing"); err != nil {
+		c.log.Error("failed to get execution sync status", "err", err)
+		return &CheckResult{Healthy: false}, nil
+	}
+	var syncing bool
+	if err := json.Unmarshal(raw, &syncing); err == nil && !syncing {
+		return &CheckResult{Healthy: true}, nil
+	}
+	var progress struct {
+		CurrentBlock hexutil.Uint64 `json:"currentBlock"`
+		HighestBlock hexutil.Uint64 `json:"highestBlock"`
+	}
+	if err := json.Unmarshal(raw, &progress); err != nil {
+		return nil, fmt.Errorf("failed to decode execution sync status: %w", err)
+	}
+	var behind uint64
+	if progress.HighestBlock > progress.CurrentBlock {
+		behind = uint64(progress.HighestBlock - progress.CurrentBlock)
+	}
+	c.log.Error("execution engine is syncing", "current_block", progress.CurrentBlock, "highest_block", progress.HighestBlock)
+	return &CheckResult{Healthy: false, Value: behind}, nil
+}
+
+// L1RPCCheck checks that the L1 RPC used by the sequencer is reachable.
+type L1RPCCheck struct {
+	log log.Logger
+	rpc client.RPC
+}
+
+var _ HealthCheck = (*L1RPCCheck)(nil)
+
+func NewL1RPCCheck(log log.Logger, rpc client.RPC) *L1RPCCheck {
+	return &L1RPCCheck{log: log, rpc: rpc}
+}
+
+func (c *L1RPCCheck) Name() string {
+	return CheckL1RPC
+}
+
+// Check reports the latest L1 block number, an unreachable L1 RPC is unhealthy.
+func (c *L1RPCCheck) Check(ctx context.Context, _ *eth.SyncStatus, _ uint64) (*CheckResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, remoteCheckTimeout)
+	defer cancel()
+	var number hexutil.Uint64
+	if err := c.rpc.CallContext(ctx, &number, "eth_blockNumber"); err != nil {
+		c.log.Error("l1 rpc is unreachable", "err", err)
+		return &CheckResult{Healthy: false}, nil
+	}
+	return &CheckResult{Healthy: true, Value: uint64(number)}, nil
+}
+
+// BatcherBacklogCheck checks that the batcher keeps up with the unsafe blocks, through its admin RPC.
+type BatcherBacklogCheck struct {
+	log        log.Logger
+	rpc        client.RPC
+	maxBacklog uint64
+}
+
+var _ HealthCheck = (*BatcherBacklogCheck)(nil)
+
+func NewBatcherBacklogCheck(log log.Logger, rpc client.RPC, maxBacklog uint64) *BatcherBacklogCheck {
+	return &BatcherBacklogCheck{log: log, rpc: rpc, maxBacklog: maxBacklog}
+}
+
+func (c *BatcherBacklogCheck) Name() string {
+	return CheckBatcherBacklog
+}
+
+// Check reports the number of blocks pending in the batcher, an unreachable batcher is unhealthy.
+func (c *BatcherBacklogCheck) Check(ctx context.Context, _ *eth.SyncStatus, _ uint64) (*CheckResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, remoteCheckTimeout)
+	defer cancel()
+	var blocks []eth.BlockID
+	if err := c.rpc.CallContext(ctx, &blocks, "admin_pendingBlocks"); err != nil {
+		c.log.Error("batcher rpc is unreachable", "err", err)
+		return &CheckResult{Healthy: false, Threshold: c.maxBacklog}, nil
+	}
+	backlog := uint64(len(blocks))
+	if backlog > c.maxBacklog {
+		c.log.Error("batcher backlog is above maximum", "backlog", backlog, "max_backlog", c.maxBacklog)
+	}
+	return &CheckResult{Healthy: backlog <= c.maxBacklog, Value: backlog, Threshold: c.maxBacklog}, nil
+}
+
+// TxPoolCheck checks that the txpool of the sequencer is not overflowing.
+type TxPoolCheck struct {
+	log     log.Logger
+	rpc     client.RPC
+	maxSize uint64
+}
+
+var _ HealthCheck = (*TxPoolCheck)(nil)
+
+func NewTxPoolCheck(log log.Logger, rpc client.RPC, maxSize uint64) *TxPoolCheck {
+	return &TxPoolCheck{log: log, rpc: rpc, maxSize: maxSize}
+}
+
+func (c *TxPoolCheck) Name() string {
+	return CheckTxPoolSize
+}
+
+// Check reports the number of pending and queued txs in the txpool, an unavailable txpool namespace is unhealthy.
+func (c *TxPoolCheck) Check(ctx context.Context, _ *eth.SyncStatus, _ uint64) (*CheckResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, remoteCheckTimeout)
+	defer cancel()
+	var status struct {
+		Pending hexutil.Uint64 `json:"pending"`
+		Queued  hexutil.Uint64 `json:"queued"`
+	}
+	if err := c.rpc.CallContext(ctx, &status, "txpool_status"); err != nil {
+		c.log.Error("failed to get txpool status", "err", err)
+		return &CheckResult{Healthy: false, Threshold: c.maxSize}, nil
+	}
+	size := uint64(status.Pending) + uint64(status.Queued)
+	if size > c.maxSize {
+		c.log.Error("txpool size is above maximum", "pending", status.Pending, "queued", status.Queued, "max_size", c.maxSize)
+	}
+	return &CheckResult{Healthy: size <= c.maxSize, Value: size, Threshold: c.maxSize}, nil
+}
@@ -2,7 +2,10 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	health "github.com/ethereum-optimism/optimism/op-conductor/health"
+	mock "github.com/stretchr/testify/mock"
+)
 
 // HealthMonitor is an autogenerated mock type for the HealthMonitor type
 type HealthMonitor struct {
@@ -17,6 +20,53 @@ func (_m *HealthMonitor) EXPECT() *HealthMonitor_Expecter {
 	return &HealthMonitor_Expecter{mock: &_m.Mock}
 }
 
+// LatestReport provides a mock function with given fields:
+func (_m *HealthMonitor) LatestReport() *health.HealthReport {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for LatestReport")
+	}
+
+	var r0 *health.HealthReport
+	if rf, ok := ret.Get(0).(func() *health.HealthReport); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*health.HealthReport)
+		}
+	}
+
+	return r0
+}
+
+// HealthMonitor_LatestReport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LatestReport'
+type HealthMonitor_LatestReport_Call struct {
+	*mock.Call
+}
+
+// LatestReport is a helper method to define mock.On call
+func (_e *HealthMonitor_Expecter) LatestReport() *HealthMonitor_LatestReport_Call {
+	return &HealthMonitor_LatestReport_Call{Call: _e.mock.On("LatestReport")}
+}
+
+func (_c *HealthMonitor_LatestReport_Call) Run(run func()) *HealthMonitor_LatestReport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *HealthMonitor_LatestReport_Call) Return(_a0 *health.HealthReport) *HealthMonitor_LatestReport_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HealthMonitor_LatestReport_Call) RunAndReturn(run func() *health.HealthReport) *HealthMonitor_LatestReport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Start provides a mock function with given fields:
 func (_m *HealthMonitor) Start() error {
 	ret := _m.Called()
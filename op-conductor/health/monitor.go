@@ -3,6 +3,7 @@ package health
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 
@@ -12,19 +13,37 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
+// checkTimeout bounds each check of a health check round, so a single unresponsive
+// endpoint doesn't stall the round.
+const checkTimeout = 5 * time.Second
+
 var (
 	ErrSequencerNotHealthy     = errors.New("sequencer is not healthy")
 	ErrSequencerConnectionDown = errors.New("cannot connect to sequencer rpc endpoints")
 )
 
+// HealthReport is the result of a health check round.
+type HealthReport struct {
+	// Time is the time of the health check in milliseconds.
+	Time    uint64 `json:"time"`
+	Healthy bool   `json:"healthy"`
+	Error   string `json:"error,omitempty"`
+	// UnhealthyWeight is the total weight of the checks failing for longer than their grace period.
+	UnhealthyWeight uint64        `json:"unhealthyWeight"`
+	Checks          []CheckResult `json:"checks"`
+}
+
 // HealthMonitor defines the interface for monitoring the health of the sequencer.
 //
 //go:generate mockery --name HealthMonitor --output mocks/ --with-expecter=true
 type HealthMonitor interface {
 	// Subscribe returns a channel that will be notified for every health check.
 	Subscribe() <-chan error
+	// LatestReport returns the per-check results of the latest health check, or nil before the first one.
+	LatestReport() *HealthReport
 	// Start starts the health check.
 	Start() error
 	// Stop stops the health check.
@@ -33,27 +52,33 @@ type HealthMonitor interface {
 
 // NewSequencerHealthMonitor creates a new sequencer health monitor.
 // interval is the interval between health checks measured in seconds.
+// unsafeInterval is the interval allowed between unsafe head and now measured in seconds.
 // safeInterval is the interval between safe head progress measured in seconds.
 // minPeerCount is the minimum number of peers required for the sequencer to be healthy.
-func NewSequencerHealthMonitor(log log.Logger, metrics metrics.Metricer, interval, unsafeInterval, safeInterval, minPeerCount uint64, safeEnabled bool, rollupCfg *rollup.Config, node dial.RollupClientInterface, p2p p2p.API) HealthMonitor {
+// maxBlockDeficit is the number of blocks the unsafe head may fall behind the block time schedule.
+// registry holds the additional checks and the options of all checks, it may be nil to only run the built-in checks.
+func NewSequencerHealthMonitor(log log.Logger, metrics metrics.Metricer, interval, unsafeInterval, safeInterval, minPeerCount, maxBlockDeficit uint64, safeEnabled bool, rollupCfg *rollup.Config, node dial.RollupClientInterface, p2p p2p.API, registry *Registry) HealthMonitor {
 	return &SequencerHealthMonitor{
-		log:            log,
-		metrics:        metrics,
-		done:           make(chan struct{}),
-		interval:       interval,
-		healthUpdateCh: make(chan error),
-		rollupCfg:      rollupCfg,
-		unsafeInterval: unsafeInterval,
-		safeEnabled:    safeEnabled,
-		safeInterval:   safeInterval,
-		minPeerCount:   minPeerCount,
-		timeProviderFn: currentTimeProvicer,
-		node:           node,
-		p2p:            p2p,
+		log:              log,
+		metrics:          metrics,
+		done:             make(chan struct{}),
+		interval:         interval,
+		healthUpdateCh:   make(chan error),
+		rollupCfg:        rollupCfg,
+		unsafeIntervalMs: unsafeInterval * 1000,
+		safeEnabled:      safeEnabled,
+		safeIntervalMs:   safeInterval * 1000,
+		minPeerCount:     minPeerCount,
+		maxBlockDeficit:  maxBlockDeficit,
+		timeProviderFn:   currentTimeProvicer,
+		node:             node,
+		p2p:              p2p,
+		registry:         registry,
 	}
 }
 
 // SequencerHealthMonitor monitors sequencer health.
+// All times are measured in milliseconds, as blocks are produced on a sub-second interval since Volta.
 type SequencerHealthMonitor struct {
 	log     log.Logger
 	metrics metrics.Metricer
@@ -61,15 +86,23 @@ type SequencerHealthMonitor struct {
 	wg      sync.WaitGroup
 
 	rollupCfg          *rollup.Config
-	unsafeInterval     uint64
+	unsafeIntervalMs   uint64
 	safeEnabled        bool
-	safeInterval       uint64
+	safeIntervalMs     uint64
 	minPeerCount       uint64
+	maxBlockDeficit    uint64
 	interval           uint64
 	healthUpdateCh     chan error
 	lastSeenUnsafeNum  uint64
 	lastSeenUnsafeTime uint64
 
+	registry *Registry
+	// failingSince is the time the checks started failing, by name
+	failingSince map[string]uint64
+
+	reportLock sync.RWMutex
+	report     *HealthReport
+
 	timeProviderFn func() uint64
 
 	node dial.RollupClientInterface
@@ -103,6 +136,13 @@ func (hm *SequencerHealthMonitor) Subscribe() <-chan error {
 	return hm.healthUpdateCh
 }
 
+// LatestReport implements HealthMonitor.
+func (hm *SequencerHealthMonitor) LatestReport() *HealthReport {
+	hm.reportLock.RLock()
+	defer hm.reportLock.RUnlock()
+	return hm.report
+}
+
 func (hm *SequencerHealthMonitor) loop() {
 	defer hm.wg.Done()
 
@@ -128,12 +168,32 @@ func (hm *SequencerHealthMonitor) loop() {
 	}
 }
 
-// healthCheck checks the health of the sequencer by 3 criteria:
-// 1. unsafe head is progressing per block time
+// healthCheck checks the health of the sequencer by the built-in criteria:
+// 1. unsafe head is progressing per block time, within the configured block deficit
 // 2. unsafe head is not too far behind now (measured by unsafeInterval)
 // 3. safe head is progressing every configured batch submission interval
 // 4. peer count is above the configured minimum
+// and by the checks of the registry. All checks are evaluated, so the report has the result of each of them,
+// and the sequencer is unhealthy once the total weight of the failing checks reaches the unhealthy weight.
 func (hm *SequencerHealthMonitor) healthCheck() error {
+	now := hm.timeProviderFn()
+	report := &HealthReport{Time: now}
+	err := hm.runChecks(now, report)
+	report.Healthy = err == nil
+	if err != nil {
+		report.Error = err.Error()
+	}
+	for _, check := range report.Checks {
+		hm.metrics.RecordHealthCheckResult(check.Name, check.Healthy, check.Value)
+	}
+
+	hm.reportLock.Lock()
+	hm.report = report
+	hm.reportLock.Unlock()
+	return err
+}
+
+func (hm *SequencerHealthMonitor) runChecks(now uint64, report *HealthReport) error {
 	ctx := context.Background()
 	status, err := hm.node.SyncStatus(ctx)
 	if err != nil {
@@ -141,75 +201,174 @@ func (hm *SequencerHealthMonitor) healthCheck() error {
 		return ErrSequencerConnectionDown
 	}
 
-	now := hm.timeProviderFn()
+	registry := hm.registry
+	if registry == nil {
+		registry = NewRegistry(1)
+	}
+	if hm.failingSince == nil {
+		hm.failingSince = make(map[string]uint64)
+	}
+	builtins := []HealthCheck{
+		&checkFunc{name: CheckUnsafeProgress, fn: hm.checkUnsafeProgress},
+		&checkFunc{name: CheckUnsafeLag, fn: hm.checkUnsafeLag},
+		&checkFunc{name: CheckSafeLag, fn: hm.checkSafeLag},
+		&checkFunc{name: CheckPeerCount, fn: hm.checkPeerCount},
+	}
+	checks := append(builtins, registry.Checks()...)
 
+	for i, check := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+		result, err := check.Check(checkCtx, status, now)
+		cancel()
+		if err != nil {
+			hm.log.Error("health monitor failed to run check", "check", check.Name(), "err", err)
+			// the built-in checks only query the sequencer, additional checks failing
+			// to reach their endpoint are weighed like any other failing check.
+			if i < len(builtins) {
+				return ErrSequencerConnectionDown
+			}
+			result = &CheckResult{Healthy: false}
+		}
+		if result == nil {
+			continue
+		}
+		opts := registry.Options(check.Name())
+		result.Name = check.Name()
+		result.Weight = opts.Weight
+		if result.Healthy {
+			delete(hm.failingSince, check.Name())
+		} else {
+			since, ok := hm.failingSince[check.Name()]
+			if !ok {
+				since = now
+				hm.failingSince[check.Name()] = now
+			}
+			if now-since < uint64(opts.GracePeriod.Milliseconds()) {
+				result.Grace = true
+			} else {
+				report.UnhealthyWeight += opts.Weight
+			}
+		}
+		report.Checks = append(report.Checks, *result)
+	}
+
+	if report.UnhealthyWeight > 0 && report.UnhealthyWeight >= registry.UnhealthyWeight() {
+		return ErrSequencerNotHealthy
+	}
+	hm.log.Info("sequencer is healthy")
+	return nil
+}
+
+func (hm *SequencerHealthMonitor) checkUnsafeProgress(_ context.Context, status *eth.SyncStatus, now uint64) (*CheckResult, error) {
 	var timeDiff, blockDiff, expectedBlocks uint64
 	if hm.lastSeenUnsafeNum != 0 {
 		timeDiff = calculateTimeDiff(now, hm.lastSeenUnsafeTime)
 		blockDiff = status.UnsafeL2.Number - hm.lastSeenUnsafeNum
-		// how many blocks do we expect to see, minus 1 to account for edge case with respect to time.
-		// for example, if diff = 2.001s and block time = 2s, expecting to see 1 block could potentially cause sequencer to be considered unhealthy.
-		expectedBlocks = timeDiff / hm.rollupCfg.BlockTime
-		if expectedBlocks > 0 {
-			expectedBlocks--
-		}
+		expectedBlocks = hm.expectedBlocks(hm.lastSeenUnsafeTime, now)
 	}
 	if status.UnsafeL2.Number > hm.lastSeenUnsafeNum {
 		hm.lastSeenUnsafeNum = status.UnsafeL2.Number
 		hm.lastSeenUnsafeTime = now
 	}
 
-	if timeDiff > hm.rollupCfg.BlockTime && expectedBlocks > blockDiff {
+	// the deficit is tolerated to account for edge cases with respect to time,
+	// for example, if diff = 2.001s and block time = 2s, expecting to see 1 block could potentially cause sequencer to be considered unhealthy.
+	var missingBlocks uint64
+	if expectedBlocks > blockDiff {
+		missingBlocks = expectedBlocks - blockDiff
+	}
+	if missingBlocks > hm.maxBlockDeficit {
 		hm.log.Error(
 			"unsafe head is not progressing as expected",
 			"now", now,
 			"unsafe_head_num", status.UnsafeL2.Number,
 			"last_seen_unsafe_num", hm.lastSeenUnsafeNum,
 			"last_seen_unsafe_time", hm.lastSeenUnsafeTime,
-			"unsafe_interval", hm.unsafeInterval,
 			"time_diff", timeDiff,
 			"block_diff", blockDiff,
 			"expected_blocks", expectedBlocks,
+			"max_block_deficit", hm.maxBlockDeficit,
 		)
-		return ErrSequencerNotHealthy
 	}
+	return &CheckResult{
+		Healthy:   missingBlocks <= hm.maxBlockDeficit,
+		Value:     missingBlocks,
+		Threshold: hm.maxBlockDeficit,
+	}, nil
+}
 
-	curUnsafeTimeDiff := calculateTimeDiff(now, status.UnsafeL2.Time)
-	if curUnsafeTimeDiff > hm.unsafeInterval {
+func (hm *SequencerHealthMonitor) checkUnsafeLag(_ context.Context, status *eth.SyncStatus, now uint64) (*CheckResult, error) {
+	curUnsafeTimeDiff := calculateTimeDiff(now, status.UnsafeL2.MillisecondTimestamp())
+	if curUnsafeTimeDiff > hm.unsafeIntervalMs {
 		hm.log.Error(
 			"unsafe head is falling behind the unsafe interval",
 			"now", now,
 			"unsafe_head_num", status.UnsafeL2.Number,
-			"unsafe_head_time", status.UnsafeL2.Time,
-			"unsafe_interval", hm.unsafeInterval,
+			"unsafe_head_time", status.UnsafeL2.MillisecondTimestamp(),
+			"unsafe_interval", hm.unsafeIntervalMs,
 			"cur_unsafe_time_diff", curUnsafeTimeDiff,
 		)
-		return ErrSequencerNotHealthy
 	}
+	return &CheckResult{
+		Healthy:   curUnsafeTimeDiff <= hm.unsafeIntervalMs,
+		Value:     curUnsafeTimeDiff,
+		Threshold: hm.unsafeIntervalMs,
+	}, nil
+}
 
-	if hm.safeEnabled && calculateTimeDiff(now, status.SafeL2.Time) > hm.safeInterval {
+func (hm *SequencerHealthMonitor) checkSafeLag(_ context.Context, status *eth.SyncStatus, now uint64) (*CheckResult, error) {
+	if !hm.safeEnabled {
+		return nil, nil
+	}
+	curSafeTimeDiff := calculateTimeDiff(now, status.SafeL2.MillisecondTimestamp())
+	if curSafeTimeDiff > hm.safeIntervalMs {
 		hm.log.Error(
 			"safe head is not progressing as expected",
 			"now", now,
 			"safe_head_num", status.SafeL2.Number,
-			"safe_head_time", status.SafeL2.Time,
-			"safe_interval", hm.safeInterval,
+			"safe_head_time", status.SafeL2.MillisecondTimestamp(),
+			"safe_interval", hm.safeIntervalMs,
+			"cur_safe_time_diff", curSafeTimeDiff,
 		)
-		return ErrSequencerNotHealthy
 	}
+	return &CheckResult{
+		Healthy:   curSafeTimeDiff <= hm.safeIntervalMs,
+		Value:     curSafeTimeDiff,
+		Threshold: hm.safeIntervalMs,
+	}, nil
+}
 
+func (hm *SequencerHealthMonitor) checkPeerCount(ctx context.Context, _ *eth.SyncStatus, _ uint64) (*CheckResult, error) {
 	stats, err := hm.p2p.PeerStats(ctx)
 	if err != nil {
-		hm.log.Error("health monitor failed to get peer stats", "err", err)
-		return ErrSequencerConnectionDown
+		return nil, fmt.Errorf("failed to get peer stats: %w", err)
 	}
 	if uint64(stats.Connected) < hm.minPeerCount {
 		hm.log.Error("peer count is below minimum", "connected", stats.Connected, "minPeerCount", hm.minPeerCount)
-		return ErrSequencerNotHealthy
 	}
+	return &CheckResult{
+		Healthy:   uint64(stats.Connected) >= hm.minPeerCount,
+		Value:     uint64(stats.Connected),
+		Threshold: hm.minPeerCount,
+	}, nil
+}
 
-	hm.log.Info("sequencer is healthy")
-	return nil
+// expectedBlocks returns the number of blocks expected to be produced between the from and to times in milliseconds,
+// following the block interval schedule of the rollup config (2s -> 500ms at Volta -> 250ms at Fourier).
+func (hm *SequencerHealthMonitor) expectedBlocks(from, to uint64) uint64 {
+	var blocks uint64
+	for from < to {
+		interval := hm.rollupCfg.MillisecondBlockInterval(from)
+		end := to
+		for _, fork := range []*uint64{hm.rollupCfg.VoltaTime, hm.rollupCfg.FourierTime} {
+			if fork != nil && *fork*1000 > from && *fork*1000 < end {
+				end = *fork * 1000
+			}
+		}
+		blocks += (end - from) / interval
+		from = end
+	}
+	return blocks
 }
 
 func calculateTimeDiff(now, then uint64) uint64 {
@@ -220,5 +379,5 @@ func calculateTimeDiff(now, then uint64) uint64 {
 }
 
 func currentTimeProvicer() uint64 {
-	return uint64(time.Now().Unix())
+	return uint64(time.Now().UnixMilli())
 }
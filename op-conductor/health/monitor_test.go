@@ -2,10 +2,13 @@ package health
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/ethereum-optimism/optimism/op-conductor/metrics"
@@ -47,28 +50,29 @@ func (s *HealthMonitorTestSuite) SetupMonitor(
 	mockRollupClient *testutils.MockRollupClient,
 	mockP2P *p2pMocks.API,
 ) *SequencerHealthMonitor {
-	tp := &timeProvider{now: now}
+	tp := &timeProvider{now: now * 1000}
 	if mockP2P == nil {
 		mockP2P = &p2pMocks.API{}
 		ps1 := &p2p.PeerStats{
 			Connected: healthyPeerCount,
 		}
-		mockP2P.EXPECT().PeerStats(context.Background()).Return(ps1, nil)
+		mockP2P.EXPECT().PeerStats(mock.Anything).Return(ps1, nil)
 	}
 	monitor := &SequencerHealthMonitor{
-		log:            s.log,
-		done:           make(chan struct{}),
-		interval:       s.interval,
-		metrics:        &metrics.NoopMetricsImpl{},
-		healthUpdateCh: make(chan error),
-		rollupCfg:      s.rollupCfg,
-		unsafeInterval: unsafeInterval,
-		safeInterval:   safeInterval,
-		safeEnabled:    true,
-		minPeerCount:   s.minPeerCount,
-		timeProviderFn: tp.Now,
-		node:           mockRollupClient,
-		p2p:            mockP2P,
+		log:              s.log,
+		done:             make(chan struct{}),
+		interval:         s.interval,
+		metrics:          &metrics.NoopMetricsImpl{},
+		healthUpdateCh:   make(chan error),
+		rollupCfg:        s.rollupCfg,
+		unsafeIntervalMs: unsafeInterval * 1000,
+		safeIntervalMs:   safeInterval * 1000,
+		safeEnabled:      true,
+		minPeerCount:     s.minPeerCount,
+		maxBlockDeficit:  1,
+		timeProviderFn:   tp.Now,
+		node:             mockRollupClient,
+		p2p:              mockP2P,
 	}
 	err := monitor.Start()
 	s.NoError(err)
@@ -88,7 +92,7 @@ func (s *HealthMonitorTestSuite) TestUnhealthyLowPeerCount() {
 	ps1 := &p2p.PeerStats{
 		Connected: unhealthyPeerCount,
 	}
-	pc.EXPECT().PeerStats(context.Background()).Return(ps1, nil).Times(1)
+	pc.EXPECT().PeerStats(mock.Anything).Return(ps1, nil).Times(1)
 
 	monitor := s.SetupMonitor(now, 60, 60, rc, pc)
 
@@ -116,7 +120,7 @@ func (s *HealthMonitorTestSuite) TestUnhealthyUnsafeHeadNotProgressing() {
 		healthy := <-healthUpdateCh
 		if i < 4 {
 			s.Nil(healthy)
-			s.Equal(now, monitor.lastSeenUnsafeTime)
+			s.Equal(now*1000, monitor.lastSeenUnsafeTime)
 			s.Equal(uint64(5), monitor.lastSeenUnsafeNum)
 		} else {
 			s.NotNil(healthy)
@@ -194,17 +198,153 @@ func (s *HealthMonitorTestSuite) TestHealthyWithUnsafeLag() {
 
 	healthy = <-healthUpdateCh
 	s.Nil(healthy)
-	s.Equal(lastSeenUnsafeTime+2, monitor.lastSeenUnsafeTime)
+	s.Equal(lastSeenUnsafeTime+2000, monitor.lastSeenUnsafeTime)
 	s.Equal(uint64(2), monitor.lastSeenUnsafeNum)
 
 	healthy = <-healthUpdateCh
 	s.Nil(healthy)
-	s.Equal(lastSeenUnsafeTime+2, monitor.lastSeenUnsafeTime)
+	s.Equal(lastSeenUnsafeTime+2000, monitor.lastSeenUnsafeTime)
 	s.Equal(uint64(2), monitor.lastSeenUnsafeNum)
 
 	s.NoError(monitor.Stop())
 }
 
+func (s *HealthMonitorTestSuite) TestUnhealthyMillisecondBlocksNotProgressing() {
+	s.T().Parallel()
+	now := uint64(time.Now().Unix())
+
+	rc := &testutils.MockRollupClient{}
+	// 500ms blocks since Volta, so 2 blocks are expected every check
+	ss1 := mockSyncStatus(now, 5, now, 1)
+	rc.ExpectSyncStatus(ss1, nil)
+	rc.ExpectSyncStatus(mockSyncStatus(now+1, 7, now, 1), nil)
+	rc.ExpectSyncStatus(mockSyncStatus(now+1, 7, now, 1), nil)
+
+	monitor := s.SetupMonitor(now, 60, 60, rc, nil)
+	voltaTime := uint64(0)
+	monitor.rollupCfg = &rollup.Config{BlockTime: 1, VoltaTime: &voltaTime}
+	healthUpdateCh := monitor.Subscribe()
+
+	s.Nil(<-healthUpdateCh)
+	s.Nil(<-healthUpdateCh)
+	// no block in 1s falls behind the 500ms block time by more than 1 block
+	s.NotNil(<-healthUpdateCh)
+
+	report := monitor.LatestReport()
+	s.False(report.Healthy)
+	s.Equal(now*1000+2000, report.Time)
+	s.Len(report.Checks, 4)
+	s.Equal(CheckResult{Name: CheckUnsafeProgress, Healthy: false, Value: 2, Threshold: 1, Weight: 1}, report.Checks[0])
+	s.Equal(CheckResult{Name: CheckUnsafeLag, Healthy: true, Value: 1000, Threshold: 60000, Weight: 1}, report.Checks[1])
+	s.True(report.Checks[2].Healthy)
+	s.True(report.Checks[3].Healthy)
+
+	s.NoError(monitor.Stop())
+}
+
+func TestExpectedBlocks(t *testing.T) {
+	voltaTime, fourierTime := uint64(10), uint64(20)
+	monitor := &SequencerHealthMonitor{
+		rollupCfg: &rollup.Config{BlockTime: 1, VoltaTime: &voltaTime, FourierTime: &fourierTime},
+	}
+	require.Equal(t, uint64(4), monitor.expectedBlocks(5000, 9999))
+	require.Equal(t, uint64(5+10), monitor.expectedBlocks(5000, 10000+5000))
+	require.Equal(t, uint64(10+4), monitor.expectedBlocks(15000, 21000))
+	require.Equal(t, uint64(5+20+40), monitor.expectedBlocks(5000, 30000))
+}
+
+type testCheck struct {
+	name    string
+	healthy bool
+	err     error
+}
+
+func (c *testCheck) Name() string {
+	return c.name
+}
+
+func (c *testCheck) Check(context.Context, *eth.SyncStatus, uint64) (*CheckResult, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &CheckResult{Healthy: c.healthy}, nil
+}
+
+func TestHealthCheckRegistry(t *testing.T) {
+	now := uint64(time.Now().Unix())
+	rc := &testutils.MockRollupClient{}
+	pc := &p2pMocks.API{}
+	pc.EXPECT().PeerStats(mock.Anything).Return(&p2p.PeerStats{Connected: healthyPeerCount}, nil)
+
+	a := &testCheck{name: "a", healthy: true}
+	b := &testCheck{name: "b", healthy: true}
+	registry := NewRegistry(2)
+	require.NoError(t, registry.Register(a))
+	require.NoError(t, registry.Register(b))
+	require.Error(t, registry.Register(&testCheck{name: "a"}))
+	require.Error(t, registry.Register(&testCheck{name: CheckPeerCount}))
+	registry.SetOptions("a", CheckOptions{Weight: 2, GracePeriod: 2 * time.Second})
+
+	tp := &timeProvider{now: now * 1000}
+	monitor := &SequencerHealthMonitor{
+		log:              testlog.Logger(t, log.LevelDebug),
+		metrics:          &metrics.NoopMetricsImpl{},
+		rollupCfg:        &rollup.Config{BlockTime: blockTime},
+		unsafeIntervalMs: 60000,
+		minPeerCount:     minPeerCount,
+		maxBlockDeficit:  1,
+		timeProviderFn:   tp.Now,
+		node:             rc,
+		p2p:              pc,
+		registry:         registry,
+	}
+	healthCheck := func() error {
+		rc.ExpectSyncStatus(mockSyncStatus(tp.now/1000, tp.now/1000, tp.now/1000, 1), nil)
+		return monitor.healthCheck()
+	}
+
+	require.NoError(t, healthCheck())
+	report := monitor.LatestReport()
+	require.True(t, report.Healthy)
+	require.Len(t, report.Checks, 5)
+	require.Equal(t, CheckResult{Name: "a", Healthy: true, Weight: 2}, report.Checks[3])
+	require.Equal(t, CheckResult{Name: "b", Healthy: true, Weight: 1}, report.Checks[4])
+
+	// b alone doesn't reach the unhealthy weight
+	b.healthy = false
+	require.NoError(t, healthCheck())
+	require.Equal(t, uint64(1), monitor.LatestReport().UnhealthyWeight)
+
+	// a is failing within its grace period
+	a.healthy = false
+	require.NoError(t, healthCheck())
+	require.True(t, monitor.LatestReport().Checks[3].Grace)
+	require.NoError(t, healthCheck())
+
+	// and weighs once its grace period is over
+	require.ErrorIs(t, healthCheck(), ErrSequencerNotHealthy)
+	report = monitor.LatestReport()
+	require.False(t, report.Healthy)
+	require.False(t, report.Checks[3].Grace)
+	require.Equal(t, uint64(3), report.UnhealthyWeight)
+
+	// recovering resets the grace period
+	a.healthy = true
+	require.NoError(t, healthCheck())
+	a.healthy = false
+	require.NoError(t, healthCheck())
+	require.True(t, monitor.LatestReport().Checks[3].Grace)
+
+	// check errors are weighed as failing checks
+	a.healthy = true
+	b.healthy = true
+	b.err = errors.New("connection refused")
+	require.NoError(t, healthCheck())
+	report = monitor.LatestReport()
+	require.Equal(t, CheckResult{Name: "b", Healthy: false, Weight: 1}, report.Checks[4])
+	require.Equal(t, uint64(1), report.UnhealthyWeight)
+}
+
 func mockSyncStatus(unsafeTime, unsafeNum, safeTime, safeNum uint64) *eth.SyncStatus {
 	return &eth.SyncStatus{
 		UnsafeL2: eth.L2BlockRef{
@@ -228,6 +368,6 @@ type timeProvider struct {
 
 func (tp *timeProvider) Now() uint64 {
 	now := tp.now
-	tp.now++
+	tp.now += 1000
 	return now
 }
@@ -18,6 +18,7 @@ type Metricer interface {
 	RecordStartSequencer(success bool)
 	RecordStopSequencer(success bool)
 	RecordHealthCheck(success bool, err error)
+	RecordHealthCheckResult(check string, healthy bool, value uint64)
 	RecordLoopExecutionTime(duration float64)
 }
 
@@ -33,6 +34,8 @@ type Metrics struct {
 	up   prometheus.Gauge
 
 	healthChecks    *prometheus.CounterVec
+	checkHealthy    *prometheus.GaugeVec
+	checkValues     *prometheus.GaugeVec
 	leaderTransfers *prometheus.CounterVec
 	sequencerStarts *prometheus.CounterVec
 	sequencerStops  *prometheus.CounterVec
@@ -73,6 +76,16 @@ func NewMetrics() *Metrics {
 			Name:      "healthchecks_count",
 			Help:      "Number of healthchecks",
 		}, []string{"success", "error"}),
+		checkHealthy: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "healthcheck_healthy",
+			Help:      "1 if the latest result of the health check is healthy",
+		}, []string{"check"}),
+		checkValues: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "healthcheck_value",
+			Help:      "Value measured by the latest result of the health check, e.g. missing blocks, lag in milliseconds or peer count",
+		}, []string{"check"}),
 		leaderTransfers: factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: Namespace,
 			Name:      "leader_transfers_count",
@@ -131,6 +144,16 @@ func (m *Metrics) RecordHealthCheck(success bool, err error) {
 	m.healthChecks.WithLabelValues(strconv.FormatBool(success), errStr).Inc()
 }
 
+// RecordHealthCheckResult records the latest result of a single health check.
+func (m *Metrics) RecordHealthCheckResult(check string, healthy bool, value uint64) {
+	var healthyValue float64
+	if healthy {
+		healthyValue = 1
+	}
+	m.checkHealthy.WithLabelValues(check).Set(healthyValue)
+	m.checkValues.WithLabelValues(check).Set(float64(value))
+}
+
 // RecordLeaderTransfer increments the leaderTransfers counter.
 func (m *Metrics) RecordLeaderTransfer(success bool) {
 	m.leaderTransfers.WithLabelValues(strconv.FormatBool(success)).Inc()
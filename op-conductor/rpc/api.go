@@ -7,6 +7,7 @@ import (
 	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum-optimism/optimism/op-conductor/consensus"
+	"github.com/ethereum-optimism/optimism/op-conductor/health"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
@@ -25,6 +26,8 @@ type API interface {
 	Stopped(ctx context.Context) (bool, error)
 	// SequencerHealthy returns true if the sequencer is healthy.
 	SequencerHealthy(ctx context.Context) (bool, error)
+	// SequencerHealthReport returns the per-check results of the latest sequencer health check.
+	SequencerHealthReport(ctx context.Context) (*health.HealthReport, error)
 
 	// Consensus related APIs
 	// Leader returns true if the server is the leader.
@@ -43,6 +46,10 @@ type API interface {
 	TransferLeaderToServer(ctx context.Context, id string, addr string) error
 	// ClusterMembership returns the current cluster membership configuration.
 	ClusterMembership(ctx context.Context) ([]*consensus.ServerInfo, error)
+	// TakeSnapshot snapshots the raft FSM and compacts the raft log.
+	TakeSnapshot(ctx context.Context) error
+	// RaftStorageStats returns the size of the raft storage along with the raft log indexes.
+	RaftStorageStats(ctx context.Context) (*consensus.StorageStats, error)
 
 	// APIs called by op-node
 	// Active returns true if op-conductor is active (not paused or stopped).
@@ -6,6 +6,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-conductor/consensus"
+	"github.com/ethereum-optimism/optimism/op-conductor/health"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
@@ -15,6 +16,7 @@ type conductor interface {
 	Paused() bool
 	Stopped() bool
 	SequencerHealthy(ctx context.Context) bool
+	SequencerHealthReport(ctx context.Context) *health.HealthReport
 
 	Leader(ctx context.Context) bool
 	LeaderWithID(ctx context.Context) *consensus.ServerInfo
@@ -25,6 +27,8 @@ type conductor interface {
 	TransferLeaderToServer(ctx context.Context, id string, addr string) error
 	CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error
 	ClusterMembership(ctx context.Context) ([]*consensus.ServerInfo, error)
+	TakeSnapshot(ctx context.Context) error
+	RaftStorageStats(ctx context.Context) (*consensus.StorageStats, error)
 }
 
 // APIBackend is the backend implementation of the API.
@@ -117,7 +121,22 @@ func (api *APIBackend) SequencerHealthy(ctx context.Context) (bool, error) {
 	return api.con.SequencerHealthy(ctx), nil
 }
 
+// SequencerHealthReport implements API.
+func (api *APIBackend) SequencerHealthReport(ctx context.Context) (*health.HealthReport, error) {
+	return api.con.SequencerHealthReport(ctx), nil
+}
+
 // ClusterMembership implements API.
 func (api *APIBackend) ClusterMembership(ctx context.Context) ([]*consensus.ServerInfo, error) {
 	return api.con.ClusterMembership(ctx)
 }
+
+// TakeSnapshot implements API.
+func (api *APIBackend) TakeSnapshot(ctx context.Context) error {
+	return api.con.TakeSnapshot(ctx)
+}
+
+// RaftStorageStats implements API.
+func (api *APIBackend) RaftStorageStats(ctx context.Context) (*consensus.StorageStats, error) {
+	return api.con.RaftStorageStats(ctx)
+}
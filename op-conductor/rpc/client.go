@@ -6,6 +6,7 @@ import (
 	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum-optimism/optimism/op-conductor/consensus"
+	"github.com/ethereum-optimism/optimism/op-conductor/health"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
@@ -114,9 +115,28 @@ func (c *APIClient) SequencerHealthy(ctx context.Context) (bool, error) {
 	return healthy, err
 }
 
+// SequencerHealthReport implements API.
+func (c *APIClient) SequencerHealthReport(ctx context.Context) (*health.HealthReport, error) {
+	var report *health.HealthReport
+	err := c.c.CallContext(ctx, &report, prefixRPC("sequencerHealthReport"))
+	return report, err
+}
+
 // ClusterMembership implements API.
 func (c *APIClient) ClusterMembership(ctx context.Context) ([]*consensus.ServerInfo, error) {
 	var info []*consensus.ServerInfo
 	err := c.c.CallContext(ctx, &info, prefixRPC("clusterMembership"))
 	return info, err
 }
+
+// TakeSnapshot implements API.
+func (c *APIClient) TakeSnapshot(ctx context.Context) error {
+	return c.c.CallContext(ctx, nil, prefixRPC("takeSnapshot"))
+}
+
+// RaftStorageStats implements API.
+func (c *APIClient) RaftStorageStats(ctx context.Context) (*consensus.StorageStats, error) {
+	var stats *consensus.StorageStats
+	err := c.c.CallContext(ctx, &stats, prefixRPC("raftStorageStats"))
+	return stats, err
+}
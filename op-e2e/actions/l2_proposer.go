@@ -65,6 +65,14 @@ func (f fakeTxMgr) Send(_ context.Context, _ txmgr.TxCandidate) (*types.Receipt,
 	panic("unimplemented")
 }
 
+func (f fakeTxMgr) Cancel(_ context.Context, _ uint64) (*types.Receipt, error) {
+	panic("unimplemented")
+}
+
+func (f fakeTxMgr) Replace(_ context.Context, _ uint64, _ txmgr.TxCandidate) (*types.Receipt, error) {
+	panic("unimplemented")
+}
+
 func (f fakeTxMgr) Close() {
 }
 
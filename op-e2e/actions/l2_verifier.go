@@ -67,6 +67,7 @@ type L2API interface {
 type safeDB interface {
 	derive.SafeHeadListener
 	node.SafeDBReader
+	node.SafeDBImporter
 }
 
 func NewL2Verifier(t Testing, log log.Logger, l1 derive.L1Fetcher, blobsSrc derive.L1BlobsFetcher, plasmaSrc driver.PlasmaIface, eng L2API, cfg *rollup.Config, syncCfg *sync.Config, safeHeadListener safeDB) *L2Verifier {
@@ -85,7 +86,7 @@ func NewL2Verifier(t Testing, log log.Logger, l1 derive.L1Fetcher, blobsSrc deri
 	attributesHandler := attributes.NewAttributesHandler(log, cfg, engine, eng, false)
 
 	pipeline := derive.NewDerivationPipeline(log, cfg, l1, blobsSrc, plasmaSrc, eng, engine, metrics,
-		syncCfg, safeHeadListener, finalizer, attributesHandler)
+		syncCfg, safeHeadListener, finalizer, attributesHandler, derive.DerivationEventsDisabled)
 	pipeline.Reset()
 
 	rollupNode := &L2Verifier{
@@ -117,7 +118,7 @@ func NewL2Verifier(t Testing, log log.Logger, l1 derive.L1Fetcher, blobsSrc deri
 		{
 			Namespace:     "admin",
 			Version:       "",
-			Service:       node.NewAdminAPI(backend, m, log),
+			Service:       node.NewAdminAPI(backend, safeHeadListener, m, log),
 			Public:        true, // TODO: this field is deprecated. Do we even need this anymore?
 			Authenticated: false,
 		},
@@ -0,0 +1,193 @@
+package explain
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// maxTemporaryErrors bounds the consecutive temporary errors of the derivation, before giving up.
+const maxTemporaryErrors = 10
+
+// L2Source is the L2 chain the derived blocks are checked against.
+type L2Source interface {
+	derive.SafeBlockFetcher
+	derive.SystemConfigL2Fetcher
+	L2BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L2BlockRef, error)
+}
+
+// Config is the L1 range to explain, inclusive to exclusive.
+type Config struct {
+	Start, End uint64
+}
+
+// Explain runs the derive stages over the given L1 range, on top of the last L2 block with an L1 origin before the range,
+// and reports how the batcher transactions of the range derive into L2 blocks.
+// The derivation follows the canonical L2 chain, and stops at the first derived block that doesn't match it,
+// because the blocks are not executed.
+func Explain(ctx context.Context, logger log.Logger, rollupCfg *rollup.Config, l1 derive.L1Fetcher, blobs derive.L1BlobsFetcher, l2 L2Source, config Config) (*Report, error) {
+	parent, err := findL2Start(ctx, rollupCfg, l2, config.Start)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start the pipeline like the engine queue does on a reset, a channel timeout before the safe head,
+	// so the channels opened before the safe head can still be read.
+	pipelineL2 := parent
+	for {
+		afterL2Genesis := pipelineL2.Number > rollupCfg.Genesis.L2.Number
+		afterL1Genesis := pipelineL2.L1Origin.Number > rollupCfg.Genesis.L1.Number
+		afterChannelTimeout := pipelineL2.L1Origin.Number+rollupCfg.ChannelTimeout > parent.L1Origin.Number
+		if !afterL2Genesis || !afterL1Genesis || !afterChannelTimeout {
+			break
+		}
+		pipelineL2, err = l2.L2BlockRefByNumber(ctx, pipelineL2.Number-1)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch L2 block %d: %w", pipelineL2.Number-1, err)
+		}
+	}
+	pipelineOrigin, err := l1.L1BlockRefByHash(ctx, pipelineL2.L1Origin.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch L1 block %s: %w", pipelineL2.L1Origin, err)
+	}
+	sysCfg, err := l2.SystemConfigByL2Hash(ctx, pipelineL2.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch L1 config of L2 block %s: %w", pipelineL2.ID(), err)
+	}
+
+	report := &Report{
+		L1Start: config.Start,
+		L1End:   config.End,
+		L2Start: parent.ID(),
+	}
+	signer := types.LatestSignerForChainID(rollupCfg.L1ChainID)
+	t := newTracer(l1, rollupCfg.BatchInboxAddress, blobs, signer, report)
+	t.parent = parent
+
+	traversal := derive.NewL1Traversal(logger, rollupCfg, l1)
+	t.traversal = traversal
+	dataSrc := derive.NewDataSourceFactory(logger, rollupCfg, l1, blobs, nil)
+	l1Src := derive.NewL1Retrieval(logger, dataSrc, traversal)
+	frameQueue := derive.NewFrameQueue(logger, l1Src)
+	bank := derive.NewChannelBank(logger, rollupCfg, &frameTracer{prev: frameQueue, t: t}, l1, metrics.NoopMetrics)
+	bank.SetDerivationEvents(t)
+	chInReader := derive.NewChannelInReader(rollupCfg, logger, bank, metrics.NoopMetrics)
+	batchQueue := derive.NewBatchQueue(logger, rollupCfg, &batchTracer{prev: chInReader, t: t}, l2)
+	batchQueue.SetDerivationEvents(t)
+	attrBuilder := derive.NewFetchingAttributesBuilder(rollupCfg, l1, l2)
+	attributesQueue := derive.NewAttributesQueue(logger, rollupCfg, attrBuilder, batchQueue)
+
+	stages := []derive.ResettableStage{traversal, l1Src, frameQueue, bank, chInReader, batchQueue, attributesQueue}
+	for _, stage := range stages {
+		if err := stage.Reset(ctx, pipelineOrigin, sysCfg); err != io.EOF {
+			return nil, fmt.Errorf("failed to reset derivation to L1 block %s: %w", pipelineOrigin, err)
+		}
+	}
+
+	temporaryErrors := 0
+	for {
+		attrs, err := attributesQueue.NextAttributes(ctx, parent)
+		if err == io.EOF {
+			temporaryErrors = 0
+			if traversal.Origin().Number+1 >= config.End {
+				break
+			}
+			if err := traversal.AdvanceL1Block(ctx); err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, err
+			}
+			continue
+		} else if errors.Is(err, derive.NotEnoughData) {
+			continue
+		} else if errors.Is(err, derive.ErrTemporary) && temporaryErrors < maxTemporaryErrors {
+			logger.Warn("Temporary error in derivation", "err", err)
+			temporaryErrors++
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		temporaryErrors = 0
+
+		ref, divergence, err := checkCanonical(ctx, rollupCfg, l2, attrs)
+		if err != nil {
+			return nil, err
+		}
+		if divergence != nil {
+			report.Divergence = divergence
+			break
+		}
+		t.derived(ref, attrs)
+		parent = ref
+	}
+	report.L2End = parent.ID()
+	return report, nil
+}
+
+// findL2Start returns the last L2 block with an L1 origin before the given L1 block.
+func findL2Start(ctx context.Context, rollupCfg *rollup.Config, l2 L2Source, l1Start uint64) (eth.L2BlockRef, error) {
+	head, err := l2.L2BlockRefByLabel(ctx, eth.Unsafe)
+	if err != nil {
+		return eth.L2BlockRef{}, fmt.Errorf("failed to fetch L2 head: %w", err)
+	}
+	lo, hi := rollupCfg.Genesis.L2.Number, head.Number
+	if l1Start <= rollupCfg.Genesis.L1.Number {
+		hi = lo
+	}
+	for lo < hi {
+		mid := lo + (hi-lo+1)/2
+		ref, err := l2.L2BlockRefByNumber(ctx, mid)
+		if err != nil {
+			return eth.L2BlockRef{}, fmt.Errorf("failed to fetch L2 block %d: %w", mid, err)
+		}
+		if ref.L1Origin.Number < l1Start {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return l2.L2BlockRefByNumber(ctx, lo)
+}
+
+// checkCanonical returns the canonical L2 block derived from the given attributes,
+// or why the attributes don't match the canonical L2 chain.
+func checkCanonical(ctx context.Context, rollupCfg *rollup.Config, l2 L2Source, attrs *derive.AttributesWithParent) (eth.L2BlockRef, *DivergenceReport, error) {
+	number := attrs.Parent.Number + 1
+	envelope, err := l2.PayloadByNumber(ctx, number)
+	if errors.Is(err, ethereum.NotFound) {
+		return eth.L2BlockRef{}, &DivergenceReport{Number: number, Reason: "block is not in the L2 chain yet"}, nil
+	} else if err != nil {
+		return eth.L2BlockRef{}, nil, fmt.Errorf("failed to fetch L2 block %d: %w", number, err)
+	}
+	payload := envelope.ExecutionPayload
+	if payload.ParentHash != attrs.Parent.Hash {
+		return eth.L2BlockRef{}, &DivergenceReport{Number: number, Reason: fmt.Sprintf("parent hash %s, derived on top of %s", payload.ParentHash, attrs.Parent.Hash)}, nil
+	}
+	if payload.Timestamp != attrs.Attributes.Timestamp {
+		return eth.L2BlockRef{}, &DivergenceReport{Number: number, Reason: fmt.Sprintf("timestamp %d, derived %d", payload.Timestamp, attrs.Attributes.Timestamp)}, nil
+	}
+	if len(payload.Transactions) != len(attrs.Attributes.Transactions) {
+		return eth.L2BlockRef{}, &DivergenceReport{Number: number, Reason: fmt.Sprintf("%d transactions, derived %d", len(payload.Transactions), len(attrs.Attributes.Transactions))}, nil
+	}
+	for i, tx := range payload.Transactions {
+		if !bytes.Equal(tx, attrs.Attributes.Transactions[i]) {
+			return eth.L2BlockRef{}, &DivergenceReport{Number: number, Reason: fmt.Sprintf("transaction %d differs from the derived one", i)}, nil
+		}
+	}
+	ref, err := derive.PayloadToBlockRef(rollupCfg, payload)
+	if err != nil {
+		return eth.L2BlockRef{}, nil, fmt.Errorf("failed to decode L2 block %d: %w", number, err)
+	}
+	return ref, nil, nil
+}
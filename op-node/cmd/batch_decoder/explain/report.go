@@ -0,0 +1,100 @@
+package explain
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// Status of a frame, channel or batch in the derivation.
+const (
+	StatusIngested = "ingested"
+	StatusDropped  = "dropped"
+	StatusPending  = "pending"
+	StatusRead     = "read"
+	StatusTimedOut = "timed_out"
+	StatusAccepted = "accepted"
+	StatusSkipped  = "skipped"
+)
+
+// Report explains how the batches submitted in a range of L1 blocks derive into L2 blocks.
+type Report struct {
+	L1Start uint64 `json:"l1_start"`
+	L1End   uint64 `json:"l1_end"`
+	// L2Start is the L2 block the derivation started on top of.
+	L2Start eth.BlockID `json:"l2_start"`
+	// L2End is the last derived L2 block.
+	L2End        eth.BlockID       `json:"l2_end"`
+	Transactions []*TxReport       `json:"transactions"`
+	Channels     []*ChannelReport  `json:"channels"`
+	Blocks       []*L2BlockReport  `json:"blocks"`
+	Divergence   *DivergenceReport `json:"divergence,omitempty"`
+}
+
+// TxReport is a batcher transaction, with the frames it carried.
+type TxReport struct {
+	Hash        common.Hash    `json:"hash"`
+	L1Block     uint64         `json:"l1_block"`
+	Sender      common.Address `json:"sender"`
+	Frames      []*FrameReport `json:"frames"`
+	ParseErrors []string       `json:"parse_errors,omitempty"`
+}
+
+// FrameReport is a frame, as ingested by the channel bank.
+type FrameReport struct {
+	Channel     derive.ChannelID `json:"channel"`
+	FrameNumber uint16           `json:"frame_number"`
+	IsLast      bool             `json:"is_last"`
+	L1Block     uint64           `json:"l1_block"`
+	// Tx is the batcher transaction the frame was found in,
+	// unset if the frame didn't come from a transaction found by the inbox scan.
+	Tx     common.Hash `json:"tx"`
+	Status string      `json:"status"`
+	Reason string      `json:"reason,omitempty"`
+}
+
+// ChannelReport is a channel of the channel bank, with the batches read from it.
+type ChannelReport struct {
+	ID derive.ChannelID `json:"id"`
+	// OpenedAt is the L1 block of the first frame of the channel.
+	OpenedAt uint64 `json:"opened_at"`
+	// ClosedAt is the L1 block the channel got read or timed out at.
+	ClosedAt uint64         `json:"closed_at,omitempty"`
+	Status   string         `json:"status"`
+	Txs      []common.Hash  `json:"txs"`
+	Batches  []*BatchReport `json:"batches"`
+}
+
+// BatchReport is a batch read from a channel, with the L2 blocks derived from it.
+type BatchReport struct {
+	Type string `json:"type"`
+	// Timestamp is the timestamp in milliseconds of the first block of the batch.
+	Timestamp  uint64   `json:"timestamp"`
+	BlockCount int      `json:"block_count"`
+	L1Block    uint64   `json:"l1_block"`
+	Status     string   `json:"status"`
+	Reason     string   `json:"reason,omitempty"`
+	L2Blocks   []uint64 `json:"l2_blocks"`
+
+	channel *ChannelReport
+}
+
+// L2BlockReport is a derived L2 block.
+type L2BlockReport struct {
+	Number uint64      `json:"number"`
+	Hash   common.Hash `json:"hash"`
+	// Timestamp is the timestamp of the block in milliseconds.
+	Timestamp uint64 `json:"timestamp"`
+	// Channel is the channel of the batch the block got derived from,
+	// unset for the empty blocks generated when the sequence window expired.
+	Channel derive.ChannelID `json:"channel"`
+	Txs     int              `json:"txs"`
+}
+
+// DivergenceReport describes the first derived block which doesn't match the canonical L2 chain,
+// the derivation can't proceed past it without executing the block.
+type DivergenceReport struct {
+	Number uint64 `json:"number"`
+	Reason string `json:"reason"`
+}
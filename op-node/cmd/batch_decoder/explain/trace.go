@@ -0,0 +1,331 @@
+package explain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// Validities of the batch checked events.
+var (
+	validityAccept = derive.BatchValidity(derive.BatchAccept).String()
+	validityDrop   = derive.BatchValidity(derive.BatchDrop).String()
+)
+
+type frameKey struct {
+	l1Block     uint64
+	channel     derive.ChannelID
+	frameNumber uint16
+}
+
+// tracer follows the data through the derive stages, along with the decisions the stages emit, and builds the Report.
+type tracer struct {
+	l1        derive.L1TransactionFetcher
+	inbox     common.Address
+	blobs     derive.L1BlobsFetcher
+	signer    types.Signer
+	traversal *derive.L1Traversal
+
+	report *Report
+
+	scanned   map[uint64]bool
+	txByFrame map[frameKey]*TxReport
+	channels  map[derive.ChannelID]*ChannelReport
+	batches   []*BatchReport
+
+	// lastFrame is the frame last passed to the channel bank
+	lastFrame *FrameReport
+	// readChannel is the channel last read by the channel bank, the next batches are read from it
+	readChannel *ChannelReport
+	// current is the batch the last L2 block got derived from, nil when empty batches are generated
+	current *BatchReport
+	// parent is the L2 block the derivation builds upon
+	parent eth.L2BlockRef
+}
+
+func newTracer(l1 derive.L1TransactionFetcher, inbox common.Address, blobs derive.L1BlobsFetcher, signer types.Signer, report *Report) *tracer {
+	return &tracer{
+		l1:        l1,
+		inbox:     inbox,
+		blobs:     blobs,
+		signer:    signer,
+		report:    report,
+		scanned:   make(map[uint64]bool),
+		txByFrame: make(map[frameKey]*TxReport),
+		channels:  make(map[derive.ChannelID]*ChannelReport),
+	}
+}
+
+// scan decodes the frames of the batcher transactions of the given L1 block,
+// so the frames ingested by the channel bank can be traced back to their transaction.
+func (t *tracer) scan(ctx context.Context, ref eth.L1BlockRef) error {
+	if t.scanned[ref.Number] {
+		return nil
+	}
+	_, txs, err := t.l1.InfoAndTxsByHash(ctx, ref.Hash)
+	if err != nil {
+		return fmt.Errorf("failed to fetch transactions of L1 block %s: %w", ref, err)
+	}
+	sysCfg := t.traversal.SystemConfig()
+	blobIndex := 0 // index of each blob in the block's blob sidecar
+	for _, tx := range txs {
+		if tx.To() == nil || *tx.To() != t.inbox {
+			blobIndex += len(tx.BlobHashes())
+			continue
+		}
+		sender, err := types.Sender(t.signer, tx)
+		if err != nil || sender != sysCfg.BatcherAddr {
+			blobIndex += len(tx.BlobHashes())
+			continue
+		}
+		txr := &TxReport{Hash: tx.Hash(), L1Block: ref.Number, Sender: sender}
+		var datas []eth.Data
+		if tx.Type() != types.BlobTxType {
+			datas = append(datas, tx.Data())
+		} else if t.blobs == nil {
+			txr.ParseErrors = append(txr.ParseErrors, "no blobs fetcher to fetch the blobs of the transaction")
+			blobIndex += len(tx.BlobHashes())
+		} else {
+			var hashes []eth.IndexedBlobHash
+			for _, h := range tx.BlobHashes() {
+				hashes = append(hashes, eth.IndexedBlobHash{Index: uint64(blobIndex), Hash: h})
+				blobIndex += 1
+			}
+			blobs, err := t.blobs.GetBlobs(ctx, ref, hashes)
+			if err != nil {
+				return fmt.Errorf("failed to fetch blobs of tx %s: %w", tx.Hash(), err)
+			}
+			for _, blob := range blobs {
+				data, err := blob.ToData()
+				if err != nil {
+					txr.ParseErrors = append(txr.ParseErrors, err.Error())
+					continue
+				}
+				datas = append(datas, data)
+			}
+		}
+		for _, data := range datas {
+			frames, err := derive.ParseFrames(data)
+			if err != nil {
+				txr.ParseErrors = append(txr.ParseErrors, err.Error())
+				continue
+			}
+			for _, f := range frames {
+				t.txByFrame[frameKey{ref.Number, f.ID, f.FrameNumber}] = txr
+			}
+		}
+		t.report.Transactions = append(t.report.Transactions, txr)
+	}
+	t.scanned[ref.Number] = true
+	return nil
+}
+
+// frame records a frame passed to the channel bank, which ingests it at the given origin.
+func (t *tracer) frame(ctx context.Context, origin eth.L1BlockRef, f derive.Frame) error {
+	if err := t.scan(ctx, origin); err != nil {
+		return err
+	}
+	fr := &FrameReport{
+		Channel:     f.ID,
+		FrameNumber: f.FrameNumber,
+		IsLast:      f.IsLast,
+		L1Block:     origin.Number,
+		Status:      StatusIngested,
+	}
+	ch, ok := t.channels[f.ID]
+	// the channel bank opens a new channel once a channel with the same ID got read or timed out
+	if !ok || ch.Status != StatusPending {
+		ch = &ChannelReport{ID: f.ID, OpenedAt: origin.Number, Status: StatusPending}
+		t.channels[f.ID] = ch
+		t.report.Channels = append(t.report.Channels, ch)
+	}
+	if txr, ok := t.txByFrame[frameKey{origin.Number, f.ID, f.FrameNumber}]; ok {
+		fr.Tx = txr.Hash
+		txr.Frames = append(txr.Frames, fr)
+		if len(ch.Txs) == 0 || ch.Txs[len(ch.Txs)-1] != txr.Hash {
+			ch.Txs = append(ch.Txs, txr.Hash)
+		}
+	}
+	t.lastFrame = fr
+	return nil
+}
+
+// batch records a batch read from the last read channel, included in the given L1 block.
+func (t *tracer) batch(origin eth.L1BlockRef, batch derive.Batch) {
+	br := &BatchReport{
+		Timestamp: batch.GetTimestamp(),
+		L1Block:   origin.Number,
+		Status:    StatusPending,
+	}
+	if span, ok := batch.AsSpanBatch(); ok {
+		br.Type = "span"
+		br.BlockCount = span.GetBlockCount()
+	} else {
+		br.Type = "singular"
+		br.BlockCount = 1
+	}
+	// the batch queue ignores the batches included before the L1 origin of the safe head
+	if origin.Number < t.parent.L1Origin.Number {
+		br.Status = StatusSkipped
+		br.Reason = "included before the L1 origin of the L2 safe head"
+	}
+	if ch := t.readChannel; ch != nil {
+		br.channel = ch
+		ch.Batches = append(ch.Batches, br)
+	}
+	t.batches = append(t.batches, br)
+}
+
+// derived records a derived L2 block, built from the current batch.
+func (t *tracer) derived(ref eth.L2BlockRef, attrs *derive.AttributesWithParent) {
+	t.current = t.batchOf(ref)
+	block := &L2BlockReport{
+		Number:    ref.Number,
+		Hash:      ref.Hash,
+		Timestamp: ref.MillisecondTimestamp(),
+		Txs:       len(attrs.Attributes.Transactions),
+	}
+	if t.current != nil {
+		t.current.L2Blocks = append(t.current.L2Blocks, ref.Number)
+		if t.current.channel != nil {
+			block.Channel = t.current.channel.ID
+		}
+	}
+	t.report.Blocks = append(t.report.Blocks, block)
+	t.parent = ref
+}
+
+// batchOf returns the batch the given derived L2 block got built from, nil for the empty blocks
+// generated when the sequence window expired.
+func (t *tracer) batchOf(ref eth.L2BlockRef) *BatchReport {
+	ts := ref.MillisecondTimestamp()
+	for i := len(t.batches) - 1; i >= 0; i-- {
+		b := t.batches[i]
+		if b.Status != StatusAccepted || b.Timestamp != ts || len(b.L2Blocks) > 0 {
+			continue
+		}
+		// the batch queue drops the remaining blocks of a span batch once one of them doesn't apply
+		if c := t.current; c != nil && len(c.L2Blocks) < c.BlockCount {
+			c.Reason = fmt.Sprintf("%d of %d blocks derived, the remaining blocks got dropped", len(c.L2Blocks), c.BlockCount)
+		}
+		return b
+	}
+	if c := t.current; c != nil && len(c.L2Blocks) < c.BlockCount {
+		return c
+	}
+	return nil
+}
+
+// findBatch returns the latest batch the batch queue may still check, matching the batch of an event.
+func (t *tracer) findBatch(info *derive.BatchEventInfo) *BatchReport {
+	typ := "singular"
+	if info.BatchType == derive.SpanBatchType {
+		typ = "span"
+	}
+	for i := len(t.batches) - 1; i >= 0; i-- {
+		b := t.batches[i]
+		if b.Status == StatusSkipped || b.Status == StatusDropped {
+			continue
+		}
+		if b.Type == typ && b.Timestamp == info.Timestamp && b.L1Block == info.L1InclusionBlock.Number {
+			return b
+		}
+	}
+	return nil
+}
+
+var _ derive.DerivationEventListener = (*tracer)(nil)
+
+func (t *tracer) Enabled() bool {
+	return true
+}
+
+// OnDerivationEvent follows the decisions of the channel bank and the batch queue.
+func (t *tracer) OnDerivationEvent(ev derive.DerivationEvent) {
+	switch ev.Type {
+	case derive.FrameRejectedEvent:
+		if f := t.lastFrame; f != nil && f.Channel == ev.Frame.Channel && f.FrameNumber == ev.Frame.FrameNumber {
+			f.Status = StatusDropped
+			f.Reason = ev.Reason
+		}
+	case derive.ChannelReadEvent, derive.ChannelTimedOutEvent, derive.ChannelPrunedEvent:
+		ch, ok := t.channels[ev.Channel.ID]
+		if !ok {
+			return
+		}
+		ch.ClosedAt = ev.Origin.Number
+		switch ev.Type {
+		case derive.ChannelReadEvent:
+			ch.Status = StatusRead
+			t.readChannel = ch
+		case derive.ChannelTimedOutEvent:
+			ch.Status = StatusTimedOut
+		case derive.ChannelPrunedEvent:
+			ch.Status = StatusDropped
+		}
+	case derive.BatchCheckedEvent:
+		b := t.findBatch(ev.Batch)
+		if b == nil {
+			return
+		}
+		switch ev.Batch.Validity {
+		case validityAccept:
+			b.Status = StatusAccepted
+		case validityDrop:
+			b.Status = StatusDropped
+		default:
+			// undecided and future batches are checked again on the next L1 block
+			b.Status = StatusPending
+		}
+		b.Reason = ev.Reason
+	}
+}
+
+// frameTracer records the frames passed from the frame queue to the channel bank.
+type frameTracer struct {
+	prev derive.NextFrameProvider
+	t    *tracer
+}
+
+var _ derive.NextFrameProvider = (*frameTracer)(nil)
+
+func (ft *frameTracer) Origin() eth.L1BlockRef {
+	return ft.prev.Origin()
+}
+
+func (ft *frameTracer) NextFrame(ctx context.Context) (derive.Frame, error) {
+	f, err := ft.prev.NextFrame(ctx)
+	if err != nil {
+		return f, err
+	}
+	if err := ft.t.frame(ctx, ft.prev.Origin(), f); err != nil {
+		return derive.Frame{}, err
+	}
+	return f, nil
+}
+
+// batchTracer records the batches passed from the channel reader to the batch queue.
+type batchTracer struct {
+	prev derive.NextBatchProvider
+	t    *tracer
+}
+
+var _ derive.NextBatchProvider = (*batchTracer)(nil)
+
+func (bt *batchTracer) Origin() eth.L1BlockRef {
+	return bt.prev.Origin()
+}
+
+func (bt *batchTracer) NextBatch(ctx context.Context) (derive.Batch, error) {
+	batch, err := bt.prev.NextBatch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bt.t.batch(bt.prev.Origin(), batch)
+	return batch, nil
+}
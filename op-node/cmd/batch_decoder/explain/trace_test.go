@@ -0,0 +1,299 @@
+package explain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var (
+	channelA = derive.ChannelID{0xaa}
+	channelB = derive.ChannelID{0xbb}
+)
+
+func l1Ref(num uint64) eth.L1BlockRef {
+	return eth.L1BlockRef{Number: num, Hash: [32]byte{byte(num)}}
+}
+
+func l2Ref(num uint64, l1Origin uint64) eth.L2BlockRef {
+	return eth.L2BlockRef{Number: num, Hash: [32]byte{0xff, byte(num)}, Time: num * 2, L1Origin: l1Ref(l1Origin).ID()}
+}
+
+func singular(timestamp uint64) derive.Batch {
+	return &derive.SingularBatch{Timestamp: timestamp}
+}
+
+func span(timestamps ...uint64) derive.Batch {
+	batch := &derive.SpanBatch{}
+	for _, ts := range timestamps {
+		batch.Batches = append(batch.Batches, &derive.SpanBatchElement{Timestamp: ts})
+	}
+	return batch
+}
+
+// step drives the tracer like the derive stages do.
+type step func(t *testing.T, tr *tracer)
+
+func frame(origin uint64, id derive.ChannelID, number uint16, last bool) step {
+	return func(t *testing.T, tr *tracer) {
+		tr.scanned[origin] = true // no batcher transactions to scan
+		require.NoError(t, tr.frame(context.Background(), l1Ref(origin), derive.Frame{ID: id, FrameNumber: number, IsLast: last}))
+	}
+}
+
+func batch(origin uint64, b derive.Batch) step {
+	return func(t *testing.T, tr *tracer) {
+		tr.batch(l1Ref(origin), b)
+	}
+}
+
+func derived(num uint64, l1Origin uint64) step {
+	return func(t *testing.T, tr *tracer) {
+		tr.derived(l2Ref(num, l1Origin), &derive.AttributesWithParent{Attributes: &eth.PayloadAttributes{}})
+	}
+}
+
+func event(ev derive.DerivationEvent) step {
+	return func(t *testing.T, tr *tracer) {
+		tr.OnDerivationEvent(ev)
+	}
+}
+
+func channelEvent(typ derive.DerivationEventType, origin uint64, id derive.ChannelID) step {
+	return event(derive.DerivationEvent{Type: typ, Origin: l1Ref(origin).ID(), Channel: &derive.ChannelEventInfo{ID: id}})
+}
+
+func batchChecked(origin uint64, b derive.Batch, validity derive.BatchValidity, reason string) step {
+	info := &derive.BatchEventInfo{
+		BatchType:        b.GetBatchType(),
+		Timestamp:        b.GetTimestamp(),
+		L1InclusionBlock: l1Ref(origin).ID(),
+		Validity:         validity.String(),
+	}
+	return event(derive.DerivationEvent{Type: derive.BatchCheckedEvent, Origin: l1Ref(origin).ID(), Reason: reason, Batch: info})
+}
+
+func TestTracer(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps []step
+		check func(t *testing.T, report *Report, tr *tracer)
+	}{
+		{
+			name: "accepted batch of a read channel",
+			steps: []step{
+				frame(11, channelA, 0, true),
+				channelEvent(derive.ChannelReadEvent, 11, channelA),
+				batch(11, singular(22000)),
+				batchChecked(11, singular(22000), derive.BatchAccept, ""),
+				derived(11, 10),
+			},
+			check: func(t *testing.T, report *Report, tr *tracer) {
+				require.Len(t, report.Channels, 1)
+				ch := report.Channels[0]
+				require.Equal(t, StatusRead, ch.Status)
+				require.Equal(t, uint64(11), ch.OpenedAt)
+				require.Equal(t, uint64(11), ch.ClosedAt)
+				require.Len(t, ch.Batches, 1)
+				require.Equal(t, StatusAccepted, ch.Batches[0].Status)
+				require.Empty(t, ch.Batches[0].Reason)
+				require.Equal(t, []uint64{11}, ch.Batches[0].L2Blocks)
+				require.Len(t, report.Blocks, 1)
+				require.Equal(t, channelA, report.Blocks[0].Channel)
+			},
+		},
+		{
+			name: "timed out channel",
+			steps: []step{
+				frame(11, channelA, 0, false),
+				channelEvent(derive.ChannelTimedOutEvent, 16, channelA),
+			},
+			check: func(t *testing.T, report *Report, tr *tracer) {
+				require.Len(t, report.Channels, 1)
+				require.Equal(t, StatusTimedOut, report.Channels[0].Status)
+				require.Equal(t, uint64(16), report.Channels[0].ClosedAt)
+			},
+		},
+		{
+			name: "pruned channel",
+			steps: []step{
+				frame(11, channelA, 0, false),
+				channelEvent(derive.ChannelPrunedEvent, 12, channelA),
+			},
+			check: func(t *testing.T, report *Report, tr *tracer) {
+				require.Equal(t, StatusDropped, report.Channels[0].Status)
+				require.Equal(t, uint64(12), report.Channels[0].ClosedAt)
+			},
+		},
+		{
+			name: "channel reopened after it got read",
+			steps: []step{
+				frame(11, channelA, 0, true),
+				channelEvent(derive.ChannelReadEvent, 11, channelA),
+				frame(12, channelA, 0, false),
+			},
+			check: func(t *testing.T, report *Report, tr *tracer) {
+				require.Len(t, report.Channels, 2)
+				require.Equal(t, StatusRead, report.Channels[0].Status)
+				require.Equal(t, StatusPending, report.Channels[1].Status)
+				require.Equal(t, uint64(12), report.Channels[1].OpenedAt)
+			},
+		},
+		{
+			name: "rejected frame",
+			steps: []step{
+				frame(11, channelA, 0, false),
+				frame(11, channelA, 1, false),
+				event(derive.DerivationEvent{
+					Type:   derive.FrameRejectedEvent,
+					Origin: l1Ref(11).ID(),
+					Reason: "channel is timed out",
+					Frame:  &derive.FrameEventInfo{Channel: channelA, FrameNumber: 1},
+				}),
+			},
+			check: func(t *testing.T, report *Report, tr *tracer) {
+				require.Equal(t, StatusPending, tr.channels[channelA].Status, "the channel is untouched")
+				require.Equal(t, StatusDropped, tr.lastFrame.Status)
+				require.Equal(t, "channel is timed out", tr.lastFrame.Reason)
+			},
+		},
+		{
+			name: "rejected event of another frame",
+			steps: []step{
+				frame(11, channelA, 0, false),
+				event(derive.DerivationEvent{
+					Type:   derive.FrameRejectedEvent,
+					Reason: "frame already exists",
+					Frame:  &derive.FrameEventInfo{Channel: channelB, FrameNumber: 0},
+				}),
+			},
+			check: func(t *testing.T, report *Report, tr *tracer) {
+				require.Equal(t, StatusIngested, tr.lastFrame.Status)
+				require.Empty(t, tr.lastFrame.Reason)
+			},
+		},
+		{
+			name: "dropped batch",
+			steps: []step{
+				frame(11, channelA, 0, true),
+				channelEvent(derive.ChannelReadEvent, 11, channelA),
+				batch(11, singular(22000)),
+				batchChecked(11, singular(22000), derive.BatchDrop, "sequence window expired"),
+			},
+			check: func(t *testing.T, report *Report, tr *tracer) {
+				b := report.Channels[0].Batches[0]
+				require.Equal(t, StatusDropped, b.Status)
+				require.Equal(t, "sequence window expired", b.Reason)
+			},
+		},
+		{
+			name: "undecided batch",
+			steps: []step{
+				batch(11, singular(22000)),
+				batchChecked(11, singular(22000), derive.BatchUndecided, "missing L1 block input, cannot proceed with batch checking"),
+			},
+			check: func(t *testing.T, report *Report, tr *tracer) {
+				b := tr.batches[0]
+				require.Equal(t, StatusPending, b.Status)
+				require.Equal(t, "missing L1 block input, cannot proceed with batch checking", b.Reason)
+			},
+		},
+		{
+			name: "undecided batch accepted later",
+			steps: []step{
+				batch(11, singular(22000)),
+				batchChecked(11, singular(22000), derive.BatchUndecided, "missing L1 block input, cannot proceed with batch checking"),
+				batchChecked(11, singular(22000), derive.BatchAccept, ""),
+			},
+			check: func(t *testing.T, report *Report, tr *tracer) {
+				b := tr.batches[0]
+				require.Equal(t, StatusAccepted, b.Status)
+				require.Empty(t, b.Reason)
+			},
+		},
+		{
+			name: "batch included before the L1 origin of the safe head",
+			steps: []step{
+				batch(9, singular(22000)),
+				batchChecked(9, singular(22000), derive.BatchDrop, "dropped"),
+			},
+			check: func(t *testing.T, report *Report, tr *tracer) {
+				b := tr.batches[0]
+				require.Equal(t, StatusSkipped, b.Status)
+				require.Equal(t, "included before the L1 origin of the L2 safe head", b.Reason)
+			},
+		},
+		{
+			name: "span batch",
+			steps: []step{
+				frame(11, channelA, 0, true),
+				channelEvent(derive.ChannelReadEvent, 11, channelA),
+				batch(11, span(22000, 24000)),
+				batchChecked(11, span(22000, 24000), derive.BatchAccept, ""),
+				derived(11, 10),
+				derived(12, 10),
+			},
+			check: func(t *testing.T, report *Report, tr *tracer) {
+				b := report.Channels[0].Batches[0]
+				require.Equal(t, "span", b.Type)
+				require.Equal(t, 2, b.BlockCount)
+				require.Equal(t, StatusAccepted, b.Status)
+				require.Empty(t, b.Reason)
+				require.Equal(t, []uint64{11, 12}, b.L2Blocks)
+			},
+		},
+		{
+			name: "span batch with dropped remaining blocks",
+			steps: []step{
+				frame(11, channelA, 0, true),
+				channelEvent(derive.ChannelReadEvent, 11, channelA),
+				batch(11, span(22000, 24000, 26000)),
+				batchChecked(11, span(22000, 24000, 26000), derive.BatchAccept, ""),
+				derived(11, 10),
+				frame(12, channelB, 0, true),
+				channelEvent(derive.ChannelReadEvent, 12, channelB),
+				batch(12, singular(24000)),
+				batchChecked(12, singular(24000), derive.BatchAccept, ""),
+				derived(12, 10),
+			},
+			check: func(t *testing.T, report *Report, tr *tracer) {
+				spanBatch := report.Channels[0].Batches[0]
+				require.Equal(t, []uint64{11}, spanBatch.L2Blocks)
+				require.Equal(t, "1 of 3 blocks derived, the remaining blocks got dropped", spanBatch.Reason)
+				singularBatch := report.Channels[1].Batches[0]
+				require.Equal(t, []uint64{12}, singularBatch.L2Blocks)
+				require.Equal(t, channelB, report.Blocks[1].Channel)
+			},
+		},
+		{
+			name: "generated empty block",
+			steps: []step{
+				batch(11, singular(24000)),
+				batchChecked(11, singular(24000), derive.BatchFuture, "received out-of-order batch for future processing after next batch"),
+				derived(11, 10),
+			},
+			check: func(t *testing.T, report *Report, tr *tracer) {
+				require.Equal(t, StatusPending, tr.batches[0].Status)
+				require.Empty(t, tr.batches[0].L2Blocks)
+				require.Len(t, report.Blocks, 1)
+				require.Equal(t, derive.ChannelID{}, report.Blocks[0].Channel)
+				require.Nil(t, tr.current)
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			report := &Report{}
+			tr := newTracer(nil, [20]byte{}, nil, nil, report)
+			tr.parent = l2Ref(10, 10)
+			for _, s := range test.steps {
+				s(t, tr)
+			}
+			test.check(t, report, tr)
+		})
+	}
+}
@@ -13,7 +13,6 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
-	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -47,8 +46,10 @@ type Config struct {
 
 // Batches fetches & stores all transactions sent to the batch inbox address in
 // the given block range (inclusive to exclusive).
+// The blobs of blob transactions are fetched with the given blobs fetcher, either a
+// sources.L1BeaconClient or, on BSC, a sources.BSCBlobClient. It may be nil to skip blob transactions.
 // The transactions & metadata are written to the out directory.
-func Batches(client *ethclient.Client, beacon *sources.L1BeaconClient, config Config) (totalValid, totalInvalid uint64) {
+func Batches(client *ethclient.Client, blobs derive.L1BlobsFetcher, config Config) (totalValid, totalInvalid uint64) {
 	if err := os.MkdirAll(config.OutDirectory, 0750); err != nil {
 		log.Fatal(err)
 	}
@@ -64,7 +65,7 @@ func Batches(client *ethclient.Client, beacon *sources.L1BeaconClient, config Co
 		}
 		number := i
 		g.Go(func() error {
-			valid, invalid, err := fetchBatchesPerBlock(ctx, client, beacon, number, signer, config)
+			valid, invalid, err := fetchBatchesPerBlock(ctx, client, blobs, number, signer, config)
 			if err != nil {
 				return fmt.Errorf("error occurred while fetching block %d: %w", number, err)
 			}
@@ -80,7 +81,7 @@ func Batches(client *ethclient.Client, beacon *sources.L1BeaconClient, config Co
 }
 
 // fetchBatchesPerBlock gets a block & the parses all of the transactions in the block.
-func fetchBatchesPerBlock(ctx context.Context, client *ethclient.Client, beacon *sources.L1BeaconClient, number uint64, signer types.Signer, config Config) (uint64, uint64, error) {
+func fetchBatchesPerBlock(ctx context.Context, client *ethclient.Client, blobs derive.L1BlobsFetcher, number uint64, signer types.Signer, config Config) (uint64, uint64, error) {
 	validBatchCount := uint64(0)
 	invalidBatchCount := uint64(0)
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
@@ -108,8 +109,8 @@ func fetchBatchesPerBlock(ctx context.Context, client *ethclient.Client, beacon
 				datas = append(datas, tx.Data())
 				// no need to increment blobIndex because no blobs
 			} else {
-				if beacon == nil {
-					fmt.Printf("Unable to handle blob transaction (%s) because L1 Beacon API not provided\n", tx.Hash().String())
+				if blobs == nil {
+					fmt.Printf("Unable to handle blob transaction (%s) because neither L1 Beacon API nor BSC blob RPC provided\n", tx.Hash().String())
 					blobIndex += len(tx.BlobHashes())
 					continue
 				}
@@ -122,7 +123,7 @@ func fetchBatchesPerBlock(ctx context.Context, client *ethclient.Client, beacon
 					hashes = append(hashes, idh)
 					blobIndex += 1
 				}
-				blobs, err := beacon.GetBlobs(ctx, eth.L1BlockRef{
+				txBlobs, err := blobs.GetBlobs(ctx, eth.L1BlockRef{
 					Hash:       block.Hash(),
 					Number:     block.Number().Uint64(),
 					ParentHash: block.ParentHash(),
@@ -131,7 +132,7 @@ func fetchBatchesPerBlock(ctx context.Context, client *ethclient.Client, beacon
 				if err != nil {
 					log.Fatal(fmt.Errorf("failed to fetch blobs: %w", err))
 				}
-				for _, blob := range blobs {
+				for _, blob := range txBlobs {
 					data, err := blob.ToData()
 					if err != nil {
 						log.Fatal(fmt.Errorf("failed to parse blobs: %w", err))
@@ -6,16 +6,22 @@ import (
 	"log"
 	"math/big"
 	"os"
+	"path/filepath"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-node/cmd/batch_decoder/explain"
 	"github.com/ethereum-optimism/optimism/op-node/cmd/batch_decoder/fetch"
 	"github.com/ethereum-optimism/optimism/op-node/cmd/batch_decoder/reassemble"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/urfave/cli/v2"
 )
 
@@ -65,6 +71,12 @@ func main() {
 					Usage:    "Address of L1 Beacon-node HTTP endpoint to use",
 					EnvVars:  []string{"L1_BEACON"},
 				},
+				&cli.StringSliceFlag{
+					Name:     "l1.bsc-blob-rpc",
+					Required: false,
+					Usage:    "BSC RPC endpoints serving eth_getBlobSidecars, to fetch blobs on BSC where there is no Beacon API",
+					EnvVars:  []string{"L1_BSC_BLOB_RPC"},
+				},
 				&cli.IntFlag{
 					Name:  "concurrent-requests",
 					Value: 10,
@@ -82,18 +94,12 @@ func main() {
 				if err != nil {
 					log.Fatal(err)
 				}
-				beaconAddr := cliCtx.String("l1.beacon")
-				var beacon *sources.L1BeaconClient
-				if beaconAddr != "" {
-					beaconClient := sources.NewBeaconHTTPClient(client.NewBasicHTTPClient(beaconAddr, nil))
-					beaconCfg := sources.L1BeaconClientConfig{FetchAllSidecars: false}
-					beacon = sources.NewL1BeaconClient(beaconClient, beaconCfg)
-					_, err := beacon.GetVersion(ctx)
-					if err != nil {
-						log.Fatal(fmt.Errorf("failed to check L1 Beacon API version: %w", err))
-					}
-				} else {
-					fmt.Println("L1 Beacon endpoint not set. Unable to fetch post-ecotone channel frames")
+				blobs, err := newBlobsFetcher(ctx, cliCtx)
+				if err != nil {
+					log.Fatal(err)
+				}
+				if blobs == nil {
+					fmt.Println("Neither L1 Beacon endpoint nor BSC blob RPC set. Unable to fetch post-ecotone channel frames")
 				}
 				config := fetch.Config{
 					Start:   uint64(cliCtx.Int("start")),
@@ -106,7 +112,7 @@ func main() {
 					OutDirectory:       cliCtx.String("out"),
 					ConcurrentRequests: uint64(cliCtx.Int("concurrent-requests")),
 				}
-				totalValid, totalInvalid := fetch.Batches(l1Client, beacon, config)
+				totalValid, totalInvalid := fetch.Batches(l1Client, blobs, config)
 				fmt.Printf("Fetched batches in range [%v,%v). Found %v valid & %v invalid batches\n", config.Start, config.End, totalValid, totalInvalid)
 				fmt.Printf("Fetch Config: Chain ID: %v. Inbox Address: %v. Valid Senders: %v.\n", config.ChainID, config.BatchInbox, config.BatchSenders)
 				fmt.Printf("Wrote transactions with batches to %v\n", config.OutDirectory)
@@ -186,6 +192,113 @@ func main() {
 				return nil
 			},
 		},
+		{
+			Name:  "explain",
+			Usage: "Re-derives the L2 blocks from the batches of the specified L1 range and explains the derivation",
+			Flags: []cli.Flag{
+				&cli.Uint64Flag{
+					Name:     "start",
+					Required: true,
+					Usage:    "First L1 block (inclusive) to derive from",
+				},
+				&cli.Uint64Flag{
+					Name:     "end",
+					Required: true,
+					Usage:    "Last L1 block (exclusive) to derive from",
+				},
+				&cli.StringFlag{
+					Name:     "rollup-config",
+					Required: true,
+					Usage:    "Rollup chain parameters",
+				},
+				&cli.StringFlag{
+					Name:     "l1",
+					Required: true,
+					Usage:    "L1 RPC URL",
+					EnvVars:  []string{"L1_RPC"},
+				},
+				&cli.StringFlag{
+					Name:     "l2",
+					Required: true,
+					Usage:    "L2 execution engine RPC URL, the derived blocks are checked against its chain",
+					EnvVars:  []string{"L2_RPC"},
+				},
+				&cli.StringFlag{
+					Name:     "l1.beacon",
+					Required: false,
+					Usage:    "Address of L1 Beacon-node HTTP endpoint to use",
+					EnvVars:  []string{"L1_BEACON"},
+				},
+				&cli.StringSliceFlag{
+					Name:     "l1.bsc-blob-rpc",
+					Required: false,
+					Usage:    "BSC RPC endpoints serving eth_getBlobSidecars, to fetch blobs on BSC where there is no Beacon API",
+					EnvVars:  []string{"L1_BSC_BLOB_RPC"},
+				},
+				&cli.StringFlag{
+					Name:  "out",
+					Value: "/tmp/batch_decoder/explain.json",
+					Usage: "File to write the derivation report to",
+				},
+				&cli.StringFlag{
+					Name:  "log.level",
+					Value: "warn",
+					Usage: "The lowest log level of the derivation logs that will be output",
+				},
+			},
+			Action: func(cliCtx *cli.Context) error {
+				rollupCfg, err := jsonutil.LoadJSON[rollup.Config](cliCtx.String("rollup-config"))
+				if err != nil {
+					log.Fatal(err)
+				}
+				lvl, err := oplog.LevelFromString(cliCtx.String("log.level"))
+				if err != nil {
+					log.Fatal(err)
+				}
+				logger := gethlog.NewLogger(gethlog.NewTerminalHandlerWithLevel(os.Stderr, lvl, true))
+				ctx := context.Background()
+				l1RPC, err := client.NewRPC(ctx, logger, cliCtx.String("l1"))
+				if err != nil {
+					log.Fatal(err)
+				}
+				l1Client, err := sources.NewL1Client(l1RPC, logger, nil, sources.L1ClientDefaultConfig(rollupCfg, true, sources.RPCKindStandard))
+				if err != nil {
+					log.Fatal(err)
+				}
+				l2RPC, err := client.NewRPC(ctx, logger, cliCtx.String("l2"))
+				if err != nil {
+					log.Fatal(err)
+				}
+				l2Client, err := sources.NewL2Client(l2RPC, logger, nil, sources.L2ClientDefaultConfig(rollupCfg, true))
+				if err != nil {
+					log.Fatal(err)
+				}
+				blobs, err := newBlobsFetcher(ctx, cliCtx)
+				if err != nil {
+					log.Fatal(err)
+				}
+				config := explain.Config{
+					Start: cliCtx.Uint64("start"),
+					End:   cliCtx.Uint64("end"),
+				}
+				report, err := explain.Explain(ctx, logger, rollupCfg, l1Client, blobs, l2Client, config)
+				if err != nil {
+					log.Fatal(err)
+				}
+				if err := os.MkdirAll(filepath.Dir(cliCtx.String("out")), 0750); err != nil {
+					log.Fatal(err)
+				}
+				if err := jsonutil.WriteJSON(cliCtx.String("out"), report, 0o644); err != nil {
+					log.Fatal(err)
+				}
+				fmt.Printf("Derived L2 blocks %v to %v from L1 blocks [%v,%v)\n", report.L2Start.Number+1, report.L2End.Number, config.Start, config.End)
+				if report.Divergence != nil {
+					fmt.Printf("Derivation diverged from the L2 chain at block %v: %v\n", report.Divergence.Number, report.Divergence.Reason)
+				}
+				fmt.Printf("Wrote derivation report to %v\n", cliCtx.String("out"))
+				return nil
+			},
+		},
 		{
 			Name:  "force-close",
 			Usage: "Create the tx data which will force close a channel",
@@ -232,3 +345,30 @@ func main() {
 		log.Fatal(err)
 	}
 }
+
+// newBlobsFetcher returns the fetcher of the blobs of blob transactions, either from the L1 Beacon API,
+// or from the BSC RPC endpoints serving eth_getBlobSidecars. It returns nil if neither is configured.
+func newBlobsFetcher(ctx context.Context, cliCtx *cli.Context) (derive.L1BlobsFetcher, error) {
+	beaconAddr := cliCtx.String("l1.beacon")
+	bscBlobAddrs := cliCtx.StringSlice("l1.bsc-blob-rpc")
+	if beaconAddr != "" {
+		beaconClient := sources.NewBeaconHTTPClient(client.NewBasicHTTPClient(beaconAddr, nil))
+		beaconCfg := sources.L1BeaconClientConfig{FetchAllSidecars: false}
+		beacon := sources.NewL1BeaconClient(beaconClient, beaconCfg)
+		if _, err := beacon.GetVersion(ctx); err != nil {
+			return nil, fmt.Errorf("failed to check L1 Beacon API version: %w", err)
+		}
+		return beacon, nil
+	} else if len(bscBlobAddrs) > 0 {
+		var rpcs []client.RPC
+		for _, addr := range bscBlobAddrs {
+			rpcClient, err := rpc.DialContext(ctx, addr)
+			if err != nil {
+				return nil, fmt.Errorf("failed to dial BSC blob RPC %s: %w", addr, err)
+			}
+			rpcs = append(rpcs, client.NewBaseRPCClient(rpcClient))
+		}
+		return sources.NewBSCBlobClient(rpcs), nil
+	}
+	return nil, nil
+}
@@ -133,6 +133,12 @@ var (
 		EnvVars:  prefixEnvVars("RPC_ENABLE_ADMIN"),
 		Category: OperationsCategory,
 	}
+	RPCEnableDerivationEvents = &cli.BoolFlag{
+		Name:     "rpc.enable-derivation-events",
+		Usage:    "Enable the optimism_derivationEvents subscription, streaming the decisions of the derivation pipeline over websocket",
+		EnvVars:  prefixEnvVars("RPC_ENABLE_DERIVATION_EVENTS"),
+		Category: OperationsCategory,
+	}
 	RPCAdminPersistence = &cli.StringFlag{
 		Name:     "rpc.admin-state",
 		Usage:    "File path used to persist state changes made via the admin API so they persist across restarts. Disabled if not set.",
@@ -200,11 +206,38 @@ var (
 	}
 	L1ArchiveBlobRpcAddr = &cli.StringFlag{
 		Name:     "l1.archive-blob-rpc",
-		Usage:    "Optional address of L1 archive blob endpoint to use. Multiple alternative addresses are supported, separated by commas, and will rotate when error",
+		Usage:    "Optional address of L1 archive blob endpoint to use, consulted in the order set by l1.blob-providers. Multiple alternative addresses are supported, separated by commas, and will rotate when error",
 		Required: false,
 		EnvVars:  prefixEnvVars("L1_ARCHIVE_BLOB_RPC"),
 		Category: RollupCategory,
 	}
+	L1BlobArchiveDir = &cli.StringFlag{
+		Name:     "l1.blob-archive-dir",
+		Usage:    "Optional directory of an on-disk blob sidecar archive, holding one <block-hash>.json file of sidecars per L1 block.",
+		Required: false,
+		EnvVars:  prefixEnvVars("L1_BLOB_ARCHIVE_DIR"),
+		Category: RollupCategory,
+	}
+	L1BlobArchiveHTTPAddr = &cli.StringFlag{
+		Name:     "l1.blob-archive-http",
+		Usage:    "Optional address of an HTTP blob-archive service, serving blob sidecars by versioned hash.",
+		Required: false,
+		EnvVars:  prefixEnvVars("L1_BLOB_ARCHIVE_HTTP"),
+		Category: RollupCategory,
+	}
+	L1BlobProviders = &cli.StringSliceFlag{
+		Name: "l1.blob-providers",
+		Usage: fmt.Sprintf("Priority order of the blob sidecar providers to consult, highest priority first. "+
+			"Providers that are not configured are skipped. Supported providers: %s.", openum.EnumString(sources.BlobProviderKinds)),
+		Value: cli.NewStringSlice(
+			sources.BlobProviderRPC.String(),
+			sources.BlobProviderArchiveRPC.String(),
+			sources.BlobProviderArchiveDir.String(),
+			sources.BlobProviderArchiveHTTP.String(),
+		),
+		EnvVars:  prefixEnvVars("L1_BLOB_PROVIDERS"),
+		Category: RollupCategory,
+	}
 	L1BlobRpcRateLimit = &cli.Float64Flag{
 		Name:     "l1.blob-rpc-rate-limit",
 		Usage:    "Optional self-imposed global rate-limit on L1 blob RPC requests, specified in requests / second. Disabled if set to 0.",
@@ -348,6 +381,39 @@ var (
 		EnvVars:  prefixEnvVars("SAFEDB_PATH"),
 		Category: OperationsCategory,
 	}
+	BlobDBPath = &cli.StringFlag{
+		Name:     "blobdb.path",
+		Usage:    "File path used to persist validated blob sidecars, which are then served locally and via the optimism_blobSidecars RPC. Disabled if not set.",
+		EnvVars:  prefixEnvVars("BLOBDB_PATH"),
+		Category: OperationsCategory,
+	}
+	BlobDBRetention = &cli.Uint64Flag{
+		Name:     "blobdb.retention",
+		Usage:    "Number of L1 blocks to keep blob sidecars for in the blob sidecar database. Kept forever if 0.",
+		EnvVars:  prefixEnvVars("BLOBDB_RETENTION"),
+		Value:    0,
+		Category: OperationsCategory,
+	}
+	PayloadDBPath = &cli.StringFlag{
+		Name:     "payloaddb.path",
+		Usage:    "File path used to persist received unsafe payloads, which are then reloaded at startup and served to peers. Disabled if not set.",
+		EnvVars:  prefixEnvVars("PAYLOADDB_PATH"),
+		Category: OperationsCategory,
+	}
+	PayloadDBMaxPayloads = &cli.Uint64Flag{
+		Name:     "payloaddb.max-payloads",
+		Usage:    "Maximum number of unsafe payloads to keep in the unsafe payload database. Unbounded if 0.",
+		EnvVars:  prefixEnvVars("PAYLOADDB_MAX_PAYLOADS"),
+		Value:    3600,
+		Category: OperationsCategory,
+	}
+	PayloadDBMaxAge = &cli.DurationFlag{
+		Name:     "payloaddb.max-age",
+		Usage:    "Maximum age of unsafe payloads to keep in the unsafe payload database, relative to the latest payload. Unbounded if 0.",
+		EnvVars:  prefixEnvVars("PAYLOADDB_MAX_AGE"),
+		Value:    time.Hour,
+		Category: OperationsCategory,
+	}
 	FastnodeMode = &cli.BoolFlag{
 		Name:    "fastnode",
 		Usage:   "Fastnode has a strong dependency on a specific synchronization mode during synchronization, so please set this flag when running fastnode.",
@@ -360,6 +426,18 @@ var (
 		Value:   86400,
 		EnvVars: prefixEnvVars("EL_TRIGGER_GAP"),
 	}
+	ELCheckpointNumber = &cli.Uint64Flag{
+		Name:     "el-sync.checkpoint-number",
+		Usage:    "Number of a trusted L2 block to bootstrap execution-layer sync from. Must be set together with --el-sync.checkpoint-hash.",
+		EnvVars:  prefixEnvVars("EL_SYNC_CHECKPOINT_NUMBER"),
+		Category: RollupCategory,
+	}
+	ELCheckpointHash = &cli.StringFlag{
+		Name:     "el-sync.checkpoint-hash",
+		Usage:    "Hash of a trusted L2 block to bootstrap execution-layer sync from. Must be set together with --el-sync.checkpoint-number.",
+		EnvVars:  prefixEnvVars("EL_SYNC_CHECKPOINT_HASH"),
+		Category: RollupCategory,
+	}
 	/* Deprecated Flags */
 	L2EngineSyncEnabled = &cli.BoolFlag{
 		Name:    "l2.engine-sync",
@@ -440,6 +518,8 @@ var optionalFlags = []cli.Flag{
 	SyncModeFlag,
 	FastnodeMode,
 	ELTriggerGap,
+	ELCheckpointNumber,
+	ELCheckpointHash,
 	RPCListenAddr,
 	RPCListenPort,
 	L1TrustRPC,
@@ -450,6 +530,9 @@ var optionalFlags = []cli.Flag{
 	L1RPCMaxConcurrency,
 	L1HTTPPollInterval,
 	L1ArchiveBlobRpcAddr,
+	L1BlobArchiveDir,
+	L1BlobArchiveHTTPAddr,
+	L1BlobProviders,
 	L1BlobRpcRateLimit,
 	L1BlobRpcMaxBatchSize,
 	VerifierL1Confs,
@@ -464,6 +547,7 @@ var optionalFlags = []cli.Flag{
 	L1EpochPollIntervalFlag,
 	RuntimeConfigReloadIntervalFlag,
 	RPCEnableAdmin,
+	RPCEnableDerivationEvents,
 	RPCAdminPersistence,
 	MetricsEnabledFlag,
 	MetricsAddrFlag,
@@ -479,6 +563,11 @@ var optionalFlags = []cli.Flag{
 	ConductorRpcFlag,
 	ConductorRpcTimeoutFlag,
 	SafeDBPath,
+	BlobDBPath,
+	BlobDBRetention,
+	PayloadDBPath,
+	PayloadDBMaxPayloads,
+	PayloadDBMaxAge,
 }
 
 var DeprecatedFlags = []cli.Flag{
@@ -75,6 +75,8 @@ type Metricer interface {
 	ReportProtocolVersions(local, engine, recommended, required params.ProtocolVersion)
 	RecordL1UrlSwitchEvent()
 	RecordSequencerStepTime(step string, duration time.Duration)
+	RecordBlobProviderRequest(provider string, result string, duration time.Duration)
+	RecordELSyncProgress(target uint64, current uint64, remaining time.Duration)
 }
 
 // Metrics tracks all the metrics for the op-node.
@@ -109,6 +111,9 @@ type Metrics struct {
 
 	L1RequestDurationSeconds *prometheus.HistogramVec
 
+	BlobProviderRequestsTotal          *prometheus.CounterVec
+	BlobProviderRequestDurationSeconds *prometheus.HistogramVec
+
 	SequencerBuildingDiffDurationSeconds prometheus.Histogram
 	SequencerBuildingDiffTotal           prometheus.Counter
 
@@ -117,6 +122,10 @@ type Metrics struct {
 
 	SequencerStepDurationSeconds *prometheus.HistogramVec
 
+	ELSyncTarget           prometheus.Gauge
+	ELSyncCurrent          prometheus.Gauge
+	ELSyncRemainingSeconds prometheus.Gauge
+
 	UnsafePayloadsBufferLen     prometheus.Gauge
 	UnsafePayloadsBufferMemSize prometheus.Gauge
 
@@ -348,6 +357,22 @@ func NewMetrics(procName string) *Metrics {
 			Help: "Histogram of L1 request time",
 		}, []string{"request"}),
 
+		BlobProviderRequestsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "blob_provider_requests_total",
+			Help:      "Number of blob sidecar requests, by provider and result",
+		}, []string{
+			"provider",
+			"result",
+		}),
+		BlobProviderRequestDurationSeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "blob_provider_request_seconds",
+			Buckets: []float64{
+				.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+			Help: "Histogram of blob sidecar request time, by provider",
+		}, []string{"provider"}),
+
 		SequencerBuildingDiffDurationSeconds: factory.NewHistogram(prometheus.HistogramOpts{
 			Namespace: ns,
 			Name:      "sequencer_building_diff_seconds",
@@ -381,6 +406,21 @@ func NewMetrics(procName string) *Metrics {
 			},
 			[]string{"step"},
 		),
+		ELSyncTarget: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "el_sync_target",
+			Help:      "L2 block number the execution engine is EL syncing towards",
+		}),
+		ELSyncCurrent: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "el_sync_current",
+			Help:      "L2 block number of the execution engine head while EL syncing",
+		}),
+		ELSyncRemainingSeconds: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "el_sync_remaining_seconds",
+			Help:      "Estimated number of seconds until the execution engine reaches the EL sync target, 0 if unknown",
+		}),
 		ProtocolVersionDelta: factory.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: ns,
 			Name:      "protocol_version_delta",
@@ -558,6 +598,19 @@ func (m *Metrics) RecordSequencerStepTime(step string, duration time.Duration) {
 	m.SequencerStepDurationSeconds.WithLabelValues(step).Observe(float64(duration) / float64(time.Second))
 }
 
+// RecordBlobProviderRequest tracks the result and duration of a blob sidecar request to a single provider.
+func (m *Metrics) RecordBlobProviderRequest(provider string, result string, duration time.Duration) {
+	m.BlobProviderRequestsTotal.WithLabelValues(provider, result).Inc()
+	m.BlobProviderRequestDurationSeconds.WithLabelValues(provider).Observe(float64(duration) / float64(time.Second))
+}
+
+// RecordELSyncProgress tracks the progress of the execution engine towards the EL sync target.
+func (m *Metrics) RecordELSyncProgress(target uint64, current uint64, remaining time.Duration) {
+	m.ELSyncTarget.Set(float64(target))
+	m.ELSyncCurrent.Set(float64(current))
+	m.ELSyncRemainingSeconds.Set(remaining.Seconds())
+}
+
 // StartServer starts the metrics server on the given hostname and port.
 func (m *Metrics) StartServer(hostname string, port int) (*ophttp.HTTPServer, error) {
 	addr := net.JoinHostPort(hostname, strconv.Itoa(port))
@@ -774,3 +827,9 @@ func (n *noopMetricer) RecordL1UrlSwitchEvent() {
 
 func (n *noopMetricer) RecordSequencerStepTime(step string, duration time.Duration) {
 }
+
+func (n *noopMetricer) RecordBlobProviderRequest(provider string, result string, duration time.Duration) {
+}
+
+func (n *noopMetricer) RecordELSyncProgress(target uint64, current uint64, remaining time.Duration) {
+}
@@ -5,12 +5,15 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/ethereum-optimism/optimism/op-node/node/blobdb"
 	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/version"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/metrics"
@@ -35,19 +38,37 @@ type driverClient interface {
 	OnUnsafeL2Payload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error
 }
 
+// maxSafeHeadUpdatesRange is the maximum number of L1 blocks a single safe head updates query can cover.
+const maxSafeHeadUpdatesRange = 10_000
+
+var ErrSafeHeadUpdatesRange = errors.New("safe head updates range too large")
+
 type SafeDBReader interface {
 	SafeHeadAtL1(ctx context.Context, l1BlockNum uint64) (l1 eth.BlockID, l2 eth.BlockID, err error)
+	SafeHeadUpdates(ctx context.Context, fromL1 uint64, toL1 uint64) ([]eth.SafeHeadResponse, error)
+	SafeHeadAtL2(ctx context.Context, l2BlockNum uint64) (l1 eth.BlockID, l2 eth.BlockID, err error)
+}
+
+type SafeDBImporter interface {
+	ImportSafeHeads(updates []eth.SafeHeadResponse) error
+}
+
+type BlobDBReader interface {
+	Enabled() bool
+	BlobSidecarsByBlockHash(ctx context.Context, blockHash common.Hash) (eth.BSCBlobSidecars, error)
 }
 
 type adminAPI struct {
 	*rpc.CommonAdminAPI
-	dr driverClient
+	dr     driverClient
+	safeDB SafeDBImporter
 }
 
-func NewAdminAPI(dr driverClient, m metrics.RPCMetricer, log log.Logger) *adminAPI {
+func NewAdminAPI(dr driverClient, safeDB SafeDBImporter, m metrics.RPCMetricer, log log.Logger) *adminAPI {
 	return &adminAPI{
 		CommonAdminAPI: rpc.NewCommonAdminAPI(m, log),
 		dr:             dr,
+		safeDB:         safeDB,
 	}
 }
 
@@ -91,6 +112,17 @@ func (n *adminAPI) PostUnsafePayload(ctx context.Context, envelope *eth.Executio
 	return n.dr.OnUnsafeL2Payload(ctx, envelope)
 }
 
+// ImportSafeHeads records safe head updates exported with optimism_safeHeadUpdates from another node.
+// The existing entries from the L1 block of the first update up to the one of the last update are replaced.
+func (n *adminAPI) ImportSafeHeads(_ context.Context, updates []eth.SafeHeadResponse) error {
+	recordDur := n.M.RecordRPCServerRequest("admin_importSafeHeads")
+	defer recordDur()
+	if err := n.safeDB.ImportSafeHeads(updates); err != nil {
+		return fmt.Errorf("failed to import safe heads: %w", err)
+	}
+	return nil
+}
+
 type nodeAPI struct {
 	config *rollup.Config
 	client l2EthClient
@@ -149,6 +181,40 @@ func (n *nodeAPI) SafeHeadAtL1Block(ctx context.Context, number hexutil.Uint64)
 	}, nil
 }
 
+// SafeHeadUpdates returns the safe head updates recorded at L1 blocks from fromL1 to toL1 inclusive.
+// Paging through it exports the safe head database, to be imported with admin_importSafeHeads.
+func (n *nodeAPI) SafeHeadUpdates(ctx context.Context, fromL1 hexutil.Uint64, toL1 hexutil.Uint64) ([]eth.SafeHeadResponse, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_safeHeadUpdates")
+	defer recordDur()
+	if toL1 >= fromL1 && toL1-fromL1 >= maxSafeHeadUpdatesRange {
+		return nil, fmt.Errorf("%w: %d L1 blocks requested, at most %d allowed", ErrSafeHeadUpdatesRange, toL1-fromL1+1, maxSafeHeadUpdatesRange)
+	}
+	updates, err := n.safeDB.SafeHeadUpdates(ctx, uint64(fromL1), uint64(toL1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get safe head updates from l1 block %s to %s: %w", fromL1, toL1, err)
+	}
+	if updates == nil {
+		updates = []eth.SafeHeadResponse{}
+	}
+	return updates, nil
+}
+
+// SafeHeadAtL2Block returns the first L1 block at which the given L2 block became safe.
+func (n *nodeAPI) SafeHeadAtL2Block(ctx context.Context, number hexutil.Uint64) (*eth.SafeHeadResponse, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_safeHeadAtL2Block")
+	defer recordDur()
+	l1Block, safeHead, err := n.safeDB.SafeHeadAtL2(ctx, uint64(number))
+	if errors.Is(err, safedb.ErrNotFound) {
+		return nil, err
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get safe head at l2 block %s: %w", number, err)
+	}
+	return &eth.SafeHeadResponse{
+		L1Block:  l1Block,
+		SafeHead: safeHead,
+	}, nil
+}
+
 func (n *nodeAPI) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
 	recordDur := n.m.RecordRPCServerRequest("optimism_syncStatus")
 	defer recordDur()
@@ -166,3 +232,79 @@ func (n *nodeAPI) Version(ctx context.Context) (string, error) {
 	defer recordDur()
 	return version.Version + "-" + version.Meta, nil
 }
+
+type blobAPI struct {
+	blobDB BlobDBReader
+	log    log.Logger
+	m      metrics.RPCMetricer
+}
+
+func NewBlobAPI(blobDB BlobDBReader, log log.Logger, m metrics.RPCMetricer) *blobAPI {
+	return &blobAPI{
+		blobDB: blobDB,
+		log:    log,
+		m:      m,
+	}
+}
+
+// BlobSidecars returns the archived blob sidecars of the given L1 block,
+// in the same format as the eth_getBlobSidecars RPC of the L1 node.
+func (n *blobAPI) BlobSidecars(ctx context.Context, blockHash common.Hash) (eth.BSCBlobSidecars, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_blobSidecars")
+	defer recordDur()
+	blobSidecars, err := n.blobDB.BlobSidecarsByBlockHash(ctx, blockHash)
+	if errors.Is(err, blobdb.ErrNotFound) {
+		return nil, err
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get blob sidecars of l1 block %s: %w", blockHash, err)
+	}
+	return blobSidecars, nil
+}
+
+// derivationEventsBuffer is the number of derivation events buffered for a subscriber,
+// before events are dropped for it.
+const derivationEventsBuffer = 1024
+
+type derivationEventsAPI struct {
+	feed *derive.DerivationEventFeed
+	log  log.Logger
+	m    metrics.RPCMetricer
+}
+
+func NewDerivationEventsAPI(feed *derive.DerivationEventFeed, log log.Logger, m metrics.RPCMetricer) *derivationEventsAPI {
+	return &derivationEventsAPI{
+		feed: feed,
+		log:  log,
+		m:    m,
+	}
+}
+
+// DerivationEvents streams the decisions of the derivation pipeline, until the subscription is cancelled.
+func (n *derivationEventsAPI) DerivationEvents(ctx context.Context) (*gethrpc.Subscription, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_derivationEvents")
+	defer recordDur()
+	notifier, supported := gethrpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, gethrpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+	sub := n.feed.Subscribe(derivationEventsBuffer)
+	go func() {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case ev := <-sub.Events():
+				if err := notifier.Notify(rpcSub.ID, ev); err != nil {
+					n.log.Warn("Failed to notify derivation event", "err", err)
+					return
+				}
+			case <-rpcSub.Err():
+				if dropped := sub.Dropped(); dropped > 0 {
+					n.log.Info("Derivation events subscriber fell behind", "dropped", dropped)
+				}
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
@@ -0,0 +1,300 @@
+package blobdb
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+)
+
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrInvalidEntry = errors.New("invalid db entry")
+)
+
+const (
+	// Keys are prefixed with a constant byte to allow us to differentiate different "columns" within the data
+	keyPrefixBlobsByL1Block   byte = 1
+	keyPrefixL1BlockNumByHash byte = 2
+)
+
+// blobsByL1BlockKey orders the blobs of each L1 block by block number, so old blocks can be pruned by range.
+// Blobs are keyed by the hash of their L1 block along with their versioned hash, so a blob included again
+// in a block of a reorg is stored separately, and pruning a block never deletes the blobs of another block.
+type blobsByL1BlockKey struct{}
+
+func (blobsByL1BlockKey) Of(num uint64, blockHash common.Hash, versionedHash common.Hash) []byte {
+	key := make([]byte, 0, 73)
+	key = append(key, keyPrefixBlobsByL1Block)
+	key = binary.BigEndian.AppendUint64(key, num)
+	key = append(key, blockHash.Bytes()...)
+	key = append(key, versionedHash.Bytes()...)
+	return key
+}
+
+func (blobsByL1BlockKey) Block(num uint64, blockHash common.Hash) *pebble.IterOptions {
+	lower := make([]byte, 0, 41)
+	lower = append(lower, keyPrefixBlobsByL1Block)
+	lower = binary.BigEndian.AppendUint64(lower, num)
+	lower = append(lower, blockHash.Bytes()...)
+	upper := append(slices.Clone(lower), common.MaxHash.Bytes()...)
+	upper = append(upper, 0xff)
+	return &pebble.IterOptions{
+		LowerBound: lower,
+		UpperBound: upper,
+	}
+}
+
+func (blobsByL1BlockKey) Before(num uint64) *pebble.IterOptions {
+	return &pebble.IterOptions{
+		LowerBound: binary.BigEndian.AppendUint64([]byte{keyPrefixBlobsByL1Block}, 0),
+		UpperBound: binary.BigEndian.AppendUint64([]byte{keyPrefixBlobsByL1Block}, num),
+	}
+}
+
+func decodeBlobsByL1Block(key []byte) (l1 eth.BlockID, versionedHash common.Hash, err error) {
+	if len(key) != 73 || key[0] != keyPrefixBlobsByL1Block {
+		err = ErrInvalidEntry
+		return
+	}
+	l1.Number = binary.BigEndian.Uint64(key[1:9])
+	copy(l1.Hash[:], key[9:41])
+	copy(versionedHash[:], key[41:])
+	return
+}
+
+func hashKey(prefix byte, h common.Hash) []byte {
+	key := make([]byte, 0, 33)
+	key = append(key, prefix)
+	key = append(key, h.Bytes()...)
+	return key
+}
+
+// BlobDB is a persistent archive of validated blob sidecars.
+// Every blob is stored as a single-blob eth.BSCBlobSidecar, keyed by the L1 block that included it and its versioned hash.
+type BlobDB struct {
+	// m ensures all read iterators are closed before closing the database by preventing concurrent read and write
+	// operations (with close considered a write operation).
+	m   sync.RWMutex
+	log log.Logger
+	db  *pebble.DB
+
+	// retention is the number of L1 blocks to keep sidecars for, relative to the latest stored L1 block.
+	// Sidecars are kept forever if 0.
+	retention uint64
+
+	writeOpts *pebble.WriteOptions
+
+	closed bool
+}
+
+var _ sources.BlobSidecarsProvider = (*BlobDB)(nil)
+
+func NewBlobDB(logger log.Logger, path string, retention uint64) (*BlobDB, error) {
+	db, err := pebble.Open(path, &pebble.Options{})
+	if err != nil {
+		return nil, err
+	}
+	return &BlobDB{
+		log:       logger,
+		db:        db,
+		retention: retention,
+		writeOpts: &pebble.WriteOptions{Sync: true},
+	}, nil
+}
+
+func (d *BlobDB) Enabled() bool {
+	return true
+}
+
+// BlobSidecarsFetched persists the blobs of the given sidecars, which must already have been validated against ref.
+func (d *BlobDB) BlobSidecarsFetched(ref eth.L1BlockRef, blobSidecars eth.BSCBlobSidecars) {
+	if err := d.storeBlobSidecars(ref, blobSidecars); err != nil {
+		d.log.Warn("Failed to store blob sidecars", "l1", ref, "err", err)
+	}
+}
+
+func (d *BlobDB) storeBlobSidecars(ref eth.L1BlockRef, blobSidecars eth.BSCBlobSidecars) error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	batch := d.db.NewBatch()
+	defer batch.Close()
+	count := 0
+	for _, sidecar := range blobSidecars {
+		for i := range sidecar.Blobs {
+			versionedHash := eth.KZGToVersionedHash(kzg4844.Commitment(sidecar.Commitments[i]))
+			indexKey := blobsByL1BlockKey{}.Of(ref.Number, ref.Hash, versionedHash)
+			if _, closer, err := d.db.Get(indexKey); err == nil {
+				// Already stored, e.g. when the blob was served by this database
+				_ = closer.Close()
+				continue
+			} else if !errors.Is(err, pebble.ErrNotFound) {
+				return fmt.Errorf("failed to check blob %s: %w", versionedHash, err)
+			}
+			single := *sidecar
+			single.Blobs = []eth.Blob{sidecar.Blobs[i]}
+			single.Commitments = []eth.Bytes48{sidecar.Commitments[i]}
+			single.Proofs = []eth.Bytes48{sidecar.Proofs[i]}
+			val, err := json.Marshal(&single)
+			if err != nil {
+				return fmt.Errorf("failed to encode blob %s: %w", versionedHash, err)
+			}
+			if err := batch.Set(indexKey, val, d.writeOpts); err != nil {
+				return fmt.Errorf("failed to record blob %s: %w", versionedHash, err)
+			}
+			count++
+		}
+	}
+	if count == 0 {
+		return nil
+	}
+	if err := batch.Set(hashKey(keyPrefixL1BlockNumByHash, ref.Hash), binary.BigEndian.AppendUint64(nil, ref.Number), d.writeOpts); err != nil {
+		return fmt.Errorf("failed to index L1 block %s: %w", ref, err)
+	}
+	if d.retention > 0 && ref.Number > d.retention {
+		if err := d.prune(batch, ref.Number-d.retention); err != nil {
+			return err
+		}
+	}
+	if err := batch.Commit(d.writeOpts); err != nil {
+		return fmt.Errorf("failed to commit blob sidecars: %w", err)
+	}
+	d.log.Debug("Stored blob sidecars", "l1", ref, "blobs", count)
+	return nil
+}
+
+// prune deletes all blobs included in L1 blocks before the given L1 block number.
+// The blobs of the L1 blocks after it are kept, even if the same blob got included in a pruned block.
+func (d *BlobDB) prune(batch *pebble.Batch, before uint64) error {
+	iter, err := d.db.NewIter(blobsByL1BlockKey{}.Before(before))
+	if err != nil {
+		return fmt.Errorf("prune failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+	pruned := 0
+	for valid := iter.First(); valid; valid = iter.Next() {
+		l1, _, err := decodeBlobsByL1Block(iter.Key())
+		if err != nil {
+			return fmt.Errorf("prune encountered invalid entry: %w", err)
+		}
+		if err := batch.Delete(hashKey(keyPrefixL1BlockNumByHash, l1.Hash), d.writeOpts); err != nil {
+			return fmt.Errorf("prune failed to delete L1 block %s: %w", l1, err)
+		}
+		pruned++
+	}
+	if pruned == 0 {
+		return nil
+	}
+	opts := blobsByL1BlockKey{}.Before(before)
+	if err := batch.DeleteRange(opts.LowerBound, opts.UpperBound, d.writeOpts); err != nil {
+		return fmt.Errorf("prune failed to delete blobs before L1 block %d: %w", before, err)
+	}
+	d.log.Info("Pruned blob sidecars", "before", before, "blobs", pruned)
+	return nil
+}
+
+// BlobSidecarsByBlockHash returns all stored sidecars of the given L1 block, grouped per transaction.
+func (d *BlobDB) BlobSidecarsByBlockHash(ctx context.Context, blockHash common.Hash) (eth.BSCBlobSidecars, error) {
+	d.m.RLock()
+	defer d.m.RUnlock()
+	val, closer, err := d.db.Get(hashKey(keyPrefixL1BlockNumByHash, blockHash))
+	if errors.Is(err, pebble.ErrNotFound) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	if len(val) != 8 {
+		_ = closer.Close()
+		return nil, ErrInvalidEntry
+	}
+	num := binary.BigEndian.Uint64(val)
+	_ = closer.Close()
+	return d.blobSidecars(ctx, num, blockHash)
+}
+
+func (d *BlobDB) blobSidecars(ctx context.Context, num uint64, blockHash common.Hash) (eth.BSCBlobSidecars, error) {
+	iter, err := d.db.NewIterWithContext(ctx, blobsByL1BlockKey{}.Block(num, blockHash))
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	var result eth.BSCBlobSidecars
+	byTx := make(map[common.Hash]*eth.BSCBlobSidecar)
+	for valid := iter.First(); valid; valid = iter.Next() {
+		_, versionedHash, err := decodeBlobsByL1Block(iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		val, err := iter.ValueAndErr()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read blob %s: %w", versionedHash, err)
+		}
+		var single eth.BSCBlobSidecar
+		if err := json.Unmarshal(val, &single); err != nil {
+			return nil, fmt.Errorf("failed to decode blob %s: %w", versionedHash, err)
+		}
+		if sidecar, ok := byTx[single.TxHash]; ok {
+			sidecar.Blobs = append(sidecar.Blobs, single.Blobs...)
+			sidecar.Commitments = append(sidecar.Commitments, single.Commitments...)
+			sidecar.Proofs = append(sidecar.Proofs, single.Proofs...)
+			continue
+		}
+		byTx[single.TxHash] = &single
+		result = append(result, &single)
+	}
+	if len(result) == 0 {
+		return nil, ErrNotFound
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return txIndex(result[i].TxIndex) < txIndex(result[j].TxIndex)
+	})
+	return result, nil
+}
+
+func txIndex(idx *hexutil.Uint64) uint64 {
+	if idx == nil {
+		return math.MaxUint64
+	}
+	return uint64(*idx)
+}
+
+func (d *BlobDB) Name() string {
+	return "blobdb"
+}
+
+// GetBlobSidecars serves the stored sidecars of the L1 block, so previously fetched blobs don't have to be re-fetched.
+func (d *BlobDB) GetBlobSidecars(ctx context.Context, ref eth.L1BlockRef, _ []eth.IndexedBlobHash) (eth.BSCBlobSidecars, error) {
+	d.m.RLock()
+	defer d.m.RUnlock()
+	blobSidecars, err := d.blobSidecars(ctx, ref.Number, ref.Hash)
+	if errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("no stored blob sidecars for block %s: %w", ref, ethereum.NotFound)
+	}
+	return blobSidecars, err
+}
+
+func (d *BlobDB) Close() error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	if d.closed {
+		// Already closed
+		return nil
+	}
+	d.closed = true
+	return d.db.Close()
+}
@@ -0,0 +1,144 @@
+package blobdb
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func makeSidecar(ref eth.L1BlockRef, txIndex uint64, seeds ...byte) *eth.BSCBlobSidecar {
+	idx := hexutil.Uint64(txIndex)
+	sidecar := &eth.BSCBlobSidecar{
+		BlockHash:   ref.Hash,
+		BlockNumber: (*hexutil.Big)(new(big.Int).SetUint64(ref.Number)),
+		TxIndex:     &idx,
+		TxHash:      common.Hash{0x03, byte(txIndex)},
+	}
+	for _, seed := range seeds {
+		var blob eth.Blob
+		blob[0] = seed
+		commit, _ := kzg4844.BlobToCommitment(kzg4844.Blob(blob))
+		proof, _ := kzg4844.ComputeBlobProof(kzg4844.Blob(blob), commit)
+		sidecar.Blobs = append(sidecar.Blobs, blob)
+		sidecar.Commitments = append(sidecar.Commitments, eth.Bytes48(commit))
+		sidecar.Proofs = append(sidecar.Proofs, eth.Bytes48(proof))
+	}
+	return sidecar
+}
+
+func TestStoreBlobSidecars(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	dir := t.TempDir()
+	db, err := NewBlobDB(logger, dir, 0)
+	require.NoError(t, err)
+	defer db.Close()
+
+	ref := eth.L1BlockRef{Hash: common.Hash{0x01, 0xaa}, Number: 100}
+	sidecars := eth.BSCBlobSidecars{makeSidecar(ref, 0, 1, 2), makeSidecar(ref, 3, 3)}
+	db.BlobSidecarsFetched(ref, sidecars)
+	// storing the same sidecars again is a no-op
+	db.BlobSidecarsFetched(ref, sidecars)
+
+	verify := func(db *BlobDB) {
+		actual, err := db.BlobSidecarsByBlockHash(context.Background(), ref.Hash)
+		require.NoError(t, err)
+		require.Len(t, actual, 2)
+		for i := range sidecars {
+			require.Equal(t, sidecars[i].TxHash, actual[i].TxHash)
+			require.Equal(t, sidecars[i].BlockHash, actual[i].BlockHash)
+			require.ElementsMatch(t, sidecars[i].Blobs, actual[i].Blobs)
+			require.ElementsMatch(t, sidecars[i].Commitments, actual[i].Commitments)
+		}
+
+		provided, err := db.GetBlobSidecars(context.Background(), ref, nil)
+		require.NoError(t, err)
+		require.Equal(t, actual, provided)
+
+		_, err = db.BlobSidecarsByBlockHash(context.Background(), common.Hash{0x01, 0xbb})
+		require.ErrorIs(t, err, ErrNotFound)
+		_, err = db.GetBlobSidecars(context.Background(), eth.L1BlockRef{Hash: common.Hash{0x01, 0xbb}, Number: 100}, nil)
+		require.ErrorIs(t, err, ethereum.NotFound)
+	}
+	verify(db)
+
+	// Close the DB and open a new instance
+	require.NoError(t, db.Close())
+	newDB, err := NewBlobDB(logger, dir, 0)
+	require.NoError(t, err)
+	defer newDB.Close()
+	// Verify the data is reloaded correctly
+	verify(newDB)
+}
+
+func TestRetention(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	db, err := NewBlobDB(logger, t.TempDir(), 10)
+	require.NoError(t, err)
+	defer db.Close()
+
+	refA := eth.L1BlockRef{Hash: common.Hash{0x01, 0xaa}, Number: 100}
+	refB := eth.L1BlockRef{Hash: common.Hash{0x01, 0xbb}, Number: 105}
+	refC := eth.L1BlockRef{Hash: common.Hash{0x01, 0xcc}, Number: 111}
+	db.BlobSidecarsFetched(refA, eth.BSCBlobSidecars{makeSidecar(refA, 0, 1)})
+	db.BlobSidecarsFetched(refB, eth.BSCBlobSidecars{makeSidecar(refB, 0, 2)})
+
+	_, err = db.BlobSidecarsByBlockHash(context.Background(), refA.Hash)
+	require.NoError(t, err)
+
+	// block A is more than 10 blocks older than block C, and gets pruned
+	db.BlobSidecarsFetched(refC, eth.BSCBlobSidecars{makeSidecar(refC, 0, 3)})
+	_, err = db.BlobSidecarsByBlockHash(context.Background(), refA.Hash)
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = db.BlobSidecarsByBlockHash(context.Background(), refB.Hash)
+	require.NoError(t, err)
+	_, err = db.BlobSidecarsByBlockHash(context.Background(), refC.Hash)
+	require.NoError(t, err)
+}
+
+func TestReorgedBlob(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	db, err := NewBlobDB(logger, t.TempDir(), 10)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// the same blob is included in block A, and again in block B after A got reorged out
+	refA := eth.L1BlockRef{Hash: common.Hash{0x01, 0xaa}, Number: 100}
+	refB := eth.L1BlockRef{Hash: common.Hash{0x01, 0xbb}, Number: 102}
+	db.BlobSidecarsFetched(refA, eth.BSCBlobSidecars{makeSidecar(refA, 0, 1)})
+	db.BlobSidecarsFetched(refB, eth.BSCBlobSidecars{makeSidecar(refB, 2, 1)})
+
+	verify := func(ref eth.L1BlockRef, txIndex uint64) {
+		actual, err := db.BlobSidecarsByBlockHash(context.Background(), ref.Hash)
+		require.NoError(t, err)
+		require.Len(t, actual, 1)
+		require.Equal(t, ref.Hash, actual[0].BlockHash)
+		require.Equal(t, hexutil.Uint64(txIndex), *actual[0].TxIndex)
+		require.Len(t, actual[0].Blobs, 1)
+	}
+	// each block keeps its own record of the blob
+	verify(refA, 0)
+	verify(refB, 2)
+
+	// pruning block A keeps the blob of block B
+	refC := eth.L1BlockRef{Hash: common.Hash{0x01, 0xcc}, Number: 111}
+	db.BlobSidecarsFetched(refC, eth.BSCBlobSidecars{makeSidecar(refC, 0, 3)})
+	_, err = db.BlobSidecarsByBlockHash(context.Background(), refA.Hash)
+	require.ErrorIs(t, err, ErrNotFound)
+	verify(refB, 2)
+}
+
+func TestDisabled(t *testing.T) {
+	require.False(t, Disabled.Enabled())
+	_, err := Disabled.BlobSidecarsByBlockHash(context.Background(), common.Hash{})
+	require.ErrorIs(t, err, ErrNotEnabled)
+}
@@ -0,0 +1,32 @@
+package blobdb
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+type DisabledDB struct{}
+
+var (
+	Disabled      = &DisabledDB{}
+	ErrNotEnabled = errors.New("blob sidecar database not enabled")
+)
+
+func (d *DisabledDB) Enabled() bool {
+	return false
+}
+
+func (d *DisabledDB) BlobSidecarsFetched(_ eth.L1BlockRef, _ eth.BSCBlobSidecars) {
+}
+
+func (d *DisabledDB) BlobSidecarsByBlockHash(_ context.Context, _ common.Hash) (eth.BSCBlobSidecars, error) {
+	return nil, ErrNotEnabled
+}
+
+func (d *DisabledDB) Close() error {
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/fallbackclient"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 
 	"github.com/ethereum/go-ethereum/log"
@@ -41,7 +42,9 @@ type L1BeaconEndpointSetup interface {
 }
 
 type L1BlobEndpointSetup interface {
-	Setup(ctx context.Context, log log.Logger) ([]client.RPC, error)
+	// Setup the blob sidecar providers to fetch blobs from, in priority order.
+	// RPC providers are instrumented with the given RPC client metrics.
+	Setup(ctx context.Context, log log.Logger, m *opmetrics.RPCClientMetrics) ([]sources.BlobSidecarsProvider, error)
 	Check() error
 }
 
@@ -275,6 +278,19 @@ type L1BlobEndpointConfig struct {
 	// Address of L1 blob node endpoint to use, multiple alternative addresses separated by commas are supported, and will rotate when error
 	NodeAddrs string
 
+	// Optional address of L1 archive blob node endpoint to use, multiple alternative addresses separated by commas are supported, and will rotate when error
+	ArchiveNodeAddrs string
+
+	// Optional directory of an on-disk blob sidecar archive
+	ArchiveDir string
+
+	// Optional address of an HTTP blob-archive service, serving blob sidecars by versioned hash
+	ArchiveHTTPAddr string
+
+	// Providers is the priority order of the blob sidecar providers, highest priority first.
+	// Providers that are not configured are skipped.
+	Providers []sources.BlobProviderKind
+
 	// RateLimit specifies a self-imposed rate-limit on L1 requests. 0 is no rate-limit.
 	RateLimit float64
 
@@ -294,10 +310,64 @@ func (cfg *L1BlobEndpointConfig) Check() error {
 	if cfg.RateLimit < 0 {
 		return fmt.Errorf("rate limit cannot be negative")
 	}
+	seen := make(map[sources.BlobProviderKind]struct{})
+	for _, kind := range cfg.Providers {
+		if !sources.ValidBlobProviderKind(kind) {
+			return fmt.Errorf("unknown blob provider: %q", kind)
+		}
+		if _, ok := seen[kind]; ok {
+			return fmt.Errorf("duplicate blob provider: %q", kind)
+		}
+		seen[kind] = struct{}{}
+	}
 	return nil
 }
 
-func (cfg *L1BlobEndpointConfig) Setup(ctx context.Context, log log.Logger) ([]client.RPC, error) {
+func (cfg *L1BlobEndpointConfig) Setup(ctx context.Context, log log.Logger, m *opmetrics.RPCClientMetrics) ([]sources.BlobSidecarsProvider, error) {
+	providers := cfg.Providers
+	if len(providers) == 0 {
+		providers = sources.BlobProviderKinds
+	}
+	var out []sources.BlobSidecarsProvider
+	for _, kind := range providers {
+		switch kind {
+		case sources.BlobProviderRPC:
+			rpcClients, err := cfg.setupRPCs(ctx, log, cfg.NodeAddrs, m)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, sources.NewRPCBlobSidecarsProvider(kind.String(), rpcClients))
+		case sources.BlobProviderArchiveRPC:
+			if cfg.ArchiveNodeAddrs == "" {
+				continue
+			}
+			rpcClients, err := cfg.setupRPCs(ctx, log, cfg.ArchiveNodeAddrs, m)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, sources.NewRPCBlobSidecarsProvider(kind.String(), rpcClients))
+		case sources.BlobProviderArchiveDir:
+			if cfg.ArchiveDir == "" {
+				continue
+			}
+			out = append(out, sources.NewDirBlobSidecarsProvider(kind.String(), cfg.ArchiveDir))
+		case sources.BlobProviderArchiveHTTP:
+			if cfg.ArchiveHTTPAddr == "" {
+				continue
+			}
+			out = append(out, sources.NewHTTPBlobSidecarsProvider(kind.String(), client.NewBasicHTTPClient(cfg.ArchiveHTTPAddr, log)))
+		default:
+			return nil, fmt.Errorf("unknown blob provider: %q", kind)
+		}
+		log.Info("Configured blob sidecar provider", "provider", kind, "priority", len(out))
+	}
+	if len(out) == 0 {
+		return nil, errors.New("no blob sidecar providers configured")
+	}
+	return out, nil
+}
+
+func (cfg *L1BlobEndpointConfig) setupRPCs(ctx context.Context, log log.Logger, addrs string, m *opmetrics.RPCClientMetrics) ([]client.RPC, error) {
 	rpcClients := make([]client.RPC, 0)
 
 	opts := []client.RPCOption{
@@ -306,22 +376,16 @@ func (cfg *L1BlobEndpointConfig) Setup(ctx context.Context, log log.Logger) ([]c
 	if cfg.RateLimit != 0 {
 		opts = append(opts, client.WithRateLimit(cfg.RateLimit, cfg.BatchSize))
 	}
-	isMultiUrl, urlList := fallbackclient.MultiUrlParse(cfg.NodeAddrs)
-
-	if isMultiUrl {
-		for _, url := range urlList {
-			rpcClient, err := client.NewRPC(ctx, log, url, opts...)
-			if err != nil {
-				return nil, fmt.Errorf("setup blob client failed to dial L1 address (%s): %w", url, err)
-			}
-			rpcClients = append(rpcClients, rpcClient)
-		}
-	} else {
-		rpcClient, err := client.NewRPC(ctx, log, cfg.NodeAddrs, opts...)
+	isMultiUrl, urlList := fallbackclient.MultiUrlParse(addrs)
+	if !isMultiUrl {
+		urlList = []string{addrs}
+	}
+	for _, url := range urlList {
+		rpcClient, err := client.NewRPC(ctx, log, url, opts...)
 		if err != nil {
-			return nil, fmt.Errorf("setup blob client failed to dial L1 address (%s): %w", cfg.NodeAddrs, err)
+			return nil, fmt.Errorf("setup blob client failed to dial L1 address (%s): %w", url, err)
 		}
-		rpcClients = append(rpcClients, rpcClient)
+		rpcClients = append(rpcClients, client.NewInstrumentedRPC(rpcClient, m))
 	}
 
 	return rpcClients, nil
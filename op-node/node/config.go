@@ -48,6 +48,21 @@ type Config struct {
 	// Path to store safe head database. Disabled when set to empty string
 	SafeDBPath string
 
+	// Path to store blob sidecar database. Disabled when set to empty string
+	BlobDBPath string
+
+	// BlobDBRetention is the number of L1 blocks to keep blob sidecars for. Kept forever if 0.
+	BlobDBRetention uint64
+
+	// Path to store unsafe payload database. Disabled when set to empty string
+	PayloadDBPath string
+
+	// PayloadDBMaxPayloads is the maximum number of unsafe payloads to keep. Unbounded if 0.
+	PayloadDBMaxPayloads uint64
+
+	// PayloadDBMaxAge is the maximum age of unsafe payloads to keep, relative to the latest payload. Unbounded if 0.
+	PayloadDBMaxAge time.Duration
+
 	// RuntimeConfigReloadInterval defines the interval between runtime config reloads.
 	// Disabled if <= 0.
 	// Runtime config changes should be picked up from log-events,
@@ -83,6 +98,8 @@ type RPCConfig struct {
 	ListenAddr  string
 	ListenPort  int
 	EnableAdmin bool
+	// EnableDerivationEvents enables the optimism_derivationEvents subscription, served over websocket.
+	EnableDerivationEvents bool
 }
 
 func (cfg *RPCConfig) HttpEndpoint() string {
@@ -8,6 +8,8 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-node/node/blobdb"
+	"github.com/ethereum-optimism/optimism/op-node/node/payloaddb"
 	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	plasma "github.com/ethereum-optimism/optimism/op-plasma"
@@ -39,6 +41,21 @@ var ErrAlreadyClosed = errors.New("node is already closed")
 type closableSafeDB interface {
 	derive.SafeHeadListener
 	SafeDBReader
+	SafeDBImporter
+	io.Closer
+}
+
+type closableBlobDB interface {
+	sources.BlobSidecarsListener
+	BlobDBReader
+	io.Closer
+}
+
+type closablePayloadDB interface {
+	Enabled() bool
+	UnsafePayloadReceived(envelope *eth.ExecutionPayloadEnvelope)
+	PayloadByNumber(ctx context.Context, num uint64) (*eth.ExecutionPayloadEnvelope, error)
+	Payloads(ctx context.Context) ([]*eth.ExecutionPayloadEnvelope, error)
 	io.Closer
 }
 
@@ -62,12 +79,17 @@ type OpNode struct {
 
 	safeDB closableSafeDB
 
+	payloadDB closablePayloadDB // Local cache of recently received unsafe payloads
+
+	derivationEvents *derive.DerivationEventFeed // decisions of the derivation pipeline, for in-process and RPC subscribers
+
 	rollupHalt string // when to halt the rollup, disabled if empty
 
 	pprofService *oppprof.Service
 	metricsSrv   *httputil.HTTPServer
 
 	l1Blob *sources.BSCBlobClient // L1 Blob Client to fetch blobs
+	blobDB closableBlobDB         // Local archive of validated blob sidecars
 
 	// some resources cannot be stopped directly, like the p2p gossipsub router (not our design),
 	// and depend on this ctx to be closed.
@@ -310,6 +332,7 @@ func (n *OpNode) initRuntimeConfig(ctx context.Context, cfg *Config) error {
 }
 
 func (n *OpNode) initL1Blob(ctx context.Context, cfg *Config) error {
+	n.blobDB = blobdb.Disabled
 	// If Ecotone upgrade is not scheduled yet, then there is no need for a Blob API.
 	if cfg.Rollup.EcotoneTime == nil {
 		return nil
@@ -319,15 +342,22 @@ func (n *OpNode) initL1Blob(ctx context.Context, cfg *Config) error {
 		return fmt.Errorf("missing L1 Blob Endpoint configuration: this API is mandatory for Ecotone upgrade at t=%d", *cfg.Rollup.EcotoneTime)
 	}
 
-	rpcClients, err := cfg.L1Blob.Setup(ctx, n.log)
+	providers, err := cfg.L1Blob.Setup(ctx, n.log, &n.metrics.RPCClientMetrics)
 	if err != nil {
 		return fmt.Errorf("failed to setup L1 blob client: %w", err)
 	}
-	instrumentedClients := make([]client.RPC, 0)
-	for _, rpc := range rpcClients {
-		instrumentedClients = append(instrumentedClients, client.NewInstrumentedRPC(rpc, &n.metrics.RPCClientMetrics))
+	if cfg.BlobDBPath != "" {
+		n.log.Info("Blob sidecar database enabled", "path", cfg.BlobDBPath, "retention", cfg.BlobDBRetention)
+		blobDB, err := blobdb.NewBlobDB(n.log, cfg.BlobDBPath, cfg.BlobDBRetention)
+		if err != nil {
+			return fmt.Errorf("failed to create blob sidecar database at %v: %w", cfg.BlobDBPath, err)
+		}
+		n.blobDB = blobDB
+		// Blobs that were fetched before are served locally, ahead of all remote providers.
+		providers = append([]sources.BlobSidecarsProvider{blobDB}, providers...)
 	}
-	n.l1Blob = sources.NewBSCBlobClient(instrumentedClients)
+	n.l1Blob = sources.NewBSCBlobClientWithProviders(n.metrics, providers...)
+	n.l1Blob.SetSidecarsListener(n.blobDB)
 	return nil
 }
 
@@ -369,7 +399,18 @@ func (n *OpNode) initL2(ctx context.Context, cfg *Config, snapshotLog log.Logger
 	} else {
 		n.safeDB = safedb.Disabled
 	}
-	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source, n.l1Blob, n, n, n.log, snapshotLog, n.metrics, cfg.ConfigPersistence, n.safeDB, &cfg.Sync, sequencerConductor, plasmaDA)
+	if cfg.PayloadDBPath != "" {
+		n.log.Info("Unsafe payload database enabled", "path", cfg.PayloadDBPath, "max_payloads", cfg.PayloadDBMaxPayloads, "max_age", cfg.PayloadDBMaxAge)
+		payloadDB, err := payloaddb.NewPayloadDB(n.log, cfg.PayloadDBPath, &cfg.Rollup, cfg.PayloadDBMaxPayloads, cfg.PayloadDBMaxAge)
+		if err != nil {
+			return fmt.Errorf("failed to create unsafe payload database at %v: %w", cfg.PayloadDBPath, err)
+		}
+		n.payloadDB = payloadDB
+	} else {
+		n.payloadDB = payloaddb.Disabled
+	}
+	n.derivationEvents = derive.NewDerivationEventFeed()
+	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source, n.l1Blob, n, n, n.log, snapshotLog, n.metrics, cfg.ConfigPersistence, n.safeDB, n.derivationEvents, &cfg.Sync, sequencerConductor, plasmaDA)
 	return nil
 }
 
@@ -381,8 +422,15 @@ func (n *OpNode) initRPCServer(cfg *Config) error {
 	if n.p2pNode != nil {
 		server.EnableP2P(p2p.NewP2PAPIBackend(n.p2pNode, n.log, n.metrics))
 	}
+	if n.blobDB != nil && n.blobDB.Enabled() {
+		server.EnableBlobAPI(NewBlobAPI(n.blobDB, n.log, n.metrics))
+	}
+	if cfg.RPC.EnableDerivationEvents {
+		server.EnableDerivationEventsAPI(NewDerivationEventsAPI(n.derivationEvents, n.log, n.metrics))
+		n.log.Info("Derivation events RPC enabled")
+	}
 	if cfg.RPC.EnableAdmin {
-		server.EnableAdminAPI(NewAdminAPI(n.l2Driver, n.metrics, n.log))
+		server.EnableAdminAPI(NewAdminAPI(n.l2Driver, n.safeDB, n.metrics, n.log))
 		n.log.Info("Admin RPC enabled")
 	}
 	n.log.Info("Starting JSON-RPC server")
@@ -454,7 +502,12 @@ func (n *OpNode) initPProf(cfg *Config) error {
 func (n *OpNode) initP2P(ctx context.Context, cfg *Config) error {
 	if cfg.P2P != nil {
 		// TODO(protocol-quest/97): Use EL Sync instead of CL Alt sync for fetching missing blocks in the payload queue.
-		p2pNode, err := p2p.NewNodeP2P(n.resourcesCtx, &cfg.Rollup, n.log, cfg.P2P, n, n.l2Source, n.runCfg, n.metrics, false)
+		var l2Chain p2p.L2Chain = n.l2Source
+		if n.payloadDB.Enabled() {
+			// Serve cached unsafe payloads to peers, if the engine does not have them (yet).
+			l2Chain = &cachedL2Chain{engine: n.l2Source, cache: n.payloadDB}
+		}
+		p2pNode, err := p2p.NewNodeP2P(n.resourcesCtx, &cfg.Rollup, n.log, cfg.P2P, n, l2Chain, n.runCfg, n.metrics, false)
 		if err != nil || p2pNode == nil {
 			return err
 		}
@@ -485,9 +538,31 @@ func (n *OpNode) Start(ctx context.Context) error {
 		return err
 	}
 	log.Info("Rollup node started")
+	n.reloadUnsafePayloads(ctx)
 	return nil
 }
 
+// reloadUnsafePayloads passes the unsafe payloads that were persisted before a restart on to the L2 engine driver,
+// so they don't have to be fetched again.
+func (n *OpNode) reloadUnsafePayloads(ctx context.Context) {
+	payloads, err := n.payloadDB.Payloads(ctx)
+	if err != nil {
+		n.log.Warn("Failed to load persisted unsafe payloads", "err", err)
+		return
+	}
+	if len(payloads) == 0 {
+		return
+	}
+	n.log.Info("Reloading persisted unsafe payloads", "count", len(payloads),
+		"first", payloads[0].ExecutionPayload.ID(), "last", payloads[len(payloads)-1].ExecutionPayload.ID())
+	for _, envelope := range payloads {
+		if err := n.l2Driver.OnUnsafeL2Payload(ctx, envelope); err != nil {
+			n.log.Warn("Failed to reload persisted unsafe payload", "id", envelope.ExecutionPayload.ID(), "err", err)
+			return
+		}
+	}
+}
+
 func (n *OpNode) OnNewL1Head(ctx context.Context, sig eth.L1BlockRef) {
 	n.tracer.OnNewL1Head(ctx, sig)
 
@@ -552,6 +627,8 @@ func (n *OpNode) OnUnsafeL2Payload(ctx context.Context, from peer.ID, envelope *
 
 	n.log.Info("Received signed execution payload from p2p", "id", envelope.ExecutionPayload.ID(), "peer", from)
 
+	n.payloadDB.UnsafePayloadReceived(envelope)
+
 	// Pass on the event to the L2 Engine
 	ctx, cancel := context.WithTimeout(ctx, time.Second*30)
 	defer cancel()
@@ -575,6 +652,20 @@ func (n *OpNode) RequestL2Range(ctx context.Context, start, end eth.L2BlockRef)
 	return nil
 }
 
+// cachedL2Chain serves payloads from the engine, and falls back to the unsafe payload cache.
+type cachedL2Chain struct {
+	engine p2p.L2Chain
+	cache  p2p.L2Chain
+}
+
+func (c *cachedL2Chain) PayloadByNumber(ctx context.Context, number uint64) (*eth.ExecutionPayloadEnvelope, error) {
+	envelope, err := c.engine.PayloadByNumber(ctx, number)
+	if errors.Is(err, ethereum.NotFound) {
+		return c.cache.PayloadByNumber(ctx, number)
+	}
+	return envelope, err
+}
+
 // unixTimeStale returns true if the unix timestamp is before the current time minus the supplied duration.
 func unixTimeStale(timestamp uint64, duration time.Duration) bool {
 	return time.Unix(int64(timestamp), 0).Before(time.Now().Add(-1 * duration))
@@ -584,6 +675,11 @@ func (n *OpNode) P2P() p2p.Node {
 	return n.p2pNode
 }
 
+// DerivationEvents returns the feed of the decisions of the derivation pipeline, to subscribe to in-process.
+func (n *OpNode) DerivationEvents() *derive.DerivationEventFeed {
+	return n.derivationEvents
+}
+
 func (n *OpNode) RuntimeConfig() ReadonlyRuntimeConfig {
 	return n.runCfg
 }
@@ -643,6 +739,18 @@ func (n *OpNode) Stop(ctx context.Context) error {
 		}
 	}
 
+	if n.blobDB != nil {
+		if err := n.blobDB.Close(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to close blob sidecar db: %w", err))
+		}
+	}
+
+	if n.payloadDB != nil {
+		if err := n.payloadDB.Close(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to close unsafe payload db: %w", err))
+		}
+	}
+
 	// Wait for the runtime config loader to be done using the data sources before closing them
 	if n.runtimeConfigReloaderDone != nil {
 		<-n.runtimeConfigReloaderDone
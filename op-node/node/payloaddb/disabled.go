@@ -0,0 +1,34 @@
+package payloaddb
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+type DisabledDB struct{}
+
+var (
+	Disabled      = &DisabledDB{}
+	ErrNotEnabled = errors.New("unsafe payload database not enabled")
+)
+
+func (d *DisabledDB) Enabled() bool {
+	return false
+}
+
+func (d *DisabledDB) UnsafePayloadReceived(_ *eth.ExecutionPayloadEnvelope) {
+}
+
+func (d *DisabledDB) PayloadByNumber(_ context.Context, _ uint64) (*eth.ExecutionPayloadEnvelope, error) {
+	return nil, ErrNotEnabled
+}
+
+func (d *DisabledDB) Payloads(_ context.Context) ([]*eth.ExecutionPayloadEnvelope, error) {
+	return nil, nil
+}
+
+func (d *DisabledDB) Close() error {
+	return nil
+}
@@ -0,0 +1,271 @@
+package payloaddb
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var (
+	ErrInvalidEntry = errors.New("invalid db entry")
+)
+
+const (
+	// Keys are prefixed with a constant byte to allow us to differentiate different "columns" within the data
+	keyPrefixPayloadByNum byte = 0
+)
+
+const (
+	// encoding versions of the stored payloads, matching the versions of the p2p payload encoding
+	versionPayload  byte = 0
+	versionEnvelope byte = 1
+)
+
+func payloadByNumKey(num uint64) []byte {
+	key := make([]byte, 0, 9)
+	key = append(key, keyPrefixPayloadByNum)
+	key = binary.BigEndian.AppendUint64(key, num)
+	return key
+}
+
+func payloadByNumRange() *pebble.IterOptions {
+	return &pebble.IterOptions{
+		LowerBound: []byte{keyPrefixPayloadByNum},
+		UpperBound: []byte{keyPrefixPayloadByNum + 1},
+	}
+}
+
+// PayloadDB is a persistent cache of recently received unsafe payloads,
+// so they don't have to be fetched again after a restart. The latest payload of each block number is kept.
+//
+// The cache is bounded by the number of payloads, and by the age of the payloads relative to the latest payload.
+type PayloadDB struct {
+	// m ensures all read iterators are closed before closing the database by preventing concurrent read and write
+	// operations (with close considered a write operation).
+	m   sync.RWMutex
+	log log.Logger
+	db  *pebble.DB
+	cfg *rollup.Config
+
+	// maxPayloads is the maximum number of payloads to keep. Unbounded if 0.
+	maxPayloads uint64
+	// maxAge is the maximum age of payloads to keep, relative to the latest payload. Unbounded if 0.
+	maxAge time.Duration
+
+	// count is the number of stored payloads
+	count uint64
+
+	writeOpts *pebble.WriteOptions
+
+	closed bool
+}
+
+func NewPayloadDB(logger log.Logger, path string, cfg *rollup.Config, maxPayloads uint64, maxAge time.Duration) (*PayloadDB, error) {
+	db, err := pebble.Open(path, &pebble.Options{})
+	if err != nil {
+		return nil, err
+	}
+	d := &PayloadDB{
+		log:         logger,
+		db:          db,
+		cfg:         cfg,
+		maxPayloads: maxPayloads,
+		maxAge:      maxAge,
+		// The cache is written for every unsafe payload, and may lose the latest payloads on a crash,
+		// so the writes are not synced to disk.
+		writeOpts: pebble.NoSync,
+	}
+	iter, err := db.NewIter(payloadByNumRange())
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to create iterator: %w", err)
+	}
+	for valid := iter.First(); valid; valid = iter.Next() {
+		d.count++
+	}
+	if err := iter.Close(); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to count stored payloads: %w", err)
+	}
+	return d, nil
+}
+
+func (d *PayloadDB) Enabled() bool {
+	return true
+}
+
+// UnsafePayloadReceived persists the unsafe payload, replacing any stored payload with the same block number.
+func (d *PayloadDB) UnsafePayloadReceived(envelope *eth.ExecutionPayloadEnvelope) {
+	if err := d.storePayload(envelope); err != nil {
+		d.log.Warn("Failed to store unsafe payload", "id", envelope.ExecutionPayload.ID(), "err", err)
+	}
+}
+
+func (d *PayloadDB) storePayload(envelope *eth.ExecutionPayloadEnvelope) error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	payload := envelope.ExecutionPayload
+	val, err := d.encodePayload(envelope)
+	if err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+	key := payloadByNumKey(uint64(payload.BlockNumber))
+	exists := true
+	if _, closer, err := d.db.Get(key); errors.Is(err, pebble.ErrNotFound) {
+		exists = false
+	} else if err != nil {
+		return fmt.Errorf("failed to check payload %d: %w", payload.BlockNumber, err)
+	} else {
+		_ = closer.Close()
+	}
+	batch := d.db.NewBatch()
+	defer batch.Close()
+	if err := batch.Set(key, val, d.writeOpts); err != nil {
+		return fmt.Errorf("failed to record payload %s: %w", payload.ID(), err)
+	}
+	count := d.count
+	if !exists {
+		count++
+	}
+	pruned, err := d.prune(batch, uint64(payload.Timestamp), count)
+	if err != nil {
+		return err
+	}
+	if err := batch.Commit(d.writeOpts); err != nil {
+		return fmt.Errorf("failed to commit payload %s: %w", payload.ID(), err)
+	}
+	d.count = count - pruned
+	d.log.Debug("Stored unsafe payload", "id", payload.ID(), "pruned", pruned)
+	return nil
+}
+
+// prune deletes the oldest payloads, until count is within the maximum number of payloads,
+// and no payload is older than the maximum age relative to the given timestamp.
+// It returns the number of deleted payloads.
+func (d *PayloadDB) prune(batch *pebble.Batch, latest uint64, count uint64) (uint64, error) {
+	var minTimestamp uint64
+	if maxAge := uint64(d.maxAge / time.Second); d.maxAge > 0 && latest > maxAge {
+		minTimestamp = latest - maxAge
+	}
+	iter, err := d.db.NewIter(payloadByNumRange())
+	if err != nil {
+		return 0, fmt.Errorf("prune failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+	pruned := uint64(0)
+	for valid := iter.First(); valid; valid = iter.Next() {
+		tooMany := d.maxPayloads > 0 && count-pruned > d.maxPayloads
+		tooOld := len(iter.Value()) >= 8 && binary.BigEndian.Uint64(iter.Value()[:8]) < minTimestamp
+		if !tooMany && !tooOld {
+			break
+		}
+		if err := batch.Delete(iter.Key(), d.writeOpts); err != nil {
+			return 0, fmt.Errorf("prune failed to delete payload: %w", err)
+		}
+		pruned++
+	}
+	return pruned, nil
+}
+
+// encodePayload encodes the payload as timestamp, version, and the SSZ encoding of the payload for that version.
+func (d *PayloadDB) encodePayload(envelope *eth.ExecutionPayloadEnvelope) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Write(binary.BigEndian.AppendUint64(nil, uint64(envelope.ExecutionPayload.Timestamp)))
+	if d.cfg.IsEcotone(uint64(envelope.ExecutionPayload.Timestamp)) {
+		buf.WriteByte(versionEnvelope)
+		if _, err := envelope.MarshalSSZ(&buf); err != nil {
+			return nil, err
+		}
+	} else {
+		buf.WriteByte(versionPayload)
+		if _, err := envelope.ExecutionPayload.MarshalSSZ(&buf); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func (d *PayloadDB) decodePayload(val []byte) (*eth.ExecutionPayloadEnvelope, error) {
+	if len(val) < 9 {
+		return nil, ErrInvalidEntry
+	}
+	timestamp := binary.BigEndian.Uint64(val[:8])
+	data := val[9:]
+	switch val[8] {
+	case versionPayload:
+		blockVersion := eth.BlockV1
+		if d.cfg.IsCanyon(timestamp) {
+			blockVersion = eth.BlockV2
+		}
+		var payload eth.ExecutionPayload
+		if err := payload.UnmarshalSSZ(blockVersion, uint32(len(data)), bytes.NewReader(data)); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidEntry, err)
+		}
+		return &eth.ExecutionPayloadEnvelope{ExecutionPayload: &payload}, nil
+	case versionEnvelope:
+		var envelope eth.ExecutionPayloadEnvelope
+		if err := envelope.UnmarshalSSZ(uint32(len(data)), bytes.NewReader(data)); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidEntry, err)
+		}
+		return &envelope, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown version %d", ErrInvalidEntry, val[8])
+	}
+}
+
+// PayloadByNumber returns the stored payload with the given block number.
+// It returns ethereum.NotFound if there is none, so it can serve payloads to peers.
+func (d *PayloadDB) PayloadByNumber(_ context.Context, num uint64) (*eth.ExecutionPayloadEnvelope, error) {
+	d.m.RLock()
+	defer d.m.RUnlock()
+	val, closer, err := d.db.Get(payloadByNumKey(num))
+	if errors.Is(err, pebble.ErrNotFound) {
+		return nil, fmt.Errorf("no stored payload for block %d: %w", num, ethereum.NotFound)
+	} else if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+	return d.decodePayload(val)
+}
+
+// Payloads returns all stored payloads, ordered by ascending block number.
+func (d *PayloadDB) Payloads(ctx context.Context) ([]*eth.ExecutionPayloadEnvelope, error) {
+	d.m.RLock()
+	defer d.m.RUnlock()
+	iter, err := d.db.NewIterWithContext(ctx, payloadByNumRange())
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	var result []*eth.ExecutionPayloadEnvelope
+	for valid := iter.First(); valid; valid = iter.Next() {
+		envelope, err := d.decodePayload(iter.Value())
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode payload: %w", err)
+		}
+		result = append(result, envelope)
+	}
+	return result, nil
+}
+
+func (d *PayloadDB) Close() error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	if d.closed {
+		// Already closed
+		return nil
+	}
+	d.closed = true
+	return d.db.Close()
+}
@@ -0,0 +1,150 @@
+package payloaddb
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func testConfig() *rollup.Config {
+	ecotoneTime := uint64(1010)
+	return &rollup.Config{
+		BlockTime:   2,
+		EcotoneTime: &ecotoneTime,
+	}
+}
+
+func makePayload(cfg *rollup.Config, num uint64) *eth.ExecutionPayloadEnvelope {
+	timestamp := 1000 + num*cfg.BlockTime
+	envelope := &eth.ExecutionPayloadEnvelope{
+		ExecutionPayload: &eth.ExecutionPayload{
+			ParentHash:  common.Hash{0x01, byte(num - 1)},
+			BlockHash:   common.Hash{0x01, byte(num)},
+			BlockNumber: eth.Uint64Quantity(num),
+			Timestamp:   eth.Uint64Quantity(timestamp),
+			// SSZ decoding results in empty, rather than nil, slices
+			ExtraData:    eth.BytesMax32{},
+			Transactions: []eth.Data{},
+		},
+	}
+	if cfg.IsEcotone(timestamp) {
+		root := common.BigToHash(big.NewInt(int64(num)))
+		envelope.ParentBeaconBlockRoot = &root
+		zero := eth.Uint64Quantity(0)
+		envelope.ExecutionPayload.ExcessBlobGas = &zero
+		envelope.ExecutionPayload.BlobGasUsed = &zero
+		w := types.Withdrawals{}
+		envelope.ExecutionPayload.Withdrawals = &w
+	}
+	return envelope
+}
+
+func TestStorePayloads(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	cfg := testConfig()
+	dir := t.TempDir()
+	db, err := NewPayloadDB(logger, dir, cfg, 0, 0)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Both pre- and post-Ecotone payloads are stored
+	var payloads []*eth.ExecutionPayloadEnvelope
+	for i := uint64(1); i <= 10; i++ {
+		payloads = append(payloads, makePayload(cfg, i))
+	}
+	for i := len(payloads) - 1; i >= 0; i-- {
+		db.UnsafePayloadReceived(payloads[i])
+	}
+
+	verify := func(db *PayloadDB) {
+		actual, err := db.Payloads(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, payloads, actual, "expecting payloads in ascending order")
+
+		envelope, err := db.PayloadByNumber(context.Background(), 8)
+		require.NoError(t, err)
+		require.Equal(t, payloads[7], envelope)
+
+		_, err = db.PayloadByNumber(context.Background(), 11)
+		require.ErrorIs(t, err, ethereum.NotFound)
+	}
+	verify(db)
+
+	// Close the DB and open a new instance
+	require.NoError(t, db.Close())
+	newDB, err := NewPayloadDB(logger, dir, cfg, 0, 0)
+	require.NoError(t, err)
+	defer newDB.Close()
+	// Verify the data is reloaded correctly
+	verify(newDB)
+}
+
+func TestReplacePayload(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	cfg := testConfig()
+	db, err := NewPayloadDB(logger, t.TempDir(), cfg, 2, 0)
+	require.NoError(t, err)
+	defer db.Close()
+
+	db.UnsafePayloadReceived(makePayload(cfg, 1))
+	db.UnsafePayloadReceived(makePayload(cfg, 2))
+	replacement := makePayload(cfg, 2)
+	replacement.ExecutionPayload.BlockHash = common.Hash{0x02}
+	// Replacing a payload does not count towards the maximum number of payloads
+	db.UnsafePayloadReceived(replacement)
+
+	actual, err := db.Payloads(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []*eth.ExecutionPayloadEnvelope{makePayload(cfg, 1), replacement}, actual)
+}
+
+func TestPrunePayloads(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	cfg := testConfig()
+
+	t.Run("MaxPayloads", func(t *testing.T) {
+		dir := t.TempDir()
+		db, err := NewPayloadDB(logger, dir, cfg, 3, 0)
+		require.NoError(t, err)
+		for i := uint64(1); i <= 5; i++ {
+			db.UnsafePayloadReceived(makePayload(cfg, i))
+		}
+		actual, err := db.Payloads(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, []*eth.ExecutionPayloadEnvelope{makePayload(cfg, 3), makePayload(cfg, 4), makePayload(cfg, 5)}, actual)
+
+		// The number of payloads is restored when reopening the database
+		require.NoError(t, db.Close())
+		db, err = NewPayloadDB(logger, dir, cfg, 3, 0)
+		require.NoError(t, err)
+		defer db.Close()
+		db.UnsafePayloadReceived(makePayload(cfg, 6))
+		actual, err = db.Payloads(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, []*eth.ExecutionPayloadEnvelope{makePayload(cfg, 4), makePayload(cfg, 5), makePayload(cfg, 6)}, actual)
+	})
+
+	t.Run("MaxAge", func(t *testing.T) {
+		db, err := NewPayloadDB(logger, t.TempDir(), cfg, 0, 5*time.Second)
+		require.NoError(t, err)
+		defer db.Close()
+		for i := uint64(1); i <= 5; i++ {
+			db.UnsafePayloadReceived(makePayload(cfg, i))
+		}
+		// Payloads more than 5 seconds older than the latest payload are pruned
+		actual, err := db.Payloads(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, []*eth.ExecutionPayloadEnvelope{makePayload(cfg, 3), makePayload(cfg, 4), makePayload(cfg, 5)}, actual)
+	})
+}
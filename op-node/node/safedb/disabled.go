@@ -27,6 +27,19 @@ func (d *DisabledDB) SafeHeadAtL1(_ context.Context, _ uint64) (l1 eth.BlockID,
 	return
 }
 
+func (d *DisabledDB) SafeHeadUpdates(_ context.Context, _ uint64, _ uint64) ([]eth.SafeHeadResponse, error) {
+	return nil, ErrNotEnabled
+}
+
+func (d *DisabledDB) SafeHeadAtL2(_ context.Context, _ uint64) (l1 eth.BlockID, safeHead eth.BlockID, err error) {
+	err = ErrNotEnabled
+	return
+}
+
+func (d *DisabledDB) ImportSafeHeads(_ []eth.SafeHeadResponse) error {
+	return ErrNotEnabled
+}
+
 func (d *DisabledDB) SafeHeadReset(_ eth.L2BlockRef) error {
 	return nil
 }
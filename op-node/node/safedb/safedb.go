@@ -15,8 +15,9 @@ import (
 )
 
 var (
-	ErrNotFound     = errors.New("not found")
-	ErrInvalidEntry = errors.New("invalid db entry")
+	ErrNotFound      = errors.New("not found")
+	ErrInvalidEntry  = errors.New("invalid db entry")
+	ErrInvalidImport = errors.New("invalid safe head import")
 )
 
 const (
@@ -184,6 +185,188 @@ func (d *SafeDB) SafeHeadAtL1(ctx context.Context, l1BlockNum uint64) (l1Block e
 	return
 }
 
+// SafeHeadUpdates returns the safe head updates recorded at L1 blocks from fromL1 to toL1 inclusive, in L1 order.
+func (d *SafeDB) SafeHeadUpdates(ctx context.Context, fromL1 uint64, toL1 uint64) ([]eth.SafeHeadResponse, error) {
+	d.m.RLock()
+	defer d.m.RUnlock()
+	if toL1 < fromL1 {
+		return nil, nil
+	}
+	opts := &pebble.IterOptions{
+		LowerBound: safeByL1BlockNumKey.Of(fromL1),
+		UpperBound: safeByL1BlockNumKey.Max(),
+	}
+	iter, err := d.db.NewIterWithContext(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	var updates []eth.SafeHeadResponse
+	for valid := iter.First(); valid; valid = iter.Next() {
+		val, err := iter.ValueAndErr()
+		if err != nil {
+			return nil, err
+		}
+		l1Block, safeHead, err := decodeSafeByL1BlockNum(iter.Key(), val)
+		if err != nil {
+			return nil, err
+		}
+		if l1Block.Number > toL1 {
+			break
+		}
+		updates = append(updates, eth.SafeHeadResponse{L1Block: l1Block, SafeHead: safeHead})
+	}
+	return updates, iter.Error()
+}
+
+// SafeHeadAtL2 returns the first recorded L1 block at which the safe head reached the given L2 block,
+// along with the safe head at that L1 block.
+// Returns ErrNotFound if the L2 block is not safe yet, or was already safe when the records start,
+// since then it isn't known which L1 block made it safe.
+func (d *SafeDB) SafeHeadAtL2(ctx context.Context, l2BlockNum uint64) (l1Block eth.BlockID, safeHead eth.BlockID, err error) {
+	d.m.RLock()
+	defer d.m.RUnlock()
+	iter, err := d.db.NewIterWithContext(ctx, safeByL1BlockNumKey.IterRange())
+	if err != nil {
+		return
+	}
+	defer iter.Close()
+	entryAt := func(seek bool) (eth.BlockID, eth.BlockID, error) {
+		if !seek {
+			return eth.BlockID{}, eth.BlockID{}, ErrNotFound
+		}
+		val, err := iter.ValueAndErr()
+		if err != nil {
+			return eth.BlockID{}, eth.BlockID{}, err
+		}
+		return decodeSafeByL1BlockNum(iter.Key(), val)
+	}
+	firstL1, firstL2, err := entryAt(iter.First())
+	if err != nil {
+		return
+	}
+	lastL1, lastL2, err := entryAt(iter.Last())
+	if err != nil {
+		return
+	}
+	if firstL2.Number >= l2BlockNum || lastL2.Number < l2BlockNum {
+		err = ErrNotFound
+		return
+	}
+	// Safe heads only increase with the L1 block, as entries are truncated on reset,
+	// so binary search the first L1 block with a safe head at or after the L2 block.
+	lo, hi := firstL1.Number+1, lastL1.Number
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		_, midL2, midErr := entryAt(iter.SeekLT(safeByL1BlockNumKey.Of(mid + 1)))
+		if midErr != nil {
+			err = midErr
+			return
+		}
+		if midL2.Number >= l2BlockNum {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return entryAt(iter.SeekLT(safeByL1BlockNumKey.Of(lo + 1)))
+}
+
+// ImportSafeHeads records the given safe head updates, which must be in increasing L1 order with non-decreasing safe heads.
+// The entries from the L1 block of the first update up to the L1 block of the last update are replaced,
+// so a database can be restored by importing the updates of another one page by page, in order.
+// Entries after the imported range, e.g. recorded by the running node, are kept.
+func (d *SafeDB) ImportSafeHeads(updates []eth.SafeHeadResponse) error {
+	if len(updates) == 0 {
+		return nil
+	}
+	for i := 1; i < len(updates); i++ {
+		prev, update := updates[i-1], updates[i]
+		if update.L1Block.Number <= prev.L1Block.Number {
+			return fmt.Errorf("%w: L1 block %v after %v", ErrInvalidImport, update.L1Block, prev.L1Block)
+		}
+		if update.SafeHead.Number < prev.SafeHead.Number {
+			return fmt.Errorf("%w: safe head %v after %v", ErrInvalidImport, update.SafeHead, prev.SafeHead)
+		}
+	}
+	d.m.Lock()
+	defer d.m.Unlock()
+	// The import must not move the safe head back compared to the entries it follows.
+	prev, prevSafeHead, err := d.safeHeadBefore(updates[0].L1Block.Number)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("failed to read safe head before import: %w", err)
+	} else if err == nil && updates[0].SafeHead.Number < prevSafeHead.Number {
+		return fmt.Errorf("%w: safe head %v after %v recorded at L1 block %v", ErrInvalidImport, updates[0].SafeHead, prevSafeHead, prev)
+	}
+	// Nor past the entries that follow it.
+	last := updates[len(updates)-1]
+	next, nextSafeHead, err := d.safeHeadAfter(last.L1Block.Number)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("failed to read safe head after import: %w", err)
+	} else if err == nil && nextSafeHead.Number < last.SafeHead.Number {
+		return fmt.Errorf("%w: safe head %v before %v recorded at L1 block %v", ErrInvalidImport, last.SafeHead, nextSafeHead, next)
+	}
+	d.log.Info("Import safe heads", "count", len(updates), "first", updates[0].L1Block, "last", last.L1Block)
+	batch := d.db.NewBatch()
+	defer batch.Close()
+	end := safeByL1BlockNumKey.Max()
+	if last.L1Block.Number < math.MaxUint64 {
+		end = safeByL1BlockNumKey.Of(last.L1Block.Number + 1)
+	}
+	if err := batch.DeleteRange(safeByL1BlockNumKey.Of(updates[0].L1Block.Number), end, d.writeOpts); err != nil {
+		return fmt.Errorf("import failed to delete entries: %w", err)
+	}
+	for _, update := range updates {
+		if err := batch.Set(safeByL1BlockNumKey.Of(update.L1Block.Number), safeByL1BlockNumValue(update.L1Block, update.SafeHead), d.writeOpts); err != nil {
+			return fmt.Errorf("import failed to record safe head update: %w", err)
+		}
+	}
+	if err := batch.Commit(d.writeOpts); err != nil {
+		return fmt.Errorf("import failed to commit batch: %w", err)
+	}
+	return nil
+}
+
+// safeHeadBefore returns the last entry before the given L1 block. The caller must hold the lock.
+func (d *SafeDB) safeHeadBefore(l1BlockNum uint64) (l1Block eth.BlockID, safeHead eth.BlockID, err error) {
+	iter, err := d.db.NewIter(safeByL1BlockNumKey.IterRange())
+	if err != nil {
+		return
+	}
+	defer iter.Close()
+	if valid := iter.SeekLT(safeByL1BlockNumKey.Of(l1BlockNum)); !valid {
+		err = ErrNotFound
+		return
+	}
+	val, err := iter.ValueAndErr()
+	if err != nil {
+		return
+	}
+	return decodeSafeByL1BlockNum(iter.Key(), val)
+}
+
+// safeHeadAfter returns the first entry after the given L1 block. The caller must hold the lock.
+func (d *SafeDB) safeHeadAfter(l1BlockNum uint64) (l1Block eth.BlockID, safeHead eth.BlockID, err error) {
+	if l1BlockNum == math.MaxUint64 {
+		err = ErrNotFound
+		return
+	}
+	iter, err := d.db.NewIter(safeByL1BlockNumKey.IterRange())
+	if err != nil {
+		return
+	}
+	defer iter.Close()
+	if valid := iter.SeekGE(safeByL1BlockNumKey.Of(l1BlockNum + 1)); !valid {
+		err = ErrNotFound
+		return
+	}
+	val, err := iter.ValueAndErr()
+	if err != nil {
+		return
+	}
+	return decodeSafeByL1BlockNum(iter.Key(), val)
+}
+
 func (d *SafeDB) Close() error {
 	d.m.Lock()
 	defer d.m.Unlock()
@@ -302,6 +302,165 @@ func TestTruncateOnSafeHeadReset_AfterLastEntry(t *testing.T) {
 	verifySafeHeads()
 }
 
+func TestSafeHeadUpdates(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	dir := t.TempDir()
+	db, err := NewSafeDB(logger, dir)
+	require.NoError(t, err)
+	defer db.Close()
+	updates := storeSafeHeadUpdates(t, db)
+
+	actual, err := db.SafeHeadUpdates(context.Background(), 0, math.MaxUint64)
+	require.NoError(t, err)
+	require.Equal(t, updates, actual)
+
+	actual, err = db.SafeHeadUpdates(context.Background(), 150, 200)
+	require.NoError(t, err)
+	require.Equal(t, updates[1:3], actual)
+
+	actual, err = db.SafeHeadUpdates(context.Background(), 151, 249)
+	require.NoError(t, err)
+	require.Equal(t, updates[2:3], actual)
+
+	actual, err = db.SafeHeadUpdates(context.Background(), 251, 300)
+	require.NoError(t, err)
+	require.Empty(t, actual)
+
+	actual, err = db.SafeHeadUpdates(context.Background(), 200, 150)
+	require.NoError(t, err)
+	require.Empty(t, actual)
+}
+
+func TestSafeHeadAtL2(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	dir := t.TempDir()
+	db, err := NewSafeDB(logger, dir)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, _, err = db.SafeHeadAtL2(context.Background(), 20)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	updates := storeSafeHeadUpdates(t, db)
+
+	// Already safe when the records start
+	_, _, err = db.SafeHeadAtL2(context.Background(), 10)
+	require.ErrorIs(t, err, ErrNotFound)
+	_, _, err = db.SafeHeadAtL2(context.Background(), 20)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// Not safe yet
+	_, _, err = db.SafeHeadAtL2(context.Background(), 41)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	for l2BlockNum, expected := range map[uint64]eth.SafeHeadResponse{
+		21: updates[1],
+		25: updates[1],
+		26: updates[3],
+		40: updates[3],
+	} {
+		actualL1, actualL2, err := db.SafeHeadAtL2(context.Background(), l2BlockNum)
+		require.NoError(t, err)
+		require.Equal(t, expected.L1Block, actualL1, "l1 block for l2 block %v", l2BlockNum)
+		require.Equal(t, expected.SafeHead, actualL2, "safe head for l2 block %v", l2BlockNum)
+	}
+}
+
+func TestImportSafeHeads(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	src, err := NewSafeDB(logger, t.TempDir())
+	require.NoError(t, err)
+	defer src.Close()
+	updates := storeSafeHeadUpdates(t, src)
+
+	dest, err := NewSafeDB(logger, t.TempDir())
+	require.NoError(t, err)
+	defer dest.Close()
+	// Stale entry that is replaced by the import
+	require.NoError(t, dest.SafeHeadUpdated(eth.L2BlockRef{Hash: common.Hash{0x02, 0xff}, Number: 35}, eth.BlockID{Hash: common.Hash{0x01, 0xff}, Number: 200}))
+
+	// Import page by page
+	require.NoError(t, dest.ImportSafeHeads(updates[:2]))
+	require.NoError(t, dest.ImportSafeHeads(updates[2:]))
+
+	actual, err := dest.SafeHeadUpdates(context.Background(), 0, math.MaxUint64)
+	require.NoError(t, err)
+	require.Equal(t, updates, actual)
+
+	require.NoError(t, dest.ImportSafeHeads(nil))
+	actual, err = dest.SafeHeadUpdates(context.Background(), 0, math.MaxUint64)
+	require.NoError(t, err)
+	require.Equal(t, updates, actual)
+}
+
+func TestImportSafeHeads_KeepsNewerEntries(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	db, err := NewSafeDB(logger, t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+	updates := storeSafeHeadUpdates(t, db)
+
+	// Re-importing the start of the history keeps the entries the node recorded after it
+	imported := []eth.SafeHeadResponse{
+		updates[0],
+		{L1Block: eth.BlockID{Hash: common.Hash{0x01, 0xee}, Number: 120}, SafeHead: eth.BlockID{Hash: common.Hash{0x02, 0xee}, Number: 22}},
+	}
+	require.NoError(t, db.ImportSafeHeads(imported))
+
+	actual, err := db.SafeHeadUpdates(context.Background(), 0, math.MaxUint64)
+	require.NoError(t, err)
+	require.Equal(t, []eth.SafeHeadResponse{imported[0], imported[1], updates[1], updates[2], updates[3]}, actual)
+}
+
+func TestImportSafeHeads_Invalid(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	db, err := NewSafeDB(logger, t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+	updates := storeSafeHeadUpdates(t, db)
+
+	// L1 blocks out of order
+	err = db.ImportSafeHeads([]eth.SafeHeadResponse{updates[1], updates[0]})
+	require.ErrorIs(t, err, ErrInvalidImport)
+
+	// Safe head moves back
+	err = db.ImportSafeHeads([]eth.SafeHeadResponse{
+		updates[2],
+		{L1Block: eth.BlockID{Hash: common.Hash{0x01, 0xff}, Number: 300}, SafeHead: updates[0].SafeHead},
+	})
+	require.ErrorIs(t, err, ErrInvalidImport)
+
+	// Safe head before the one recorded at an earlier L1 block
+	err = db.ImportSafeHeads([]eth.SafeHeadResponse{
+		{L1Block: eth.BlockID{Hash: common.Hash{0x01, 0xff}, Number: 300}, SafeHead: updates[0].SafeHead},
+	})
+	require.ErrorIs(t, err, ErrInvalidImport)
+
+	// Safe head past the one recorded at a later L1 block
+	err = db.ImportSafeHeads([]eth.SafeHeadResponse{
+		{L1Block: eth.BlockID{Hash: common.Hash{0x01, 0xff}, Number: 120}, SafeHead: updates[3].SafeHead},
+	})
+	require.ErrorIs(t, err, ErrInvalidImport)
+
+	// Nothing was modified
+	actual, err := db.SafeHeadUpdates(context.Background(), 0, math.MaxUint64)
+	require.NoError(t, err)
+	require.Equal(t, updates, actual)
+}
+
+func storeSafeHeadUpdates(t *testing.T, db *SafeDB) []eth.SafeHeadResponse {
+	updates := []eth.SafeHeadResponse{
+		{L1Block: eth.BlockID{Hash: common.Hash{0x01, 0xaa}, Number: 100}, SafeHead: eth.BlockID{Hash: common.Hash{0x02, 0xaa}, Number: 20}},
+		{L1Block: eth.BlockID{Hash: common.Hash{0x01, 0xbb}, Number: 150}, SafeHead: eth.BlockID{Hash: common.Hash{0x02, 0xbb}, Number: 25}},
+		{L1Block: eth.BlockID{Hash: common.Hash{0x01, 0xcc}, Number: 200}, SafeHead: eth.BlockID{Hash: common.Hash{0x02, 0xbb}, Number: 25}},
+		{L1Block: eth.BlockID{Hash: common.Hash{0x01, 0xdd}, Number: 250}, SafeHead: eth.BlockID{Hash: common.Hash{0x02, 0xdd}, Number: 40}},
+	}
+	for _, update := range updates {
+		require.NoError(t, db.SafeHeadUpdated(eth.L2BlockRef{Hash: update.SafeHead.Hash, Number: update.SafeHead.Number}, update.L1Block))
+	}
+	return updates
+}
+
 func TestKeysFollowNaturalByteOrdering(t *testing.T) {
 	vals := []uint64{0, 1, math.MaxUint32 - 1, math.MaxUint32, math.MaxUint32 + 1, math.MaxUint64 - 1, math.MaxUint64}
 	for i := 1; i < len(vals); i++ {
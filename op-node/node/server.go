@@ -6,6 +6,7 @@ import (
 	"net"
 	"net/http"
 	"strconv"
+	"strings"
 
 	ophttp "github.com/ethereum-optimism/optimism/op-service/httputil"
 	"github.com/ethereum/go-ethereum/log"
@@ -24,6 +25,8 @@ type rpcServer struct {
 	httpServer *ophttp.HTTPServer
 	appVersion string
 	log        log.Logger
+	// ws is true if the server upgrades websocket connections, to serve subscriptions.
+	ws bool
 	sources.L2Client
 }
 
@@ -53,6 +56,26 @@ func (s *rpcServer) EnableAdminAPI(api *adminAPI) {
 	})
 }
 
+// EnableBlobAPI extends the optimism namespace with the blob sidecar archive methods.
+func (s *rpcServer) EnableBlobAPI(api *blobAPI) {
+	s.apis = append(s.apis, rpc.API{
+		Namespace:     "optimism",
+		Service:       api,
+		Authenticated: false,
+	})
+}
+
+// EnableDerivationEventsAPI extends the optimism namespace with the derivation events subscription,
+// and serves websocket connections on the RPC port for it.
+func (s *rpcServer) EnableDerivationEventsAPI(api *derivationEventsAPI) {
+	s.apis = append(s.apis, rpc.API{
+		Namespace:     "optimism",
+		Service:       api,
+		Authenticated: false,
+	})
+	s.ws = true
+}
+
 func (s *rpcServer) EnableP2P(backend *p2p.APIBackend) {
 	s.apis = append(s.apis, rpc.API{
 		Namespace:     p2p.NamespaceRPC,
@@ -73,6 +96,9 @@ func (s *rpcServer) Start() error {
 	// defaults to localhost, which will prevent containers from
 	// calling into the opnode without an "invalid host" error.
 	nodeHandler := node.NewHTTPHandlerStack(srv, []string{"*"}, []string{"*"}, nil)
+	if s.ws {
+		nodeHandler = withWebsocket(nodeHandler, node.NewWSHandlerStack(srv.WebsocketHandler([]string{"*"}), nil))
+	}
 
 	mux := http.NewServeMux()
 	mux.Handle("/", nodeHandler)
@@ -94,6 +120,18 @@ func (r *rpcServer) Addr() net.Addr {
 	return r.httpServer.Addr()
 }
 
+// withWebsocket serves the websocket upgrade requests with wsHandler, and the other requests with httpHandler.
+func withWebsocket(httpHandler http.Handler, wsHandler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") &&
+			strings.Contains(strings.ToLower(r.Header.Get("Connection")), "upgrade") {
+			wsHandler.ServeHTTP(w, r)
+			return
+		}
+		httpHandler.ServeHTTP(w, r)
+	})
+}
+
 func healthzHandler(appVersion string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		_, _ = w.Write([]byte(appVersion))
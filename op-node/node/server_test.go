@@ -5,17 +5,20 @@ import (
 	"encoding/json"
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/version"
 	rpcclient "github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -246,6 +249,68 @@ func TestSafeHeadAtL1Block(t *testing.T) {
 	safeReader.Mock.AssertExpectations(t)
 }
 
+func TestSafeHeadUpdates(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	safeReader := &mockSafeDBReader{}
+	expected := []eth.SafeHeadResponse{
+		{L1Block: eth.BlockID{Hash: common.Hash{0xdd}, Number: 5221}, SafeHead: eth.BlockID{Hash: common.Hash{0xee}, Number: 223}},
+		{L1Block: eth.BlockID{Hash: common.Hash{0xde}, Number: 5225}, SafeHead: eth.BlockID{Hash: common.Hash{0xef}, Number: 230}},
+	}
+	safeReader.ExpectSafeHeadUpdates(5220, 5230, expected, nil)
+	safeReader.ExpectSafeHeadUpdates(5231, 5240, nil, nil)
+
+	client := startSafeDBTestServer(t, log, safeReader)
+
+	var out []eth.SafeHeadResponse
+	err := client.CallContext(context.Background(), &out, "optimism_safeHeadUpdates", hexutil.Uint64(5220), hexutil.Uint64(5230))
+	require.NoError(t, err)
+	require.Equal(t, expected, out)
+
+	var empty []eth.SafeHeadResponse
+	err = client.CallContext(context.Background(), &empty, "optimism_safeHeadUpdates", hexutil.Uint64(5231), hexutil.Uint64(5240))
+	require.NoError(t, err)
+	require.NotNil(t, empty)
+	require.Empty(t, empty)
+
+	err = client.CallContext(context.Background(), &out, "optimism_safeHeadUpdates", hexutil.Uint64(0), hexutil.Uint64(maxSafeHeadUpdatesRange))
+	require.ErrorContains(t, err, ErrSafeHeadUpdatesRange.Error())
+	safeReader.Mock.AssertExpectations(t)
+}
+
+func TestSafeHeadAtL2Block(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	safeReader := &mockSafeDBReader{}
+	expected := &eth.SafeHeadResponse{
+		L1Block:  eth.BlockID{Hash: common.Hash{0xdd}, Number: 5221},
+		SafeHead: eth.BlockID{Hash: common.Hash{0xee}, Number: 225},
+	}
+	safeReader.ExpectSafeHeadAtL2(223, expected.L1Block, expected.SafeHead, nil)
+
+	client := startSafeDBTestServer(t, log, safeReader)
+
+	var out *eth.SafeHeadResponse
+	err := client.CallContext(context.Background(), &out, "optimism_safeHeadAtL2Block", hexutil.Uint64(223).String())
+	require.NoError(t, err)
+	require.Equal(t, expected, out)
+	safeReader.Mock.AssertExpectations(t)
+}
+
+func startSafeDBTestServer(t *testing.T, log log.Logger, safeReader SafeDBReader) rpcclient.RPC {
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	server, err := newRPCServer(rpcCfg, &rollup.Config{}, &testutils.MockL2Client{}, &mockDriverClient{}, safeReader, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	require.NoError(t, server.Start())
+	t.Cleanup(func() {
+		require.NoError(t, server.Stop(context.Background()))
+	})
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+	return client
+}
+
 type mockDriverClient struct {
 	mock.Mock
 }
@@ -295,3 +360,78 @@ func (m *mockSafeDBReader) SafeHeadAtL1(ctx context.Context, l1BlockNum uint64)
 func (m *mockSafeDBReader) ExpectSafeHeadAtL1(l1BlockNum uint64, l1 eth.BlockID, safeHead eth.BlockID, err error) {
 	m.Mock.On("SafeHeadAtL1", l1BlockNum).Return(l1, safeHead, &err)
 }
+
+func (m *mockSafeDBReader) SafeHeadUpdates(ctx context.Context, fromL1 uint64, toL1 uint64) ([]eth.SafeHeadResponse, error) {
+	r := m.Mock.MethodCalled("SafeHeadUpdates", fromL1, toL1)
+	return r[0].([]eth.SafeHeadResponse), *r[1].(*error)
+}
+
+func (m *mockSafeDBReader) ExpectSafeHeadUpdates(fromL1 uint64, toL1 uint64, updates []eth.SafeHeadResponse, err error) {
+	m.Mock.On("SafeHeadUpdates", fromL1, toL1).Return(updates, &err)
+}
+
+func (m *mockSafeDBReader) SafeHeadAtL2(ctx context.Context, l2BlockNum uint64) (l1Hash eth.BlockID, l2Hash eth.BlockID, err error) {
+	r := m.Mock.MethodCalled("SafeHeadAtL2", l2BlockNum)
+	return r[0].(eth.BlockID), r[1].(eth.BlockID), *r[2].(*error)
+}
+
+func (m *mockSafeDBReader) ExpectSafeHeadAtL2(l2BlockNum uint64, l1 eth.BlockID, safeHead eth.BlockID, err error) {
+	m.Mock.On("SafeHeadAtL2", l2BlockNum).Return(l1, safeHead, &err)
+}
+
+func TestDerivationEvents(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	server, err := newRPCServer(rpcCfg, rollupCfg, &testutils.MockL2Client{}, &mockDriverClient{}, &mockSafeDBReader{}, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	feed := derive.NewDerivationEventFeed()
+	server.EnableDerivationEventsAPI(NewDerivationEventsAPI(feed, log, metrics.NoopMetrics))
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	// the other APIs are still served over HTTP
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+	var out *rollup.Config
+	require.NoError(t, client.CallContext(context.Background(), &out, "optimism_rollupConfig"))
+
+	wsClient, err := gethrpc.DialContext(context.Background(), "ws://"+server.Addr().String())
+	require.NoError(t, err)
+	defer wsClient.Close()
+	events := make(chan derive.DerivationEvent, 1)
+	sub, err := wsClient.Subscribe(context.Background(), "optimism", events, "derivationEvents")
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+	require.Eventually(t, feed.Enabled, 5*time.Second, 10*time.Millisecond)
+
+	ev := derive.DerivationEvent{
+		Type:   derive.ChannelTimedOutEvent,
+		Origin: eth.BlockID{Hash: common.Hash{0x01}, Number: 10},
+		Channel: &derive.ChannelEventInfo{
+			ID:        derive.ChannelID{0x02},
+			OpenBlock: 5,
+			Frames:    2,
+			Size:      1000,
+		},
+	}
+	feed.OnDerivationEvent(ev)
+	select {
+	case got := <-events:
+		require.Equal(t, ev, got)
+	case err := <-sub.Err():
+		t.Fatal(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for derivation event")
+	}
+
+	sub.Unsubscribe()
+	require.Eventually(t, func() bool { return !feed.Enabled() }, 5*time.Second, 10*time.Millisecond)
+}
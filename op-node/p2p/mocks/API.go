@@ -665,6 +665,60 @@ func (_c *API_Self_Call) RunAndReturn(run func(context.Context) (*p2p.PeerInfo,
 	return _c
 }
 
+// SyncStats provides a mock function with given fields: ctx
+func (_m *API) SyncStats(ctx context.Context) ([]p2p.PeerSyncStats, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []p2p.PeerSyncStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]p2p.PeerSyncStats, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []p2p.PeerSyncStats); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]p2p.PeerSyncStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// API_SyncStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SyncStats'
+type API_SyncStats_Call struct {
+	*mock.Call
+}
+
+// SyncStats is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *API_Expecter) SyncStats(ctx interface{}) *API_SyncStats_Call {
+	return &API_SyncStats_Call{Call: _e.mock.On("SyncStats", ctx)}
+}
+
+func (_c *API_SyncStats_Call) Run(run func(ctx context.Context)) *API_SyncStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *API_SyncStats_Call) Return(_a0 []p2p.PeerSyncStats, _a1 error) *API_SyncStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *API_SyncStats_Call) RunAndReturn(run func(context.Context) ([]p2p.PeerSyncStats, error)) *API_SyncStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UnblockAddr provides a mock function with given fields: ctx, ip
 func (_m *API) UnblockAddr(ctx context.Context, ip net.IP) error {
 	ret := _m.Called(ctx, ip)
@@ -37,6 +37,7 @@ var (
 	ErrNoConnectionManager = errors.New("no connection manager")
 	ErrNoConnectionGater   = errors.New("no connection gater")
 	ErrInvalidRequest      = errors.New("invalid request")
+	ErrDisabledReqRespSync = errors.New("req-resp sync disabled")
 )
 
 type Node interface {
@@ -54,6 +55,8 @@ type Node interface {
 	ConnectionGater() gating.BlockingConnectionGater
 	// ConnectionManager returns the connection manager, to protect peers with, may be nil
 	ConnectionManager() connmgr.ConnManager
+	// SyncClient returns the req-resp sync client, nil if req-resp sync is disabled
+	SyncClient() *SyncClient
 }
 
 type APIBackend struct {
@@ -428,3 +431,14 @@ func (s *APIBackend) DisconnectPeer(_ context.Context, id peer.ID) error {
 	}
 	return nil
 }
+
+// SyncStats returns the latency, throughput and error rate of the peers we sync from with req-resp sync.
+func (s *APIBackend) SyncStats(_ context.Context) ([]PeerSyncStats, error) {
+	recordDur := s.m.RecordRPCServerRequest("opp2p_syncStats")
+	defer recordDur()
+	syncCl := s.node.SyncClient()
+	if syncCl == nil {
+		return nil, ErrDisabledReqRespSync
+	}
+	return syncCl.PeerSyncStats(), nil
+}
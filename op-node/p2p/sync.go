@@ -109,8 +109,11 @@ type syncResult struct {
 	peer    peer.ID
 }
 
+// peerRequest requests the count blocks up to and including num.
+// Multiple blocks are only requested at once from peers that support payloads-by-range.
 type peerRequest struct {
 	num        uint64
+	count      uint64
 	rangeReqId uint64
 }
 
@@ -231,8 +234,12 @@ type SyncClient struct {
 	metrics   SyncClientMetrics
 	appScorer SyncPeerScorer
 
+	// peerStats tracks how well each peer serves our requests, to prefer faster peers.
+	peerStats *syncPeerStats
+
 	newStreamFn     newStreamFn
 	payloadByNumber protocol.ID
+	payloadsByRange protocol.ID
 
 	peersLock sync.Mutex
 	// syncing worker per peer
@@ -286,8 +293,10 @@ func NewSyncClient(log log.Logger, cfg *rollup.Config, newStream newStreamFn, rc
 		cfg:                 cfg,
 		metrics:             metrics,
 		appScorer:           appScorer,
+		peerStats:           newSyncPeerStats(),
 		newStreamFn:         newStream,
 		payloadByNumber:     PayloadByNumberProtocolID(cfg.L2ChainID),
+		payloadsByRange:     PayloadsByRangeProtocolID(cfg.L2ChainID),
 		peers:               make(map[peer.ID]context.CancelFunc),
 		quarantineByNum:     make(map[uint64]common.Hash),
 		rangeRequests:       make(chan rangeRequest), // blocking
@@ -333,6 +342,7 @@ func (s *SyncClient) AddPeer(id peer.ID) {
 	// add new peer routine
 	ctx, cancel := context.WithCancel(s.resCtx)
 	s.peers[id] = cancel
+	s.peerStats.add(id)
 	go s.peerLoop(ctx, id)
 }
 
@@ -348,6 +358,11 @@ func (s *SyncClient) RemovePeer(id peer.ID) {
 	delete(s.peers, id)
 }
 
+// PeerSyncStats returns the latency, throughput and error rate of each peer we sync from.
+func (s *SyncClient) PeerSyncStats() []PeerSyncStats {
+	return s.peerStats.snapshot()
+}
+
 // Close will shut down the sync client and all attached work, and block until shutdown is complete.
 // This will block if the Start() has not created the main background loop.
 func (s *SyncClient) Close() error {
@@ -431,10 +446,34 @@ func (s *SyncClient) onRangeRequest(ctx context.Context, req rangeRequest) {
 	s.trusted.Add(req.end.Hash, struct{}{})
 	s.trusted.Add(req.end.ParentHash, struct{}{})
 
+	// Contiguous blocks that need to be fetched are batched into a single peer request.
+	pr := peerRequest{rangeReqId: req.id}
+	schedule := func() bool {
+		if pr.count == 0 {
+			return true
+		}
+		log.Debug("Scheduling P2P block request", "num", pr.num, "count", pr.count, "rangeReqId", req.id)
+		select {
+		case s.peerRequests <- pr:
+			for i := uint64(0); i < pr.count; i++ {
+				s.inFlight.set(pr.num-i, true)
+			}
+			pr.count = 0
+			return true
+		case <-ctx.Done():
+			log.Info("did not schedule full P2P sync range", "current", pr.num, "err", ctx.Err())
+			return false
+		default: // peers may all be busy processing requests already
+			log.Info("no peers ready to handle block requests for more P2P requests for L2 block history", "current", pr.num)
+			return false
+		}
+	}
+
 	// Now try to fetch lower numbers than current end, to traverse back towards the updated start.
 	for i := uint64(0); ; i++ {
 		num := req.end.Number - 1 - i
 		if num <= req.start {
+			schedule()
 			return
 		}
 		// check if we have something in quarantine already
@@ -444,25 +483,24 @@ func (s *SyncClient) onRangeRequest(ctx context.Context, req rangeRequest) {
 			}
 			// Don't fetch things that we have a candidate for already.
 			// We'll evict it from quarantine by finding a conflict, or if we sync enough other blocks
+			if !schedule() {
+				return
+			}
 			continue
 		}
 
 		if s.inFlight.get(num) {
 			log.Debug("request still in-flight, not rescheduling sync request", "num", num)
+			if !schedule() {
+				return
+			}
 			continue // request still in flight
 		}
-		pr := peerRequest{num: num, rangeReqId: req.id}
-
-		log.Debug("Scheduling P2P block request", "num", num, "rangeReqId", req.id)
-		// schedule number
-		select {
-		case s.peerRequests <- pr:
-			s.inFlight.set(num, true)
-		case <-ctx.Done():
-			log.Info("did not schedule full P2P sync range", "current", num, "err", ctx.Err())
-			return
-		default: // peers may all be busy processing requests already
-			log.Info("no peers ready to handle block requests for more P2P requests for L2 block history", "current", num)
+		if pr.count == 0 {
+			pr.num = num
+		}
+		pr.count++
+		if pr.count == rangeRequestBlocks && !schedule() {
 			return
 		}
 	}
@@ -539,11 +577,14 @@ func (s *SyncClient) onResult(ctx context.Context, res syncResult) {
 	}
 }
 
-// peerLoop for syncing from a single peer
+// peerLoop for syncing from a single peer.
+// Peers that serve us reliably get multiple concurrent requests, and slow peers leave requests to faster peers
+// while there is little work to do.
 func (s *SyncClient) peerLoop(ctx context.Context, id peer.ID) {
 	defer func() {
 		s.peersLock.Lock()
 		delete(s.peers, id) // clean up
+		s.peerStats.remove(id)
 		s.log.Debug("stopped syncing loop of peer", "id", id)
 		s.wg.Done()
 		s.peersLock.Unlock()
@@ -555,8 +596,24 @@ func (s *SyncClient) peerLoop(ctx context.Context, id peer.ID) {
 	// Implement the same rate limits as the server does per-peer,
 	// so we don't be too aggressive to the server.
 	rl := rate.NewLimiter(peerServerBlocksRateLimit, peerServerBlocksBurst)
+	rangeRL := rate.NewLimiter(peerServerRangeRequestsRateLimit, peerServerRangeRequestsBurst)
+
+	// requests to the peer that are in progress, each signals done when it completes
+	var requests sync.WaitGroup
+	defer requests.Wait()
+	active := 0
+	done := make(chan struct{}, maxPeerParallelRequests)
 
 	for {
+		// wait for a request slot, we only trust the peer with more concurrent requests if it serves us well
+		for active >= s.peerStats.parallelism(id) {
+			select {
+			case <-done:
+				active--
+			case <-ctx.Done():
+				return
+			}
+		}
 		// wait for a global allocation to be available
 		if err := s.globalRL.Wait(ctx); err != nil {
 			return
@@ -565,57 +622,90 @@ func (s *SyncClient) peerLoop(ctx context.Context, id peer.ID) {
 		if err := rl.Wait(ctx); err != nil {
 			return
 		}
+		// leave the request to faster peers, unless there is enough work for everyone
+		if delay := s.peerStats.yieldDelay(id); delay > 0 && len(s.peerRequests) < syncPeerYieldQueueLen {
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return
+			}
+		}
 
 		// once the peer is available, wait for a sync request.
 		select {
 		case pr := <-s.peerRequests:
 			if !s.activeRangeRequests.get(pr.rangeReqId) {
-				log.Debug("dropping cancelled p2p sync request", "num", pr.num)
-				s.inFlight.delete(pr.num)
+				log.Debug("dropping cancelled p2p sync request", "num", pr.num, "count", pr.count)
+				s.releaseInFlight(pr)
 				continue
 			}
+			active++
+			requests.Add(1)
+			go func() {
+				defer requests.Done()
+				s.peerRequest(ctx, log, id, rl, rangeRL, pr)
+				done <- struct{}{}
+			}()
+		case <-done:
+			active--
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// releaseInFlight marks the blocks of the request as no longer in-flight, so they can be requested again.
+func (s *SyncClient) releaseInFlight(pr peerRequest) {
+	for i := uint64(0); i < pr.count; i++ {
+		s.inFlight.delete(pr.num - i)
+	}
+}
+
+// peerRequest serves a single request of the peer loop.
+func (s *SyncClient) peerRequest(ctx context.Context, log log.Logger, id peer.ID, rl *rate.Limiter, rangeRL *rate.Limiter, pr peerRequest) {
+	// We already established the peer is available w.r.t. rate-limiting,
+	// and the peer loop limits the number of concurrent requests, so we can request now.
+	start := time.Now()
 
-			// We already established the peer is available w.r.t. rate-limiting,
-			// and this is the only loop over this peer, so we can request now.
-			start := time.Now()
-
-			resultCode := ResultCodeSuccess
-			err := panicGuard(s.doRequest)(ctx, id, pr.num)
-			if err != nil {
-				s.inFlight.delete(pr.num)
-				log.Warn("failed p2p sync request", "num", pr.num, "err", err)
-				resultCode = ResultCodeNotFoundErr
-				sendResponseError := true
-
-				if re, ok := err.(requestResultErr); ok {
-					resultCode = re.ResultCode()
-					if resultCode == ResultCodeNotFoundErr {
-						log.Warn("cancelling p2p sync range request", "rangeReqId", pr.rangeReqId)
-						s.activeRangeRequests.delete(pr.rangeReqId)
-						sendResponseError = false // don't penalize peer for this error
-					}
-				}
-
-				if sendResponseError {
-					s.appScorer.onResponseError(id)
-				}
-
-				// If we hit an error, then count it as many requests.
-				// We'd like to avoid making more requests for a while, so back off.
-				if err := rl.WaitN(ctx, clientErrRateCost); err != nil {
-					return
-				}
-			} else {
-				log.Debug("completed p2p sync request", "num", pr.num)
-				s.appScorer.onValidResponse(id)
+	resultCode := ResultCodeSuccess
+	var size int
+	var served uint64
+	err := panicGuard(func(ctx context.Context, id peer.ID, pr peerRequest) (err error) {
+		size, served, err = s.doRequest(ctx, id, rl, rangeRL, pr)
+		return err
+	})(ctx, id, pr)
+	took := time.Since(start)
+	if err != nil {
+		s.releaseInFlight(pr)
+		log.Warn("failed p2p sync request", "num", pr.num, "count", pr.count, "err", err)
+		resultCode = ResultCodeNotFoundErr
+		sendResponseError := true
+
+		if re, ok := err.(requestResultErr); ok {
+			resultCode = re.ResultCode()
+			if resultCode == ResultCodeNotFoundErr {
+				log.Warn("cancelling p2p sync range request", "rangeReqId", pr.rangeReqId)
+				s.activeRangeRequests.delete(pr.rangeReqId)
+				sendResponseError = false // don't penalize peer for this error
 			}
+		}
 
-			took := time.Since(start)
-			s.metrics.ClientPayloadByNumberEvent(pr.num, resultCode, took)
-		case <-ctx.Done():
-			return
+		if sendResponseError {
+			s.appScorer.onResponseError(id)
+			s.peerStats.onError(id)
 		}
+		s.metrics.ClientPayloadByNumberEvent(pr.num, resultCode, took)
+
+		// If we hit an error, then count it as many requests.
+		// We'd like to avoid making more requests for a while, so back off.
+		_ = rl.WaitN(ctx, clientErrRateCost)
+		return
 	}
+	log.Debug("completed p2p sync request", "num", pr.num, "count", pr.count, "served", served)
+	s.appScorer.onValidResponse(id)
+	// Stats are kept per block, so peers serving ranges compare fairly to peers serving single blocks.
+	s.peerStats.onSuccess(id, took/time.Duration(max(served, 1)), size/int(max(served, 1)))
+	s.metrics.ClientPayloadByNumberEvent(pr.num, resultCode, took)
 }
 
 type requestResultErr byte
@@ -634,22 +724,77 @@ func (r requestResultErr) ResultCode() byte {
 	return byte(r)
 }
 
-func (s *SyncClient) doRequest(ctx context.Context, id peer.ID, expectedBlockNum uint64) error {
+// doRequest requests the blocks of the peer request, and returns the size of the response payloads,
+// and the number of blocks that were served.
+// Blocks of the request that were not served, and not rescheduled, are released from in-flight tracking.
+//
+// Multiple blocks are requested at once with the payloads-by-range protocol, if the peer supports it.
+// Otherwise only the top block is requested, and the remaining blocks are scheduled as a new peer request.
+func (s *SyncClient) doRequest(ctx context.Context, id peer.ID, rl *rate.Limiter, rangeRL *rate.Limiter, pr peerRequest) (int, uint64, error) {
+	protocols := []protocol.ID{s.payloadByNumber}
+	if pr.count > 1 && s.peerStats.maybePayloadsByRange(id) {
+		protocols = []protocol.ID{s.payloadsByRange, s.payloadByNumber}
+		// The server charges every block of a range against the block rate limits, while the peer loop
+		// only charged one. Charge the rest before the request is sent, so parallel range requests
+		// don't wait at the server past the response timeout.
+		if err := s.globalRL.WaitN(ctx, int(pr.count)-1); err != nil {
+			return 0, 0, err
+		}
+		if err := rl.WaitN(ctx, int(pr.count)-1); err != nil {
+			return 0, 0, err
+		}
+	}
 	// open stream to peer
 	reqCtx, reqCancel := context.WithTimeout(ctx, streamTimeout)
-	str, err := s.newStreamFn(reqCtx, id, s.payloadByNumber)
+	str, err := s.newStreamFn(reqCtx, id, protocols...)
 	reqCancel()
 	if err != nil {
-		return fmt.Errorf("failed to open stream: %w", err)
+		return 0, 0, fmt.Errorf("failed to open stream: %w", err)
 	}
 	defer str.Close()
+
+	if str.Protocol() == s.payloadsByRange {
+		s.peerStats.setPayloadsByRange(id, true)
+		// Implement the same range rate limits as the server does per-peer.
+		if err := rangeRL.Wait(ctx); err != nil {
+			return 0, 0, err
+		}
+		size, served, err := s.doRangeRequest(ctx, str, id, pr)
+		if err != nil {
+			return 0, 0, err
+		}
+		// The server serves the bottom of the range. Release the rest, so it gets requested again.
+		s.releaseInFlight(peerRequest{num: pr.num, count: pr.count - served})
+		return size, served, nil
+	}
+	if len(protocols) > 1 {
+		s.peerStats.setPayloadsByRange(id, false)
+	}
+	size, err := s.doPayloadRequest(ctx, str, id, pr.num)
+	if err != nil {
+		return 0, 0, err
+	}
+	if pr.count > 1 {
+		rest := peerRequest{num: pr.num - 1, count: pr.count - 1, rangeReqId: pr.rangeReqId}
+		select {
+		case s.peerRequests <- rest:
+		default: // the queue is full, the blocks will be requested again with the next range request
+			s.releaseInFlight(rest)
+		}
+	}
+	return size, 1, nil
+}
+
+// doPayloadRequest requests the block with the given number over a stream of the payload-by-number protocol,
+// and returns the size of the response payload.
+func (s *SyncClient) doPayloadRequest(ctx context.Context, str network.Stream, id peer.ID, expectedBlockNum uint64) (int, error) {
 	// set write timeout (if available)
 	_ = str.SetWriteDeadline(time.Now().Add(clientWriteRequestTimeout))
 	if err := binary.Write(str, binary.LittleEndian, expectedBlockNum); err != nil {
-		return fmt.Errorf("failed to write request (%d): %w", expectedBlockNum, err)
+		return 0, fmt.Errorf("failed to write request (%d): %w", expectedBlockNum, err)
 	}
 	if err := str.CloseWrite(); err != nil {
-		return fmt.Errorf("failed to close writer side while making request: %w", err)
+		return 0, fmt.Errorf("failed to close writer side while making request: %w", err)
 	}
 
 	// set read timeout (if available)
@@ -661,14 +806,14 @@ func (s *SyncClient) doRequest(ctx context.Context, id peer.ID, expectedBlockNum
 	r := io.LimitReader(str, maxGossipSize)
 	var result [1]byte
 	if _, err := io.ReadFull(r, result[:]); err != nil {
-		return fmt.Errorf("failed to read result part of response: %w", err)
+		return 0, fmt.Errorf("failed to read result part of response: %w", err)
 	}
 	if res := result[0]; res != 0 {
-		return requestResultErr(res)
+		return 0, requestResultErr(res)
 	}
 	var versionData [4]byte
 	if _, err := io.ReadFull(r, versionData[:]); err != nil {
-		return fmt.Errorf("failed to read version part of response: %w", err)
+		return 0, fmt.Errorf("failed to read version part of response: %w", err)
 	}
 
 	// payload is SSZ encoded with Snappy framed compression
@@ -679,27 +824,27 @@ func (s *SyncClient) doRequest(ctx context.Context, id peer.ID, expectedBlockNum
 	// The server does not prepend it, nor would we trust a claimed length anyway, so we buffer the data we get.
 	data, err := io.ReadAll(r)
 	if err != nil {
-		return fmt.Errorf("failed to read response: %w", err)
+		return 0, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	version := binary.LittleEndian.Uint32(versionData[:])
 	isCanyon := s.cfg.IsCanyon(s.cfg.MillisecondTimestampForBlock(expectedBlockNum) / 1000)
 	envelope, err := readExecutionPayload(version, data, isCanyon)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	if err := str.CloseRead(); err != nil {
-		return fmt.Errorf("failed to close reading side")
+		return 0, fmt.Errorf("failed to close reading side")
 	}
 	if err := verifyBlock(envelope, expectedBlockNum); err != nil {
-		return fmt.Errorf("received execution payload is invalid: %w", err)
+		return 0, fmt.Errorf("received execution payload is invalid: %w", err)
 	}
 	select {
 	case s.results <- syncResult{payload: envelope, peer: id}:
 	case <-ctx.Done():
-		return fmt.Errorf("failed to process response, sync client is too busy: %w", err)
+		return 0, fmt.Errorf("failed to process response, sync client is too busy: %w", err)
 	}
-	return nil
+	return len(data), nil
 }
 
 // panicGuard is a generic function that takes another function with generic arguments and returns an error.
@@ -757,6 +902,15 @@ func verifyBlock(envelope *eth.ExecutionPayloadEnvelope, expectedNum uint64) err
 type peerStat struct {
 	// Requests tokenizes each request to sync
 	Requests *rate.Limiter
+	// RangeRequests tokenizes each request to sync a range of blocks
+	RangeRequests *rate.Limiter
+}
+
+func newPeerStat() *peerStat {
+	return &peerStat{
+		Requests:      rate.NewLimiter(peerServerBlocksRateLimit, peerServerBlocksBurst),
+		RangeRequests: rate.NewLimiter(peerServerRangeRequestsRateLimit, peerServerRangeRequestsBurst),
+	}
 }
 
 type L2Chain interface {
@@ -777,7 +931,8 @@ type ReqRespServer struct {
 	peerRateLimits *simplelru.LRU[peer.ID, *peerStat]
 	peerStatsLock  sync.Mutex
 
-	globalRequestsRL *rate.Limiter
+	globalRequestsRL      *rate.Limiter
+	globalRangeRequestsRL *rate.Limiter
 }
 
 func NewReqRespServer(cfg *rollup.Config, l2 L2Chain, metrics ReqRespServerMetrics) *ReqRespServer {
@@ -786,13 +941,15 @@ func NewReqRespServer(cfg *rollup.Config, l2 L2Chain, metrics ReqRespServerMetri
 
 	peerRateLimits, _ := simplelru.NewLRU[peer.ID, *peerStat](1000, nil)
 	globalRequestsRL := rate.NewLimiter(globalServerBlocksRateLimit, globalServerBlocksBurst)
+	globalRangeRequestsRL := rate.NewLimiter(globalServerRangeRequestsRateLimit, globalServerRangeRequestsBurst)
 
 	return &ReqRespServer{
-		cfg:              cfg,
-		l2:               l2,
-		metrics:          metrics,
-		peerRateLimits:   peerRateLimits,
-		globalRequestsRL: globalRequestsRL,
+		cfg:                   cfg,
+		l2:                    l2,
+		metrics:               metrics,
+		peerRateLimits:        peerRateLimits,
+		globalRequestsRL:      globalRequestsRL,
+		globalRangeRequestsRL: globalRangeRequestsRL,
 	}
 }
 
@@ -845,9 +1002,7 @@ func (srv *ReqRespServer) handleSyncRequest(ctx context.Context, stream network.
 	srv.peerStatsLock.Lock()
 	ps, _ := srv.peerRateLimits.Get(peerId)
 	if ps == nil {
-		ps = &peerStat{
-			Requests: rate.NewLimiter(peerServerBlocksRateLimit, peerServerBlocksBurst),
-		}
+		ps = newPeerStat()
 		srv.peerRateLimits.Add(peerId, ps)
 		ps.Requests.Reserve() // count the hit, but make it delay the next request rather than immediately waiting
 	} else {
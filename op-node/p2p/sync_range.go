@@ -0,0 +1,288 @@
+package p2p
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"golang.org/x/time/rate"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// The payloads-by-range protocol serves a contiguous range of payloads per stream, to avoid a round trip per block.
+//
+// Request: start block number, number of blocks, and maximum response size in bytes, each as little-endian uint64.
+//
+// Response: a result code byte, followed on success by a snappy framed stream of the payloads in ascending order,
+// each as a little-endian uint32 version (same as payload_by_number), a little-endian uint32 size,
+// and the SSZ encoded payload of that size.
+// The server stops early, but returns at least one payload, when the response would exceed the maximum size,
+// or when it does not have the next payload. Each served block is charged against the same block rate limits
+// as the payload_by_number protocol, so a response holds at most the per-peer block burst.
+const (
+	// maximum number of blocks a peer may request at once
+	maxRangeRequestBlocks = 64
+	// number of blocks the client requests at once, what a server serves at once at most
+	rangeRequestBlocks = peerServerBlocksBurst
+	// maximum total size of the SSZ encoded payloads in a single response
+	maxRangeResponseBytes = maxGossipSize
+	// Do not serve more than 10 range requests per second
+	globalServerRangeRequestsRateLimit rate.Limit = 10
+	// Allows a burst of 2x our rate limit
+	globalServerRangeRequestsBurst = 20
+	// Do not serve more than 2 range requests per second to the same peer, so we can serve other peers at the same time
+	peerServerRangeRequestsRateLimit rate.Limit = 2
+	// Allow a peer to make 4 range requests at once
+	peerServerRangeRequestsBurst = 4
+)
+
+func PayloadsByRangeProtocolID(l2ChainID *big.Int) protocol.ID {
+	return protocol.ID(fmt.Sprintf("/opstack/req/payloads_by_range/%d/0", l2ChainID))
+}
+
+type rangeRequestMsg struct {
+	Start    uint64
+	Count    uint64
+	MaxBytes uint64
+}
+
+// HandleRangeSyncRequest is a stream handler function to register the L2 unsafe payloads by range alt-sync protocol.
+// See MakeStreamHandler to transform this into a LibP2P handler function.
+func (srv *ReqRespServer) HandleRangeSyncRequest(ctx context.Context, log log.Logger, stream network.Stream) {
+	start := time.Now()
+
+	// We wait as long as necessary; we throttle the peer instead of disconnecting,
+	// unless the delay reaches a threshold that is unreasonable to wait for.
+	ctx, cancel := context.WithTimeout(ctx, maxThrottleDelay)
+	req, served, err := srv.handleRangeSyncRequest(ctx, stream)
+	cancel()
+
+	resultCode := ResultCodeSuccess
+	if err != nil && served == 0 {
+		log.Warn("failed to serve p2p range sync request", "start", req.Start, "count", req.Count, "err", err)
+		if errors.Is(err, ethereum.NotFound) {
+			resultCode = ResultCodeNotFoundErr
+		} else if errors.Is(err, invalidRequestErr) {
+			resultCode = ResultCodeInvalidErr
+		} else {
+			resultCode = ResultCodeUnknownErr
+		}
+		// try to write error code, so the other peer can understand the reason for failure.
+		_, _ = stream.Write([]byte{resultCode})
+	} else if err != nil {
+		log.Warn("partially served p2p range sync request", "start", req.Start, "count", req.Count, "served", served, "err", err)
+	} else {
+		log.Debug("successfully served range sync response", "start", req.Start, "count", req.Count, "served", served)
+	}
+	srv.metrics.ServerPayloadByNumberEvent(req.Start, resultCode, time.Since(start))
+}
+
+// handleRangeSyncRequest serves the request, and returns the number of payloads that were written to the stream.
+func (srv *ReqRespServer) handleRangeSyncRequest(ctx context.Context, stream network.Stream) (req rangeRequestMsg, served uint64, err error) {
+	peerId := stream.Conn().RemotePeer()
+
+	// take a token from the global rate-limiter,
+	// to make sure there's not too much concurrent server work between different peers.
+	if err := srv.globalRangeRequestsRL.Wait(ctx); err != nil {
+		return req, 0, fmt.Errorf("timed out waiting for global range sync rate limit: %w", err)
+	}
+
+	// find rate limiting data of peer, or add otherwise
+	srv.peerStatsLock.Lock()
+	ps, _ := srv.peerRateLimits.Get(peerId)
+	if ps == nil {
+		ps = newPeerStat()
+		srv.peerRateLimits.Add(peerId, ps)
+	}
+	srv.peerStatsLock.Unlock()
+	// Unlike single block requests, the limiter of a new peer has a full burst,
+	// so we can wait for it without holding the lock.
+	if err := ps.RangeRequests.Wait(ctx); err != nil {
+		return req, 0, fmt.Errorf("timed out waiting for peer range sync rate limit: %w", err)
+	}
+
+	// Set read deadline, if available
+	_ = stream.SetReadDeadline(time.Now().Add(serverReadRequestTimeout))
+
+	// Read the request
+	if err := binary.Read(stream, binary.LittleEndian, &req); err != nil {
+		return req, 0, fmt.Errorf("failed to read requested block range: %w", err)
+	}
+	if err := stream.CloseRead(); err != nil {
+		return req, 0, fmt.Errorf("failed to close reading-side of a P2P range sync request call: %w", err)
+	}
+
+	// Check the request is within the expected range of blocks
+	if req.Count == 0 || req.Count > maxRangeRequestBlocks {
+		return req, 0, fmt.Errorf("cannot serve request for %d L2 blocks, expected 1 to %d: %w", req.Count, maxRangeRequestBlocks, invalidRequestErr)
+	}
+	if req.MaxBytes == 0 {
+		return req, 0, fmt.Errorf("cannot serve request with a zero byte cap: %w", invalidRequestErr)
+	}
+	if req.Start < srv.cfg.Genesis.L2.Number {
+		return req, 0, fmt.Errorf("cannot serve request for L2 block %d before genesis %d: %w", req.Start, srv.cfg.Genesis.L2.Number, invalidRequestErr)
+	}
+	max, err := srv.cfg.TargetBlockNumber(uint64(time.Now().UnixMilli()))
+	if err != nil {
+		return req, 0, fmt.Errorf("cannot determine max target block number to verify request: %w", invalidRequestErr)
+	}
+	if req.Start > max {
+		return req, 0, fmt.Errorf("cannot serve request for L2 block %d after max expected block (%v): %w", req.Start, max, invalidRequestErr)
+	}
+	// the blocks draw from the block rate limits, a burst is the most a single response can wait for
+	count := min(req.Count, max-req.Start+1, peerServerBlocksBurst)
+	maxBytes := min(req.MaxBytes, maxRangeResponseBytes)
+
+	if err := srv.globalRequestsRL.WaitN(ctx, int(count)); err != nil {
+		return req, 0, fmt.Errorf("timed out waiting for global sync rate limit: %w", err)
+	}
+	if err := ps.Requests.WaitN(ctx, int(count)); err != nil {
+		return req, 0, fmt.Errorf("timed out waiting for peer sync rate limit: %w", err)
+	}
+
+	var w *snappy.Writer
+	var total uint64
+	for num := req.Start; num < req.Start+count; num++ {
+		envelope, err := srv.l2.PayloadByNumber(ctx, num)
+		if errors.Is(err, ethereum.NotFound) && served > 0 {
+			// serve the payloads we have
+			break
+		} else if err != nil {
+			if errors.Is(err, ethereum.NotFound) {
+				return req, served, fmt.Errorf("peer requested unknown block by number: %w", err)
+			}
+			return req, served, fmt.Errorf("failed to retrieve payload to serve to peer: %w", err)
+		}
+
+		var buf bytes.Buffer
+		version := uint32(0)
+		if srv.cfg.IsEcotone(uint64(envelope.ExecutionPayload.Timestamp)) {
+			version = 1
+			_, err = envelope.MarshalSSZ(&buf)
+		} else {
+			_, err = envelope.ExecutionPayload.MarshalSSZ(&buf)
+		}
+		if err != nil {
+			return req, served, fmt.Errorf("failed to encode payload %d: %w", num, err)
+		}
+		total += uint64(buf.Len())
+		if served > 0 && total > maxBytes {
+			break
+		}
+
+		// We set write deadline, if available, to safely write without blocking on a throttling peer connection
+		_ = stream.SetWriteDeadline(time.Now().Add(serverWriteChunkTimeout))
+		if w == nil {
+			// 0 - resultCode: success = 0
+			if _, err := stream.Write([]byte{ResultCodeSuccess}); err != nil {
+				return req, served, fmt.Errorf("failed to write response header data: %w", err)
+			}
+			w = snappy.NewBufferedWriter(stream)
+		}
+		// 0:4 - version (little endian)
+		// 4:8 - size (little endian)
+		var header [8]byte
+		binary.LittleEndian.PutUint32(header[:4], version)
+		binary.LittleEndian.PutUint32(header[4:], uint32(buf.Len()))
+		if _, err := w.Write(header[:]); err != nil {
+			return req, served, fmt.Errorf("failed to write payload header to range sync response: %w", err)
+		}
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return req, served, fmt.Errorf("failed to write payload to range sync response: %w", err)
+		}
+		served++
+	}
+	if err := w.Close(); err != nil {
+		return req, served, fmt.Errorf("failed to finishing writing payloads to range sync response: %w", err)
+	}
+	return req, served, nil
+}
+
+// doRangeRequest requests the blocks of the peer request over a stream of the payloads-by-range protocol.
+// It returns the size of the response payloads, and the number of blocks that were served from the bottom of the range.
+func (s *SyncClient) doRangeRequest(ctx context.Context, str network.Stream, id peer.ID, pr peerRequest) (int, uint64, error) {
+	start := pr.num - pr.count + 1
+	// set write timeout (if available)
+	_ = str.SetWriteDeadline(time.Now().Add(clientWriteRequestTimeout))
+	req := rangeRequestMsg{Start: start, Count: pr.count, MaxBytes: maxRangeResponseBytes}
+	if err := binary.Write(str, binary.LittleEndian, &req); err != nil {
+		return 0, 0, fmt.Errorf("failed to write range request (%d - %d): %w", start, pr.num, err)
+	}
+	if err := str.CloseWrite(); err != nil {
+		return 0, 0, fmt.Errorf("failed to close writer side while making range request: %w", err)
+	}
+
+	// set read timeout (if available)
+	_ = str.SetReadDeadline(time.Now().Add(clientReadResponsetimeout))
+
+	// Limit input, as well as output, the server may exceed the byte cap with a single payload.
+	r := io.LimitReader(str, maxRangeResponseBytes+maxGossipSize)
+	var result [1]byte
+	if _, err := io.ReadFull(r, result[:]); err != nil {
+		return 0, 0, fmt.Errorf("failed to read result part of range response: %w", err)
+	}
+	if res := result[0]; res != 0 {
+		return 0, 0, requestResultErr(res)
+	}
+	r = io.LimitReader(snappy.NewReader(r), maxRangeResponseBytes+maxGossipSize)
+
+	var envelopes []*eth.ExecutionPayloadEnvelope
+	size := 0
+	for num := start; ; num++ {
+		var header [8]byte
+		if _, err := io.ReadFull(r, header[:]); errors.Is(err, io.EOF) && len(envelopes) > 0 {
+			break
+		} else if err != nil {
+			return 0, 0, fmt.Errorf("failed to read payload header of range response: %w", err)
+		}
+		if num > pr.num {
+			return 0, 0, fmt.Errorf("range response has more payloads than the %d requested", pr.count)
+		}
+		version := binary.LittleEndian.Uint32(header[:4])
+		payloadSize := binary.LittleEndian.Uint32(header[4:])
+		if payloadSize > maxGossipSize {
+			return 0, 0, fmt.Errorf("payload %d in range response is too large: %d bytes", num, payloadSize)
+		}
+		data := make([]byte, payloadSize)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return 0, 0, fmt.Errorf("failed to read payload %d of range response: %w", num, err)
+		}
+		isCanyon := s.cfg.IsCanyon(s.cfg.MillisecondTimestampForBlock(num) / 1000)
+		envelope, err := readExecutionPayload(version, data, isCanyon)
+		if err != nil {
+			return 0, 0, err
+		}
+		if err := verifyBlock(envelope, num); err != nil {
+			return 0, 0, fmt.Errorf("received execution payload is invalid: %w", err)
+		}
+		if n := len(envelopes); n > 0 && envelope.ExecutionPayload.ParentHash != envelopes[n-1].ExecutionPayload.BlockHash {
+			return 0, 0, fmt.Errorf("received execution payload %s does not build on previous payload %s", envelope.ExecutionPayload.ID(), envelopes[n-1].ExecutionPayload.ID())
+		}
+		envelopes = append(envelopes, envelope)
+		size += len(data)
+	}
+	if err := str.CloseRead(); err != nil {
+		return 0, 0, fmt.Errorf("failed to close reading side")
+	}
+	for _, envelope := range envelopes {
+		select {
+		case s.results <- syncResult{payload: envelope, peer: id}:
+		case <-ctx.Done():
+			return 0, 0, fmt.Errorf("failed to process range response, sync client is too busy: %w", ctx.Err())
+		}
+	}
+	return size, uint64(len(envelopes)), nil
+}
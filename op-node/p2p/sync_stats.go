@@ -0,0 +1,200 @@
+package p2p
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// weight of the latest sample in the moving averages of the peer sync stats
+	syncStatsAlpha = 0.2
+	// number of successful requests before the stats of a peer are used to schedule requests
+	minSyncPeerSamples = 5
+	// maximum number of concurrent requests to a single peer that serves us reliably
+	maxPeerParallelRequests = 4
+	// a peer is considered slow if its latency exceeds that of the fastest peer by this factor
+	slowSyncPeerFactor = 2
+	// maximum time a slow peer waits before taking on a request, to leave it to faster peers
+	maxSyncPeerYield = time.Second
+	// slow peers only yield while the request queue is shallow: when it's deep, every peer is needed
+	syncPeerYieldQueueLen = 16
+)
+
+// PeerSyncStats summarizes how well a peer served our req-resp sync requests.
+type PeerSyncStats struct {
+	PeerID   peer.ID `json:"peerID"`
+	Requests uint64  `json:"requests"`
+	Errors   uint64  `json:"errors"`
+	// ErrorRate is the moving average of the fraction of failed requests.
+	ErrorRate float64 `json:"errorRate"`
+	// Latency is the moving average of the duration of successful requests.
+	Latency time.Duration `json:"latency"`
+	// Throughput is the moving average of the response size per second of successful requests.
+	Throughput float64 `json:"throughput"`
+	// Parallelism is the number of concurrent requests the peer is currently trusted with.
+	Parallelism int `json:"parallelism"`
+	// PayloadsByRange is true if the peer is known to serve the payloads-by-range protocol.
+	PayloadsByRange bool `json:"payloadsByRange"`
+}
+
+type syncPeerStat struct {
+	requests   uint64
+	errors     uint64
+	successes  uint64
+	errorRate  float64
+	latency    time.Duration
+	throughput float64
+	// rangeKnown is true once we negotiated a range request with the peer, rangeSupported holds the outcome
+	rangeKnown     bool
+	rangeSupported bool
+}
+
+// syncPeerStats tracks the latency, throughput and error rate of the peers we sync from,
+// to prefer faster peers and to adapt the number of concurrent requests per peer.
+type syncPeerStats struct {
+	mu    sync.Mutex
+	peers map[peer.ID]*syncPeerStat
+}
+
+func newSyncPeerStats() *syncPeerStats {
+	return &syncPeerStats{peers: make(map[peer.ID]*syncPeerStat)}
+}
+
+func ewma(avg float64, sample float64) float64 {
+	return avg*(1-syncStatsAlpha) + sample*syncStatsAlpha
+}
+
+func (p *syncPeerStats) add(id peer.ID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.peers[id]; !ok {
+		p.peers[id] = &syncPeerStat{}
+	}
+}
+
+func (p *syncPeerStats) remove(id peer.ID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.peers, id)
+}
+
+func (p *syncPeerStats) onSuccess(id peer.ID, latency time.Duration, size int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	st, ok := p.peers[id]
+	if !ok {
+		return
+	}
+	st.requests++
+	st.successes++
+	st.errorRate = ewma(st.errorRate, 0)
+	throughput := float64(size) / max(latency.Seconds(), 0.001)
+	if st.successes == 1 {
+		st.latency = latency
+		st.throughput = throughput
+	} else {
+		st.latency = time.Duration(ewma(float64(st.latency), float64(latency)))
+		st.throughput = ewma(st.throughput, throughput)
+	}
+}
+
+// setPayloadsByRange records whether the peer serves the payloads-by-range protocol.
+func (p *syncPeerStats) setPayloadsByRange(id peer.ID, supported bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if st, ok := p.peers[id]; ok {
+		st.rangeKnown = true
+		st.rangeSupported = supported
+	}
+}
+
+// maybePayloadsByRange returns false if the peer is known to not serve the payloads-by-range protocol.
+func (p *syncPeerStats) maybePayloadsByRange(id peer.ID) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	st, ok := p.peers[id]
+	return !ok || !st.rangeKnown || st.rangeSupported
+}
+
+func (p *syncPeerStats) onError(id peer.ID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	st, ok := p.peers[id]
+	if !ok {
+		return
+	}
+	st.requests++
+	st.errors++
+	st.errorRate = ewma(st.errorRate, 1)
+}
+
+// parallelism returns the number of concurrent requests the peer is trusted with.
+// Unknown and unreliable peers get a single request at a time.
+func (p *syncPeerStats) parallelism(id peer.ID) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.parallelismLocked(p.peers[id])
+}
+
+func (p *syncPeerStats) parallelismLocked(st *syncPeerStat) int {
+	if st == nil || st.successes < minSyncPeerSamples {
+		return 1
+	}
+	switch {
+	case st.errorRate < 0.05:
+		return maxPeerParallelRequests
+	case st.errorRate < 0.2:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// yieldDelay returns how long the peer should wait before taking on a new request,
+// so that faster and more reliable peers get to serve it first. Peers without enough samples don't wait,
+// so they get a chance to be measured.
+func (p *syncPeerStats) yieldDelay(id peer.ID) time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	st, ok := p.peers[id]
+	if !ok || st.successes < minSyncPeerSamples {
+		return 0
+	}
+	if st.errorRate >= 0.5 {
+		return maxSyncPeerYield
+	}
+	var fastest time.Duration
+	for _, other := range p.peers {
+		if other.successes >= minSyncPeerSamples && (fastest == 0 || other.latency < fastest) {
+			fastest = other.latency
+		}
+	}
+	if st.latency <= fastest*slowSyncPeerFactor {
+		return 0
+	}
+	return min(st.latency-fastest, maxSyncPeerYield)
+}
+
+// snapshot returns the stats of all peers, ordered by peer ID.
+func (p *syncPeerStats) snapshot() []PeerSyncStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	out := make([]PeerSyncStats, 0, len(p.peers))
+	for id, st := range p.peers {
+		out = append(out, PeerSyncStats{
+			PeerID:          id,
+			Requests:        st.requests,
+			Errors:          st.errors,
+			ErrorRate:       st.errorRate,
+			Latency:         st.latency,
+			Throughput:      st.throughput,
+			Parallelism:     p.parallelismLocked(st),
+			PayloadsByRange: st.rangeSupported,
+		})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].PeerID < out[j].PeerID })
+	return out
+}
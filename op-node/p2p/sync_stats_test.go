@@ -0,0 +1,58 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncPeerStats(t *testing.T) {
+	stats := newSyncPeerStats()
+	fast, slow, flaky := peer.ID("a"), peer.ID("b"), peer.ID("c")
+	stats.add(fast)
+	stats.add(slow)
+	stats.add(flaky)
+
+	// Unknown peers get a single request and don't yield, so they get measured
+	require.Equal(t, 1, stats.parallelism(fast))
+	require.Zero(t, stats.yieldDelay(slow))
+
+	for i := 0; i < minSyncPeerSamples; i++ {
+		stats.onSuccess(fast, 100*time.Millisecond, 1000)
+		stats.onSuccess(slow, 500*time.Millisecond, 1000)
+		stats.onSuccess(flaky, 100*time.Millisecond, 1000)
+	}
+	for i := 0; i < minSyncPeerSamples; i++ {
+		stats.onError(flaky)
+	}
+
+	require.Equal(t, maxPeerParallelRequests, stats.parallelism(fast))
+	require.Equal(t, maxPeerParallelRequests, stats.parallelism(slow))
+	require.Equal(t, 1, stats.parallelism(flaky))
+
+	require.Zero(t, stats.yieldDelay(fast))
+	require.Equal(t, 400*time.Millisecond, stats.yieldDelay(slow))
+	require.Equal(t, maxSyncPeerYield, stats.yieldDelay(flaky))
+
+	snapshot := stats.snapshot()
+	require.Len(t, snapshot, 3)
+	require.Equal(t, PeerSyncStats{
+		PeerID:      fast,
+		Requests:    minSyncPeerSamples,
+		Latency:     100 * time.Millisecond,
+		Throughput:  10000,
+		Parallelism: maxPeerParallelRequests,
+	}, snapshot[0])
+	require.Equal(t, slow, snapshot[1].PeerID)
+	require.Equal(t, flaky, snapshot[2].PeerID)
+	require.Equal(t, uint64(2*minSyncPeerSamples), snapshot[2].Requests)
+	require.Equal(t, uint64(minSyncPeerSamples), snapshot[2].Errors)
+
+	// Stats of removed peers are dropped, and not recorded anymore
+	stats.remove(fast)
+	stats.onSuccess(fast, time.Millisecond, 1000)
+	require.Len(t, stats.snapshot(), 2)
+	require.Zero(t, stats.yieldDelay(fast))
+}
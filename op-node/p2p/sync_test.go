@@ -2,6 +2,7 @@ package p2p
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"strings"
@@ -12,8 +13,10 @@ import (
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
 	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
@@ -191,6 +194,96 @@ func TestSinglePeerSync(t *testing.T) {
 	}
 }
 
+func TestSinglePeerRangeSync(t *testing.T) {
+	t.Parallel() // Takes a while, but can run in parallel
+
+	log := testlog.Logger(t, log.LevelError)
+
+	cfg, payloads := setupSyncTestData(100)
+
+	// Serving payloads: just load them from the map, if they exist
+	servePayload := mockPayloadFn(func(n uint64) (*eth.ExecutionPayloadEnvelope, error) {
+		p, ok := payloads.getPayload(n)
+		if !ok {
+			return nil, ethereum.NotFound
+		}
+		return p, nil
+	})
+
+	// collect received payloads in a buffered channel, so we can verify we get everything
+	received := make(chan *eth.ExecutionPayloadEnvelope, 100)
+	receivePayload := receivePayloadFn(func(ctx context.Context, from peer.ID, payload *eth.ExecutionPayloadEnvelope) error {
+		received <- payload
+		return nil
+	})
+
+	// Setup 2 minimal test hosts to attach the sync protocol to
+	mnet, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err, "failed to setup mocknet")
+	defer mnet.Close()
+	hosts := mnet.Hosts()
+	hostA, hostB := hosts[0], hosts[1]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Setup host A as the server, serving both protocols
+	srv := NewReqRespServer(cfg, servePayload, metrics.NoopMetrics)
+	payloadByNumber := MakeStreamHandler(ctx, log.New("role", "server"), srv.HandleSyncRequest)
+	hostA.SetStreamHandler(PayloadByNumberProtocolID(cfg.L2ChainID), payloadByNumber)
+	payloadsByRange := MakeStreamHandler(ctx, log.New("role", "server"), srv.HandleRangeSyncRequest)
+	hostA.SetStreamHandler(PayloadsByRangeProtocolID(cfg.L2ChainID), payloadsByRange)
+
+	// Setup host B as the client
+	cl := NewSyncClient(log.New("role", "client"), cfg, hostB.NewStream, receivePayload, metrics.NoopMetrics, &NoopApplicationScorer{})
+	cl.AddPeer(hostA.ID())
+	cl.Start()
+	defer cl.Close()
+
+	// request to start syncing between 10 and 60, more blocks than fit in a single range request
+	_, err = cl.RequestL2Range(ctx, payloads.getBlockRef(10), payloads.getBlockRef(60))
+	require.NoError(t, err)
+
+	// ranges are served in ascending order, so collect everything before checking
+	got := make(map[uint64]common.Hash)
+	for len(got) < 49 {
+		select {
+		case p := <-received:
+			got[uint64(p.ExecutionPayload.BlockNumber)] = p.ExecutionPayload.BlockHash
+		case <-time.After(30 * time.Second):
+			t.Fatalf("timed out waiting for payloads, got %d", len(got))
+		}
+	}
+	for i := uint64(11); i < 60; i++ {
+		exp, ok := payloads.getPayload(i)
+		require.True(t, ok, "expecting known payload")
+		require.Equal(t, exp.ExecutionPayload.BlockHash, got[i], "expecting the correct payload")
+	}
+
+	stats := cl.PeerSyncStats()
+	require.Len(t, stats, 1)
+	require.True(t, stats[0].PayloadsByRange, "expecting peer to serve payloads by range")
+}
+
+func TestRangeRequestRateLimits(t *testing.T) {
+	cfg, _ := setupSyncTestData(1)
+	newStream := func(ctx context.Context, peerId peer.ID, protocolId ...protocol.ID) (network.Stream, error) {
+		return nil, errors.New("no stream")
+	}
+	receivePayload := receivePayloadFn(func(ctx context.Context, from peer.ID, payload *eth.ExecutionPayloadEnvelope) error {
+		return nil
+	})
+	cl := NewSyncClient(testlog.Logger(t, log.LevelError), cfg, newStream, receivePayload, metrics.NoopMetrics, &NoopApplicationScorer{})
+	rl := rate.NewLimiter(peerServerBlocksRateLimit, peerServerBlocksBurst)
+	rangeRL := rate.NewLimiter(peerServerRangeRequestsRateLimit, peerServerRangeRequestsBurst)
+
+	// the peer loop charged the first block, a range request charges the rest like the server does
+	_, _, err := cl.doRequest(context.Background(), peer.ID("a"), rl, rangeRL, peerRequest{num: 20, count: rangeRequestBlocks})
+	require.ErrorContains(t, err, "no stream")
+	require.InDelta(t, peerServerBlocksBurst-rangeRequestBlocks+1, rl.Tokens(), 0.5)
+	require.InDelta(t, globalServerBlocksBurst-rangeRequestBlocks+1, cl.globalRL.Tokens(), 0.5)
+}
+
 func TestMultiPeerSync(t *testing.T) {
 	t.Parallel() // Takes a while, but can run in parallel
 
@@ -41,6 +41,8 @@ type AttributesQueue struct {
 	prev         *BatchQueue
 	batch        *SingularBatch
 	isLastInSpan bool
+
+	events DerivationEventListener
 }
 
 func NewAttributesQueue(log log.Logger, cfg *rollup.Config, builder AttributesBuilder, prev *BatchQueue) *AttributesQueue {
@@ -49,6 +51,7 @@ func NewAttributesQueue(log log.Logger, cfg *rollup.Config, builder AttributesBu
 		config:  cfg,
 		builder: builder,
 		prev:    prev,
+		events:  DerivationEventsDisabled,
 	}
 }
 
@@ -56,6 +59,11 @@ func (aq *AttributesQueue) Origin() eth.L1BlockRef {
 	return aq.prev.Origin()
 }
 
+// SetDerivationEvents sets the listener of the attributes produced by the attributes queue.
+func (aq *AttributesQueue) SetDerivationEvents(events DerivationEventListener) {
+	aq.events = events
+}
+
 func (aq *AttributesQueue) NextAttributes(ctx context.Context, parent eth.L2BlockRef) (*AttributesWithParent, error) {
 	// Get a batch if we need it
 	if aq.batch == nil {
@@ -73,6 +81,19 @@ func (aq *AttributesQueue) NextAttributes(ctx context.Context, parent eth.L2Bloc
 	} else {
 		// Clear out the local state once we will succeed
 		attr := AttributesWithParent{attrs, parent, aq.isLastInSpan}
+		if aq.events.Enabled() {
+			aq.events.OnDerivationEvent(DerivationEvent{
+				Type:   AttributesEvent,
+				Origin: aq.Origin().ID(),
+				Attributes: &AttributesEventInfo{
+					Parent:       parent.ID(),
+					Timestamp:    aq.batch.Timestamp,
+					Epoch:        aq.batch.Epoch(),
+					Txs:          len(attrs.Transactions),
+					IsLastInSpan: aq.isLastInSpan,
+				},
+			})
+		}
 		aq.batch = nil
 		aq.isLastInSpan = false
 		return &attr, nil
@@ -60,6 +60,8 @@ type BatchQueue struct {
 	nextSpan []*SingularBatch
 
 	l2 SafeBlockFetcher
+
+	events DerivationEventListener
 }
 
 // NewBatchQueue creates a BatchQueue, which should be Reset(origin) before use.
@@ -69,6 +71,7 @@ func NewBatchQueue(log log.Logger, cfg *rollup.Config, prev NextBatchProvider, l
 		config: cfg,
 		prev:   prev,
 		l2:     l2,
+		events: DerivationEventsDisabled,
 	}
 }
 
@@ -76,6 +79,11 @@ func (bq *BatchQueue) Origin() eth.L1BlockRef {
 	return bq.prev.Origin()
 }
 
+// SetDerivationEvents sets the listener of the batch checks of the batch queue.
+func (bq *BatchQueue) SetDerivationEvents(events DerivationEventListener) {
+	bq.events = events
+}
+
 // popNextBatch pops the next batch from the current queued up span-batch nextSpan.
 // The queue must be non-empty, or the function will panic.
 func (bq *BatchQueue) popNextBatch(parent eth.L2BlockRef) *SingularBatch {
@@ -228,7 +236,7 @@ func (bq *BatchQueue) AddBatch(ctx context.Context, batch Batch, parent eth.L2Bl
 		L1InclusionBlock: bq.origin,
 		Batch:            batch,
 	}
-	validity := CheckBatch(ctx, bq.config, bq.log, bq.l1Blocks, parent, &data, bq.l2)
+	validity := bq.checkBatch(ctx, bq.log, parent, &data)
 	if validity == BatchDrop {
 		return // if we do drop the batch, CheckBatch will log the drop reason with WARN level.
 	}
@@ -236,6 +244,32 @@ func (bq *BatchQueue) AddBatch(ctx context.Context, batch Batch, parent eth.L2Bl
 	bq.batches = append(bq.batches, &data)
 }
 
+// checkBatch checks the batch with CheckBatch, and emits the resulting validity along with its reason.
+func (bq *BatchQueue) checkBatch(ctx context.Context, log log.Logger, parent eth.L2BlockRef, batch *BatchWithL1InclusionBlock) BatchValidity {
+	validity, reason := CheckBatch(ctx, bq.config, log, bq.l1Blocks, parent, batch, bq.l2)
+	if !bq.events.Enabled() {
+		return validity
+	}
+	info := &BatchEventInfo{
+		BatchType:        batch.GetBatchType(),
+		Timestamp:        batch.GetTimestamp(),
+		BlockCount:       1,
+		L1InclusionBlock: batch.L1InclusionBlock.ID(),
+		Parent:           parent.ID(),
+		Validity:         validity.String(),
+	}
+	if spanBatch, ok := batch.AsSpanBatch(); ok {
+		info.BlockCount = spanBatch.GetBlockCount()
+	}
+	bq.events.OnDerivationEvent(DerivationEvent{
+		Type:   BatchCheckedEvent,
+		Origin: bq.origin.ID(),
+		Reason: reason,
+		Batch:  info,
+	})
+	return validity
+}
+
 // deriveNextBatch derives the next batch to apply on top of the current L2 safe head,
 // following the validity rules imposed on consecutive batches,
 // based on currently available buffered batch and L1 origin information.
@@ -265,7 +299,7 @@ func (bq *BatchQueue) deriveNextBatch(ctx context.Context, outOfData bool, paren
 	var remaining []*BatchWithL1InclusionBlock
 batchLoop:
 	for i, batch := range bq.batches {
-		validity := CheckBatch(ctx, bq.config, bq.log.New("batch_index", i), bq.l1Blocks, parent, batch, bq.l2)
+		validity := bq.checkBatch(ctx, bq.log.New("batch_index", i), parent, batch)
 		switch validity {
 		case BatchFuture:
 			remaining = append(remaining, batch)
@@ -3,6 +3,7 @@ package derive
 import (
 	"bytes"
 	"context"
+	"fmt"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -28,73 +29,86 @@ const (
 	BatchFuture
 )
 
+func (v BatchValidity) String() string {
+	switch v {
+	case BatchDrop:
+		return "drop"
+	case BatchAccept:
+		return "accept"
+	case BatchUndecided:
+		return "undecided"
+	case BatchFuture:
+		return "future"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(v))
+	}
+}
+
+// batchValidity logs the reason of a batch validity with the given log function, and returns both.
+func batchValidity(validity BatchValidity, logFn func(msg string, ctx ...any), reason string, ctx ...any) (BatchValidity, string) {
+	logFn(reason, ctx...)
+	return validity, reason
+}
+
 // CheckBatch checks if the given batch can be applied on top of the given l2SafeHead, given the contextual L1 blocks the batch was included in.
 // The first entry of the l1Blocks should match the origin of the l2SafeHead. One or more consecutive l1Blocks should be provided.
 // In case of only a single L1 block, the decision whether a batch is valid may have to stay undecided.
+// Along with the validity, it returns the reason why the batch is not accepted, which is empty for accepted batches.
 func CheckBatch(ctx context.Context, cfg *rollup.Config, log log.Logger, l1Blocks []eth.L1BlockRef,
 	l2SafeHead eth.L2BlockRef, batch *BatchWithL1InclusionBlock, l2Fetcher SafeBlockFetcher,
-) BatchValidity {
+) (BatchValidity, string) {
 	switch typ := batch.GetBatchType(); typ {
 	case SingularBatchType:
 		singularBatch, ok := batch.AsSingularBatch()
 		if !ok {
-			log.Error("failed type assertion to SingularBatch")
-			return BatchDrop
+			return batchValidity(BatchDrop, log.Error, "failed type assertion to SingularBatch")
 		}
 		return checkSingularBatch(cfg, log, l1Blocks, l2SafeHead, singularBatch, batch.L1InclusionBlock)
 	case SpanBatchType:
 		spanBatch, ok := batch.AsSpanBatch()
 		if !ok {
-			log.Error("failed type assertion to SpanBatch")
-			return BatchDrop
+			return batchValidity(BatchDrop, log.Error, "failed type assertion to SpanBatch")
 		}
 		return checkSpanBatch(ctx, cfg, log, l1Blocks, l2SafeHead, spanBatch, batch.L1InclusionBlock, l2Fetcher)
 	default:
-		log.Warn("Unrecognized batch type: %d", typ)
-		return BatchDrop
+		return batchValidity(BatchDrop, log.Warn, "Unrecognized batch type", "type", typ)
 	}
 }
 
 // checkSingularBatch implements SingularBatch validation rule.
-func checkSingularBatch(cfg *rollup.Config, log log.Logger, l1Blocks []eth.L1BlockRef, l2SafeHead eth.L2BlockRef, batch *SingularBatch, l1InclusionBlock eth.L1BlockRef) BatchValidity {
+func checkSingularBatch(cfg *rollup.Config, log log.Logger, l1Blocks []eth.L1BlockRef, l2SafeHead eth.L2BlockRef, batch *SingularBatch, l1InclusionBlock eth.L1BlockRef) (BatchValidity, string) {
 	// add details to the log
 	log = batch.LogContext(log)
 
 	// sanity check we have consistent inputs
 	if len(l1Blocks) == 0 {
-		log.Warn("missing L1 block input, cannot proceed with batch checking")
-		return BatchUndecided
+		return batchValidity(BatchUndecided, log.Warn, "missing L1 block input, cannot proceed with batch checking")
 	}
 	epoch := l1Blocks[0]
 
 	nextMilliTimestamp := cfg.NextMillisecondBlockTime(l2SafeHead.MillisecondTimestamp())
 	if batch.Timestamp > nextMilliTimestamp {
-		log.Trace("received out-of-order batch for future processing after next batch", "next_timestamp", nextMilliTimestamp)
-		return BatchFuture
+		return batchValidity(BatchFuture, log.Trace, "received out-of-order batch for future processing after next batch", "next_timestamp", nextMilliTimestamp)
 	}
 	if batch.Timestamp < nextMilliTimestamp {
-		log.Warn("dropping batch with old timestamp", "batch_timestamp", batch.Timestamp, "min_timestamp", nextMilliTimestamp)
-		return BatchDrop
+		return batchValidity(BatchDrop, log.Warn, "dropping batch with old timestamp", "batch_timestamp", batch.Timestamp, "min_timestamp", nextMilliTimestamp)
 	}
 
 	// dependent on above timestamp check. If the timestamp is correct, then it must build on top of the safe head.
 	if batch.ParentHash != l2SafeHead.Hash {
-		log.Warn("ignoring batch with mismatching parent hash", "current_safe_head", l2SafeHead.Hash)
-		return BatchDrop
+		return batchValidity(BatchDrop, log.Warn, "ignoring batch with mismatching parent hash", "current_safe_head", l2SafeHead.Hash)
 	}
 
 	// Filter out batches that were included too late.
 	if uint64(batch.EpochNum)+cfg.SeqWindowSize < l1InclusionBlock.Number {
-		log.Warn("batch was included too late, sequence window expired")
-		return BatchDrop
+		return batchValidity(BatchDrop, log.Warn, "batch was included too late, sequence window expired")
 	}
 
 	// Check the L1 origin of the batch
 	batchOrigin := epoch
 	if uint64(batch.EpochNum) < epoch.Number {
-		log.Warn("dropped batch, epoch is too old", "minimum", epoch.ID())
 		// batch epoch too old
-		return BatchDrop
+		return batchValidity(BatchDrop, log.Warn, "dropped batch, epoch is too old", "minimum", epoch.ID())
 	} else if uint64(batch.EpochNum) == epoch.Number {
 		// Batch is sticking to the current epoch, continue.
 	} else if uint64(batch.EpochNum) == epoch.Number+1 {
@@ -104,23 +118,19 @@ func checkSingularBatch(cfg *rollup.Config, log log.Logger, l1Blocks []eth.L1Blo
 		// more information otherwise the eager algorithm may diverge from a non-eager
 		// algorithm.
 		if len(l1Blocks) < 2 {
-			log.Info("eager batch wants to advance epoch, but could not without more L1 blocks", "current_epoch", epoch.ID())
-			return BatchUndecided
+			return batchValidity(BatchUndecided, log.Info, "eager batch wants to advance epoch, but could not without more L1 blocks", "current_epoch", epoch.ID())
 		}
 		batchOrigin = l1Blocks[1]
 	} else {
-		log.Warn("batch is for future epoch too far ahead, while it has the next timestamp, so it must be invalid", "current_epoch", epoch.ID())
-		return BatchDrop
+		return batchValidity(BatchDrop, log.Warn, "batch is for future epoch too far ahead, while it has the next timestamp, so it must be invalid", "current_epoch", epoch.ID())
 	}
 
 	if batch.EpochHash != batchOrigin.Hash {
-		log.Warn("batch is for different L1 chain, epoch hash does not match", "expected", batchOrigin.ID())
-		return BatchDrop
+		return batchValidity(BatchDrop, log.Warn, "batch is for different L1 chain, epoch hash does not match", "expected", batchOrigin.ID())
 	}
 
 	if batch.Timestamp < batchOrigin.MillisecondTimestamp() {
-		log.Warn("batch timestamp is less than L1 origin timestamp", "l2_ms_timestamp", batch.Timestamp, "l1_ms_timestamp", batchOrigin.MillisecondTimestamp(), "origin", batchOrigin.ID())
-		return BatchDrop
+		return batchValidity(BatchDrop, log.Warn, "batch timestamp is less than L1 origin timestamp", "l2_ms_timestamp", batch.Timestamp, "l1_ms_timestamp", batchOrigin.MillisecondTimestamp(), "origin", batchOrigin.ID())
 	}
 
 	spec := rollup.NewChainSpec(cfg)
@@ -132,13 +142,11 @@ func checkSingularBatch(cfg *rollup.Config, log log.Logger, l1Blocks []eth.L1Blo
 			// We only check batches that do not advance the epoch, to ensure epoch advancement regardless of time drift is allowed.
 			if epoch.Number == batchOrigin.Number {
 				if len(l1Blocks) < 2 {
-					log.Info("without the next L1 origin we cannot determine yet if this empty batch that exceeds the time drift is still valid")
-					return BatchUndecided
+					return batchValidity(BatchUndecided, log.Info, "without the next L1 origin we cannot determine yet if this empty batch that exceeds the time drift is still valid")
 				}
 				nextOrigin := l1Blocks[1]
 				if batch.Timestamp >= nextOrigin.MillisecondTimestamp() { // check if the next L1 origin could have been adopted
-					log.Info("batch exceeded sequencer time drift without adopting next origin, and next L1 origin would have been valid")
-					return BatchDrop
+					return batchValidity(BatchDrop, log.Info, "batch exceeded sequencer time drift without adopting next origin, and next L1 origin would have been valid")
 				} else {
 					log.Info("continuing with empty batch before late L1 block to preserve L2 time invariant")
 				}
@@ -146,37 +154,33 @@ func checkSingularBatch(cfg *rollup.Config, log log.Logger, l1Blocks []eth.L1Blo
 		} else {
 			// If the sequencer is ignoring the time drift rule, then drop the batch and force an empty batch instead,
 			// as the sequencer is not allowed to include anything past this point without moving to the next epoch.
-			log.Warn("batch exceeded sequencer time drift, sequencer must adopt new L1 origin to include transactions again", "max_time", max)
-			return BatchDrop
+			return batchValidity(BatchDrop, log.Warn, "batch exceeded sequencer time drift, sequencer must adopt new L1 origin to include transactions again", "max_time", max)
 		}
 	}
 
 	// We can do this check earlier, but it's a more intensive one, so we do this last.
 	for i, txBytes := range batch.Transactions {
 		if len(txBytes) == 0 {
-			log.Warn("transaction data must not be empty, but found empty tx", "tx_index", i)
-			return BatchDrop
+			return batchValidity(BatchDrop, log.Warn, "transaction data must not be empty, but found empty tx", "tx_index", i)
 		}
 		if txBytes[0] == types.DepositTxType {
-			log.Warn("sequencers may not embed any deposits into batch data, but found tx that has one", "tx_index", i)
-			return BatchDrop
+			return batchValidity(BatchDrop, log.Warn, "sequencers may not embed any deposits into batch data, but found tx that has one", "tx_index", i)
 		}
 	}
 
-	return BatchAccept
+	return BatchAccept, ""
 }
 
 // checkSpanBatch implements SpanBatch validation rule.
 func checkSpanBatch(ctx context.Context, cfg *rollup.Config, log log.Logger, l1Blocks []eth.L1BlockRef, l2SafeHead eth.L2BlockRef,
 	batch *SpanBatch, l1InclusionBlock eth.L1BlockRef, l2Fetcher SafeBlockFetcher,
-) BatchValidity {
+) (BatchValidity, string) {
 	// add details to the log
 	log = batch.LogContext(log)
 
 	// sanity check we have consistent inputs
 	if len(l1Blocks) == 0 {
-		log.Warn("missing L1 block input, cannot proceed with batch checking")
-		return BatchUndecided
+		return batchValidity(BatchUndecided, log.Warn, "missing L1 block input, cannot proceed with batch checking")
 	}
 	epoch := l1Blocks[0]
 
@@ -184,29 +188,25 @@ func checkSpanBatch(ctx context.Context, cfg *rollup.Config, log log.Logger, l1B
 	batchOrigin := epoch
 	if startEpochNum == batchOrigin.Number+1 {
 		if len(l1Blocks) < 2 {
-			log.Info("eager batch wants to advance epoch, but could not without more L1 blocks", "current_epoch", epoch.ID())
-			return BatchUndecided
+			return batchValidity(BatchUndecided, log.Info, "eager batch wants to advance epoch, but could not without more L1 blocks", "current_epoch", epoch.ID())
 		}
 		batchOrigin = l1Blocks[1]
 	}
 	if !cfg.IsDelta(batchOrigin.Time) {
-		log.Warn("received SpanBatch with L1 origin before Delta hard fork", "l1_origin", batchOrigin.ID(), "l1_origin_time", batchOrigin.Time)
-		return BatchDrop
+		return batchValidity(BatchDrop, log.Warn, "received SpanBatch with L1 origin before Delta hard fork", "l1_origin", batchOrigin.ID(), "l1_origin_time", batchOrigin.Time)
 	}
 
 	nextMilliTimestamp := cfg.NextMillisecondBlockTime(l2SafeHead.MillisecondTimestamp())
 
 	if batch.GetTimestamp() > nextMilliTimestamp {
-		log.Trace("received out-of-order batch for future processing after next batch",
+		return batchValidity(BatchFuture, log.Trace, "received out-of-order batch for future processing after next batch",
 			"first_ms_timestamp_of_spanbatch", batch.GetTimestamp(),
 			"next_ms_timestamp", nextMilliTimestamp)
-		return BatchFuture
 	}
 	if batch.GetBlockTimestamp(batch.GetBlockCount()-1) < nextMilliTimestamp {
-		log.Warn("span batch has no new blocks after safe head",
+		return batchValidity(BatchDrop, log.Warn, "span batch has no new blocks after safe head",
 			"last_ms_timestamp_of_spanbatch", batch.GetBlockTimestamp(batch.GetBlockCount()-1),
 			"next_ms_timestamp", nextMilliTimestamp)
-		return BatchDrop
 	}
 
 	// finding parent block of the span batch.
@@ -216,52 +216,44 @@ func checkSpanBatch(ctx context.Context, cfg *rollup.Config, log log.Logger, l1B
 	if batch.GetTimestamp() < nextMilliTimestamp {
 		if batch.GetTimestamp() > l2SafeHead.MillisecondTimestamp() {
 			// batch timestamp cannot be between safe head and next timestamp
-			log.Warn("batch has misaligned timestamp, block time is too short", "batch_timestamp", batch.GetTimestamp(), "next_timestamp", nextMilliTimestamp, "l2_safe_head_timestamp", l2SafeHead.MillisecondTimestamp(), "parent_block", parentBlock)
-			return BatchDrop
+			return batchValidity(BatchDrop, log.Warn, "batch has misaligned timestamp, block time is too short", "batch_timestamp", batch.GetTimestamp(), "next_timestamp", nextMilliTimestamp, "l2_safe_head_timestamp", l2SafeHead.MillisecondTimestamp(), "parent_block", parentBlock)
 		}
 
 		milliSecondsDistance := l2SafeHead.MillisecondTimestamp() - batch.GetTimestamp()
 		if !cfg.IsFourier(l2SafeHead.MillisecondTimestamp() / 1000) {
 			if milliSecondsDistance%rollup.MillisecondBlockIntervalVolta != 0 {
-				log.Warn("batch has misaligned timestamp, not overlapped exactly", "batch_timestamp", batch.GetTimestamp(), "next_timestamp", nextMilliTimestamp, "l2_safe_head_timestamp", l2SafeHead.MillisecondTimestamp(), "parent_block", parentBlock)
-				return BatchDrop
+				return batchValidity(BatchDrop, log.Warn, "batch has misaligned timestamp, not overlapped exactly", "batch_timestamp", batch.GetTimestamp(), "next_timestamp", nextMilliTimestamp, "l2_safe_head_timestamp", l2SafeHead.MillisecondTimestamp(), "parent_block", parentBlock)
 			}
 		} else {
 			// block interval has changed after fourier fork
 			if milliSecondsDistance%rollup.MillisecondBlockIntervalFourier != 0 {
-				log.Warn("batch has misaligned timestamp after fourier fork, not overlapped exactly", "batch_timestamp", batch.GetTimestamp(), "next_timestamp", nextMilliTimestamp, "l2_safe_head_timestamp", l2SafeHead.MillisecondTimestamp(), "parent_block", parentBlock)
-				return BatchDrop
+				return batchValidity(BatchDrop, log.Warn, "batch has misaligned timestamp after fourier fork, not overlapped exactly", "batch_timestamp", batch.GetTimestamp(), "next_timestamp", nextMilliTimestamp, "l2_safe_head_timestamp", l2SafeHead.MillisecondTimestamp(), "parent_block", parentBlock)
 			}
 		}
 		currentNum, err := cfg.TargetBlockNumber(batch.GetTimestamp())
 		if err != nil {
-			log.Warn("failed to computer batch number", "batch_ms_time", batch.GetTimestamp(), "err", err, "parent_block", parentBlock)
 			// unable to validate the batch for now. retry later.
-			return BatchUndecided
+			return batchValidity(BatchUndecided, log.Warn, "failed to computer batch number", "batch_ms_time", batch.GetTimestamp(), "err", err, "parent_block", parentBlock)
 		}
 		parentNum = currentNum - 1
 		parentBlock, err = l2Fetcher.L2BlockRefByNumber(ctx, parentNum)
 		if err != nil {
-			log.Warn("failed to fetch L2 block", "number", parentNum, "err", err, "parent_block", parentBlock)
 			// unable to validate the batch for now. retry later.
-			return BatchUndecided
+			return batchValidity(BatchUndecided, log.Warn, "failed to fetch L2 block", "number", parentNum, "err", err, "parent_block", parentBlock)
 		}
 	}
 	if !batch.CheckParentHash(parentBlock.Hash) {
-		log.Warn("ignoring batch with mismatching parent hash", "parent_block", parentBlock, "l2_safe_head", l2SafeHead)
-		return BatchDrop
+		return batchValidity(BatchDrop, log.Warn, "ignoring batch with mismatching parent hash", "parent_block", parentBlock, "l2_safe_head", l2SafeHead)
 	}
 
 	// Filter out batches that were included too late.
 	if startEpochNum+cfg.SeqWindowSize < l1InclusionBlock.Number {
-		log.Warn("batch was included too late, sequence window expired")
-		return BatchDrop
+		return batchValidity(BatchDrop, log.Warn, "batch was included too late, sequence window expired")
 	}
 
 	// Check the L1 origin of the batch
 	if startEpochNum > parentBlock.L1Origin.Number+1 {
-		log.Warn("batch is for future epoch too far ahead, while it has the next timestamp, so it must be invalid", "current_epoch", epoch.ID())
-		return BatchDrop
+		return batchValidity(BatchDrop, log.Warn, "batch is for future epoch too far ahead, while it has the next timestamp, so it must be invalid", "current_epoch", epoch.ID())
 	}
 
 	endEpochNum := batch.GetBlockEpochNum(batch.GetBlockCount() - 1)
@@ -270,21 +262,18 @@ func checkSpanBatch(ctx context.Context, cfg *rollup.Config, log log.Logger, l1B
 	for _, l1Block := range l1Blocks {
 		if l1Block.Number == endEpochNum {
 			if !batch.CheckOriginHash(l1Block.Hash) {
-				log.Warn("batch is for different L1 chain, epoch hash does not match", "expected", l1Block.Hash)
-				return BatchDrop
+				return batchValidity(BatchDrop, log.Warn, "batch is for different L1 chain, epoch hash does not match", "expected", l1Block.Hash)
 			}
 			originChecked = true
 			break
 		}
 	}
 	if !originChecked {
-		log.Info("need more l1 blocks to check entire origins of span batch")
-		return BatchUndecided
+		return batchValidity(BatchUndecided, log.Info, "need more l1 blocks to check entire origins of span batch")
 	}
 
 	if startEpochNum < parentBlock.L1Origin.Number {
-		log.Warn("dropped batch, epoch is too old", "minimum", parentBlock.ID())
-		return BatchDrop
+		return batchValidity(BatchDrop, log.Warn, "dropped batch, epoch is too old", "minimum", parentBlock.ID())
 	}
 
 	originIdx := 0
@@ -310,8 +299,7 @@ func checkSpanBatch(ctx context.Context, cfg *rollup.Config, log log.Logger, l1B
 		}
 		blockTimestamp := batch.GetBlockTimestamp(i)
 		if blockTimestamp < l1Origin.MillisecondTimestamp() {
-			log.Warn("block timestamp is less than L1 origin timestamp", "l2_timestamp", blockTimestamp, "l1_timestamp", l1Origin.MillisecondTimestamp(), "origin", l1Origin.ID())
-			return BatchDrop
+			return batchValidity(BatchDrop, log.Warn, "block timestamp is less than L1 origin timestamp", "l2_timestamp", blockTimestamp, "l1_timestamp", l1Origin.MillisecondTimestamp(), "origin", l1Origin.ID())
 		}
 
 		spec := rollup.NewChainSpec(cfg)
@@ -323,12 +311,10 @@ func checkSpanBatch(ctx context.Context, cfg *rollup.Config, log log.Logger, l1B
 				// We only check batches that do not advance the epoch, to ensure epoch advancement regardless of time drift is allowed.
 				if !originAdvanced {
 					if originIdx+1 >= len(l1Blocks) {
-						log.Info("without the next L1 origin we cannot determine yet if this empty batch that exceeds the time drift is still valid")
-						return BatchUndecided
+						return batchValidity(BatchUndecided, log.Info, "without the next L1 origin we cannot determine yet if this empty batch that exceeds the time drift is still valid")
 					}
 					if blockTimestamp >= l1Blocks[originIdx+1].MillisecondTimestamp() { // check if the next L1 origin could have been adopted
-						log.Info("batch exceeded sequencer time drift without adopting next origin, and next L1 origin would have been valid")
-						return BatchDrop
+						return batchValidity(BatchDrop, log.Info, "batch exceeded sequencer time drift without adopting next origin, and next L1 origin would have been valid")
 					} else {
 						log.Info("continuing with empty batch before late L1 block to preserve L2 time invariant")
 					}
@@ -336,19 +322,16 @@ func checkSpanBatch(ctx context.Context, cfg *rollup.Config, log log.Logger, l1B
 			} else {
 				// If the sequencer is ignoring the time drift rule, then drop the batch and force an empty batch instead,
 				// as the sequencer is not allowed to include anything past this point without moving to the next epoch.
-				log.Warn("batch exceeded sequencer time drift, sequencer must adopt new L1 origin to include transactions again", "max_time", max)
-				return BatchDrop
+				return batchValidity(BatchDrop, log.Warn, "batch exceeded sequencer time drift, sequencer must adopt new L1 origin to include transactions again", "max_time", max)
 			}
 		}
 
 		for i, txBytes := range batch.GetBlockTransactions(i) {
 			if len(txBytes) == 0 {
-				log.Warn("transaction data must not be empty, but found empty tx", "tx_index", i)
-				return BatchDrop
+				return batchValidity(BatchDrop, log.Warn, "transaction data must not be empty, but found empty tx", "tx_index", i)
 			}
 			if txBytes[0] == types.DepositTxType {
-				log.Warn("sequencers may not embed any deposits into batch data, but found tx that has one", "tx_index", i)
-				return BatchDrop
+				return batchValidity(BatchDrop, log.Warn, "sequencers may not embed any deposits into batch data, but found tx that has one", "tx_index", i)
 			}
 		}
 	}
@@ -359,9 +342,8 @@ func checkSpanBatch(ctx context.Context, cfg *rollup.Config, log log.Logger, l1B
 			safeBlockNum := parentNum + i + 1
 			safeBlockPayload, err := l2Fetcher.PayloadByNumber(ctx, safeBlockNum)
 			if err != nil {
-				log.Warn("failed to fetch L2 block payload", "number", parentNum, "err", err)
 				// unable to validate the batch for now. retry later.
-				return BatchUndecided
+				return batchValidity(BatchUndecided, log.Warn, "failed to fetch L2 block payload", "number", parentNum, "err", err)
 			}
 			safeBlockTxs := safeBlockPayload.ExecutionPayload.Transactions
 			batchTxs := batch.GetBlockTransactions(int(i))
@@ -373,26 +355,22 @@ func checkSpanBatch(ctx context.Context, cfg *rollup.Config, log log.Logger, l1B
 				}
 			}
 			if len(safeBlockTxs)-depositCount != len(batchTxs) {
-				log.Warn("overlapped block's tx count does not match", "safeBlockTxs", len(safeBlockTxs), "batchTxs", len(batchTxs))
-				return BatchDrop
+				return batchValidity(BatchDrop, log.Warn, "overlapped block's tx count does not match", "safeBlockTxs", len(safeBlockTxs), "batchTxs", len(batchTxs))
 			}
 			for j := 0; j < len(batchTxs); j++ {
 				if !bytes.Equal(safeBlockTxs[j+depositCount], batchTxs[j]) {
-					log.Warn("overlapped block's transaction does not match")
-					return BatchDrop
+					return batchValidity(BatchDrop, log.Warn, "overlapped block's transaction does not match")
 				}
 			}
 			safeBlockRef, err := PayloadToBlockRef(cfg, safeBlockPayload.ExecutionPayload)
 			if err != nil {
-				log.Error("failed to extract L2BlockRef from execution payload", "hash", safeBlockPayload.ExecutionPayload.BlockHash, "err", err)
-				return BatchDrop
+				return batchValidity(BatchDrop, log.Error, "failed to extract L2BlockRef from execution payload", "hash", safeBlockPayload.ExecutionPayload.BlockHash, "err", err)
 			}
 			if safeBlockRef.L1Origin.Number != batch.GetBlockEpochNum(int(i)) {
-				log.Warn("overlapped block's L1 origin number does not match")
-				return BatchDrop
+				return batchValidity(BatchDrop, log.Warn, "overlapped block's L1 origin number does not match")
 			}
 		}
 	}
 
-	return BatchAccept
+	return BatchAccept, ""
 }
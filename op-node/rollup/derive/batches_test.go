@@ -1588,8 +1588,9 @@ func TestValidBatch(t *testing.T) {
 		if mod := testCase.ConfigMod; mod != nil {
 			mod(rcfg, &testCase)
 		}
-		validity := CheckBatch(ctx, rcfg, logger, testCase.L1Blocks, testCase.L2SafeHead, &testCase.Batch, &l2Client)
+		validity, reason := CheckBatch(ctx, rcfg, logger, testCase.L1Blocks, testCase.L2SafeHead, &testCase.Batch, &l2Client)
 		require.Equal(t, testCase.Expected, validity, "batch check must return expected validity level")
+		require.Equal(t, validity == BatchAccept, reason == "", "batches must have a reason unless accepted")
 		if expLog := testCase.ExpectedLog; expLog != "" {
 			// Check if ExpectedLog is contained in the log buffer
 			containsFilter := testlog.NewMessageContainsFilter(expLog)
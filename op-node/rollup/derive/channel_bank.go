@@ -39,6 +39,8 @@ type ChannelBank struct {
 
 	prev    NextFrameProvider
 	fetcher L1Fetcher
+
+	events DerivationEventListener
 }
 
 var _ ResettableStage = (*ChannelBank)(nil)
@@ -53,6 +55,7 @@ func NewChannelBank(log log.Logger, cfg *rollup.Config, prev NextFrameProvider,
 		channelQueue: make([]ChannelID, 0, 10),
 		prev:         prev,
 		fetcher:      fetcher,
+		events:       DerivationEventsDisabled,
 	}
 }
 
@@ -60,6 +63,11 @@ func (cb *ChannelBank) Origin() eth.L1BlockRef {
 	return cb.prev.Origin()
 }
 
+// SetDerivationEvents sets the listener of the frame and channel decisions of the channel bank.
+func (cb *ChannelBank) SetDerivationEvents(events DerivationEventListener) {
+	cb.events = events
+}
+
 func (cb *ChannelBank) prune() {
 	// check total size
 	totalSize := uint64(0)
@@ -73,6 +81,7 @@ func (cb *ChannelBank) prune() {
 		cb.channelQueue = cb.channelQueue[1:]
 		delete(cb.channels, id)
 		cb.log.Info("pruning channel", "channel", id, "totalSize", totalSize, "channel_size", ch.size, "remaining_channel_count", len(cb.channels))
+		cb.emitChannelEvent(ChannelPrunedEvent, ch, "channel bank is full")
 		totalSize -= ch.size
 	}
 }
@@ -100,15 +109,18 @@ func (cb *ChannelBank) IngestFrame(f Frame) {
 	// check if the channel is not timed out
 	if currentCh.OpenBlockNumber()+cb.spec.ChannelTimeout() < origin.Number {
 		log.Warn("channel is timed out, ignore frame")
+		cb.emitFrameEvent(FrameRejectedEvent, f, "channel is timed out")
 		return
 	}
 
 	log.Trace("ingesting frame")
 	if err := currentCh.AddFrame(f, origin); err != nil {
 		log.Warn("failed to ingest frame into channel", "err", err)
+		cb.emitFrameEvent(FrameRejectedEvent, f, err.Error())
 		return
 	}
 	cb.metrics.RecordFrame()
+	cb.emitFrameEvent(FrameAcceptedEvent, f, "")
 
 	// Prune after the frame is loaded.
 	cb.prune()
@@ -129,6 +141,7 @@ func (cb *ChannelBank) Read() (data []byte, err error) {
 	if timedOut {
 		cb.log.Info("channel timed out", "channel", first, "frames", len(ch.inputs))
 		cb.metrics.RecordChannelTimedOut()
+		cb.emitChannelEvent(ChannelTimedOutEvent, ch, "")
 		delete(cb.channels, first)
 		cb.channelQueue = cb.channelQueue[1:]
 		return nil, nil // multiple different channels may all be timed out
@@ -162,6 +175,7 @@ func (cb *ChannelBank) tryReadChannelAtIndex(i int) (data []byte, err error) {
 		return nil, io.EOF
 	}
 	cb.log.Info("Reading channel", "channel", chanID, "frames", len(ch.inputs))
+	cb.emitChannelEvent(ChannelReadEvent, ch, "")
 
 	delete(cb.channels, chanID)
 	cb.channelQueue = slices.Delete(cb.channelQueue, i, i+1)
@@ -208,3 +222,37 @@ func (cb *ChannelBank) Reset(ctx context.Context, base eth.L1BlockRef, _ eth.Sys
 type L1BlockRefByHashFetcher interface {
 	L1BlockRefByHash(context.Context, common.Hash) (eth.L1BlockRef, error)
 }
+
+func (cb *ChannelBank) emitFrameEvent(typ DerivationEventType, f Frame, reason string) {
+	if !cb.events.Enabled() {
+		return
+	}
+	cb.events.OnDerivationEvent(DerivationEvent{
+		Type:   typ,
+		Origin: cb.Origin().ID(),
+		Reason: reason,
+		Frame: &FrameEventInfo{
+			Channel:     f.ID,
+			FrameNumber: f.FrameNumber,
+			IsLast:      f.IsLast,
+			Size:        len(f.Data),
+		},
+	})
+}
+
+func (cb *ChannelBank) emitChannelEvent(typ DerivationEventType, ch *Channel, reason string) {
+	if !cb.events.Enabled() {
+		return
+	}
+	cb.events.OnDerivationEvent(DerivationEvent{
+		Type:   typ,
+		Origin: cb.Origin().ID(),
+		Reason: reason,
+		Channel: &ChannelEventInfo{
+			ID:        ch.id,
+			OpenBlock: ch.OpenBlockNumber(),
+			Frames:    len(ch.inputs),
+			Size:      ch.size,
+		},
+	})
+}
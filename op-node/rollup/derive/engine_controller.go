@@ -34,6 +34,9 @@ const (
 	syncStatusFinishedEL                // EL sync is done & we should be performing consolidation
 )
 
+// elSyncProgressInterval is the minimum interval between fetching the engine head to track the EL sync progress
+const elSyncProgressInterval = 10 * time.Second
+
 var (
 	ErrNoFCUNeeded             = errors.New("no FCU call was needed")
 	ErrELSyncTriggerUnexpected = errors.New("forced head needed for startup")
@@ -64,8 +67,15 @@ type EngineController struct {
 	chainSpec    *rollup.ChainSpec
 	rollupCfg    *rollup.Config
 	elStart      time.Time
+	elCheckpoint eth.BlockID
 	clock        clock.Clock
 
+	// EL sync progress, tracked while EL syncing
+	elSyncStartHead eth.L2BlockRef // engine head when EL sync started
+	elSyncCurrent   eth.L2BlockRef // engine head as last reported by the engine
+	elSyncTarget    eth.BlockID    // latest unsafe payload the engine was directed to sync towards
+	elSyncPolled    time.Time      // last time the engine head was fetched to track the progress
+
 	// Block Head State
 	unsafeHead       eth.L2BlockRef
 	pendingSafeHead  eth.L2BlockRef // L2 block processed from the middle of a span batch, but not marked as the safe block yet.
@@ -103,6 +113,7 @@ func NewEngineController(engine ExecEngine, log log.Logger, metrics Metrics, rol
 		rollupCfg:    rollupCfg,
 		syncMode:     syncConfig.SyncMode,
 		elTriggerGap: syncConfig.ELTriggerGap,
+		elCheckpoint: syncConfig.ELCheckpoint,
 		syncStatus:   syncStatus,
 		clock:        clock.SystemClock,
 		combinedAPI:  combinedAPI,
@@ -139,6 +150,71 @@ func (e *EngineController) IsEngineSyncing() bool {
 	return e.syncStatus == syncStatusWillStartEL || e.syncStatus == syncStatusStartedEL || e.syncStatus == syncStatusFinishedELButNotFinalized
 }
 
+// ELSyncProgress returns the progress of the EL sync, or nil if EL sync is not in progress.
+func (e *EngineController) ELSyncProgress() *eth.ELSyncProgress {
+	if e.syncStatus != syncStatusStartedEL && e.syncStatus != syncStatusFinishedELButNotFinalized {
+		return nil
+	}
+	target := e.elSyncTarget
+	if target == (eth.BlockID{}) {
+		target = e.elCheckpoint
+	}
+	return &eth.ELSyncProgress{
+		Start:              e.elSyncStartHead,
+		Current:            e.elSyncCurrent,
+		Target:             target,
+		StartTime:          uint64(e.elStart.Unix()),
+		EstimatedRemaining: uint64(e.elSyncRemaining(target).Seconds()),
+	}
+}
+
+// elSyncRemaining estimates the time until the engine reaches the target, based on the sync rate since EL sync started.
+// It returns zero if the engine head did not progress yet.
+func (e *EngineController) elSyncRemaining(target eth.BlockID) time.Duration {
+	if e.elSyncCurrent.Number <= e.elSyncStartHead.Number || target.Number <= e.elSyncCurrent.Number {
+		return 0
+	}
+	synced := e.elSyncCurrent.Number - e.elSyncStartHead.Number
+	elapsed := e.clock.Since(e.elStart)
+	return time.Duration(float64(elapsed) / float64(synced) * float64(target.Number-e.elSyncCurrent.Number))
+}
+
+// updateELSyncProgress records the new EL sync target, and at most every elSyncProgressInterval
+// fetches the engine head and reports the progress in the metrics.
+func (e *EngineController) updateELSyncProgress(ctx context.Context, target eth.BlockID) {
+	e.elSyncTarget = target
+	now := e.clock.Now()
+	if now.Sub(e.elSyncPolled) < elSyncProgressInterval {
+		return
+	}
+	e.elSyncPolled = now
+	if head, err := e.engine.L2BlockRefByLabel(ctx, eth.Unsafe); err != nil {
+		e.log.Warn("Failed to fetch engine head to track EL sync progress", "err", err)
+	} else {
+		e.elSyncCurrent = head
+	}
+	remaining := e.elSyncRemaining(target)
+	e.metrics.RecordELSyncProgress(target.Number, e.elSyncCurrent.Number, remaining)
+	e.log.Debug("EL sync progress", "target", target, "current", e.elSyncCurrent, "remaining", remaining)
+}
+
+// syncToELCheckpoint directs the engine to sync towards the trusted checkpoint, so the engine
+// starts from it even before the unsafe payloads it is given build on it.
+func (e *EngineController) syncToELCheckpoint(ctx context.Context) {
+	fc := eth.ForkchoiceState{
+		HeadBlockHash:      e.elCheckpoint.Hash,
+		SafeBlockHash:      e.safeHead.Hash,
+		FinalizedBlockHash: e.finalizedHead.Hash,
+	}
+	fcRes, err := e.engine.ForkchoiceUpdate(ctx, &fc, nil)
+	if err != nil {
+		// the forkchoice update of the unsafe payload still drives the EL sync
+		e.log.Warn("Failed to direct EL sync to the trusted checkpoint", "checkpoint", e.elCheckpoint, "err", err)
+		return
+	}
+	e.log.Info("Directed EL sync to the trusted checkpoint", "checkpoint", e.elCheckpoint, "status", fcRes.PayloadStatus.Status)
+}
+
 // Setters
 
 // SetFinalizedHead implements LocalEngineControl.
@@ -428,10 +504,18 @@ func (e *EngineController) InsertUnsafePayload(ctx context.Context, envelope *et
 		currentUnsafe := e.GetCurrentUnsafeHead(ctx)
 		rollupGenesisIsFinalized := b.Hash == e.rollupCfg.Genesis.L2.Hash
 		isGapSyncNeeded := ref.Number-currentUnsafe.Number > uint64(e.elTriggerGap)
-		if errors.Is(err, ethereum.NotFound) || rollupGenesisIsFinalized || isGapSyncNeeded {
+		// A fresh node behind the trusted checkpoint always syncs up to it with EL sync.
+		isBehindCheckpoint := e.elCheckpoint != (eth.BlockID{}) && currentUnsafe.Number < e.elCheckpoint.Number
+		if errors.Is(err, ethereum.NotFound) || rollupGenesisIsFinalized || isGapSyncNeeded || isBehindCheckpoint {
 			e.syncStatus = syncStatusStartedEL
-			e.log.Info("Starting EL sync")
+			e.log.Info("Starting EL sync", "head", currentUnsafe, "target", ref.ID(), "checkpoint", e.elCheckpoint)
 			e.elStart = e.clock.Now()
+			e.elSyncStartHead = currentUnsafe
+			e.elSyncCurrent = currentUnsafe
+			e.elSyncPolled = time.Time{}
+			if isBehindCheckpoint {
+				e.syncToELCheckpoint(ctx)
+			}
 		} else if err == nil {
 			e.syncStatus = syncStatusFinishedEL
 			e.log.Info("Skipping EL sync and going straight to CL sync because there is a finalized block", "id", b.ID())
@@ -440,6 +524,9 @@ func (e *EngineController) InsertUnsafePayload(ctx context.Context, envelope *et
 			return NewTemporaryError(fmt.Errorf("failed to fetch finalized head: %w", err))
 		}
 	}
+	if e.syncStatus == syncStatusStartedEL {
+		e.updateELSyncProgress(ctx, ref.ID())
+	}
 	// Insert the payload & then call FCU
 	status, err := e.engine.NewPayload(ctx, envelope.ExecutionPayload, envelope.ParentBeaconBlockRoot)
 	if err != nil {
@@ -551,6 +638,7 @@ func (e *EngineController) InsertUnsafePayload(ctx context.Context, envelope *et
 
 	if e.syncStatus == syncStatusFinishedELButNotFinalized {
 		e.log.Info("Finished EL sync", "sync_duration", e.clock.Since(e.elStart), "finalized_block", ref.ID().String())
+		e.metrics.RecordELSyncProgress(ref.Number, ref.Number, 0)
 		e.syncStatus = syncStatusFinishedEL
 		e.SetUnsafeHead(ref)
 	}
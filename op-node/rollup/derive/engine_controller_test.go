@@ -0,0 +1,90 @@
+package derive
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestELSyncProgress(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	eng := &testutils.MockEngine{}
+	checkpoint := eth.BlockID{Hash: common.Hash{0xcc}, Number: 1000}
+	ec := NewEngineController(eng, logger, &testutils.TestDerivationMetrics{}, &rollup.Config{}, &sync.Config{
+		SyncMode:     sync.ELSync,
+		ELCheckpoint: checkpoint,
+	}, false)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	ec.clock = cl
+
+	// Not reported before EL sync started
+	require.Nil(t, ec.ELSyncProgress())
+
+	start := eth.L2BlockRef{Hash: common.Hash{0x01}, Number: 100}
+	ec.syncStatus = syncStatusStartedEL
+	ec.elStart = cl.Now()
+	ec.elSyncStartHead = start
+	ec.elSyncCurrent = start
+
+	// The checkpoint is the target until an unsafe payload is received
+	require.Equal(t, &eth.ELSyncProgress{
+		Start:     start,
+		Current:   start,
+		Target:    checkpoint,
+		StartTime: 1000,
+	}, ec.ELSyncProgress())
+
+	// 100 blocks synced in 10 seconds, 400 blocks to go
+	cl.AdvanceTime(10 * time.Second)
+	current := eth.L2BlockRef{Hash: common.Hash{0x02}, Number: 200}
+	target := eth.BlockID{Hash: common.Hash{0x03}, Number: 600}
+	eng.ExpectL2BlockRefByLabel(eth.Unsafe, current, nil)
+	ec.updateELSyncProgress(context.Background(), target)
+	require.Equal(t, &eth.ELSyncProgress{
+		Start:              start,
+		Current:            current,
+		Target:             target,
+		StartTime:          1000,
+		EstimatedRemaining: 40,
+	}, ec.ELSyncProgress())
+	eng.AssertExpectations(t)
+
+	// The engine head is not fetched again within the progress interval, but the target is updated
+	cl.AdvanceTime(time.Second)
+	target = eth.BlockID{Hash: common.Hash{0x04}, Number: 601}
+	ec.updateELSyncProgress(context.Background(), target)
+	require.Equal(t, target, ec.ELSyncProgress().Target)
+	require.Equal(t, current, ec.ELSyncProgress().Current)
+	eng.AssertExpectations(t)
+
+	// Not reported after EL sync finished
+	ec.syncStatus = syncStatusFinishedEL
+	require.Nil(t, ec.ELSyncProgress())
+}
+
+func TestSyncToELCheckpoint(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	eng := &testutils.MockEngine{}
+	checkpoint := eth.BlockID{Hash: common.Hash{0xcc}, Number: 1000}
+	ec := NewEngineController(eng, logger, &testutils.TestDerivationMetrics{}, &rollup.Config{}, &sync.Config{
+		SyncMode:     sync.ELSync,
+		ELCheckpoint: checkpoint,
+	}, false)
+
+	eng.ExpectForkchoiceUpdate(&eth.ForkchoiceState{HeadBlockHash: checkpoint.Hash}, nil,
+		&eth.ForkchoiceUpdatedResult{PayloadStatus: eth.PayloadStatusV1{Status: eth.ExecutionSyncing}}, nil)
+	ec.syncToELCheckpoint(context.Background())
+	eng.AssertExpectations(t)
+}
@@ -0,0 +1,161 @@
+package derive
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// DerivationEventType identifies a decision of the derivation stages.
+type DerivationEventType string
+
+const (
+	// FrameAcceptedEvent is emitted when the channel bank adds a frame to its channel.
+	FrameAcceptedEvent DerivationEventType = "frame_accepted"
+	// FrameRejectedEvent is emitted when the channel bank ignores a frame.
+	FrameRejectedEvent DerivationEventType = "frame_rejected"
+	// ChannelReadEvent is emitted when the channel bank passes a ready channel to the channel reader.
+	ChannelReadEvent DerivationEventType = "channel_read"
+	// ChannelTimedOutEvent is emitted when the channel bank drops a channel which didn't complete in time.
+	ChannelTimedOutEvent DerivationEventType = "channel_timed_out"
+	// ChannelPrunedEvent is emitted when the channel bank drops a channel because it is full.
+	ChannelPrunedEvent DerivationEventType = "channel_pruned"
+	// BatchCheckedEvent is emitted with the validity of a batch, every time the batch queue checks a batch.
+	BatchCheckedEvent DerivationEventType = "batch_checked"
+	// AttributesEvent is emitted when the attributes queue produces the attributes of the next L2 block.
+	AttributesEvent DerivationEventType = "attributes_produced"
+)
+
+// DerivationEvent is a decision of the derivation stages.
+// Depending on the type, one of Frame, Channel, Batch or Attributes is set.
+type DerivationEvent struct {
+	Type DerivationEventType `json:"type"`
+	// Origin is the L1 block the stage was at.
+	Origin eth.BlockID `json:"origin"`
+	// Reason explains why a frame or channel got dropped, or why a batch got its validity.
+	Reason string `json:"reason,omitempty"`
+
+	Frame      *FrameEventInfo      `json:"frame,omitempty"`
+	Channel    *ChannelEventInfo    `json:"channel,omitempty"`
+	Batch      *BatchEventInfo      `json:"batch,omitempty"`
+	Attributes *AttributesEventInfo `json:"attributes,omitempty"`
+}
+
+type FrameEventInfo struct {
+	Channel     ChannelID `json:"channel"`
+	FrameNumber uint16    `json:"frame_number"`
+	IsLast      bool      `json:"is_last"`
+	Size        int       `json:"size"`
+}
+
+type ChannelEventInfo struct {
+	ID ChannelID `json:"id"`
+	// OpenBlock is the L1 block the channel got opened at.
+	OpenBlock uint64 `json:"open_block"`
+	Frames    int    `json:"frames"`
+	Size      uint64 `json:"size"`
+}
+
+type BatchEventInfo struct {
+	BatchType int `json:"batch_type"`
+	// Timestamp is the timestamp in milliseconds of the first block of the batch.
+	Timestamp        uint64      `json:"timestamp"`
+	BlockCount       int         `json:"block_count"`
+	L1InclusionBlock eth.BlockID `json:"l1_inclusion_block"`
+	Parent           eth.BlockID `json:"parent"`
+	Validity         string      `json:"validity"`
+}
+
+type AttributesEventInfo struct {
+	Parent eth.BlockID `json:"parent"`
+	// Timestamp is the timestamp in milliseconds of the block to build.
+	Timestamp    uint64      `json:"timestamp"`
+	Epoch        eth.BlockID `json:"epoch"`
+	Txs          int         `json:"txs"`
+	IsLastInSpan bool        `json:"is_last_in_span"`
+}
+
+// DerivationEventListener receives the decisions of the derivation stages, as they are taken.
+type DerivationEventListener interface {
+	// Enabled returns true if the events are listened to, the stages don't emit them otherwise.
+	Enabled() bool
+	// OnDerivationEvent is called from the derivation loop, and must not block.
+	OnDerivationEvent(ev DerivationEvent)
+}
+
+type disabledDerivationEvents struct{}
+
+func (disabledDerivationEvents) Enabled() bool { return false }
+
+func (disabledDerivationEvents) OnDerivationEvent(DerivationEvent) {}
+
+// DerivationEventsDisabled is the DerivationEventListener of the stages nobody listens to.
+var DerivationEventsDisabled DerivationEventListener = disabledDerivationEvents{}
+
+// DerivationEventFeed fans the derivation events out to its subscribers.
+// Events are dropped for subscribers which don't keep up, so the derivation is never blocked.
+type DerivationEventFeed struct {
+	mu   sync.RWMutex
+	subs map[*DerivationEventSubscription]struct{}
+}
+
+var _ DerivationEventListener = (*DerivationEventFeed)(nil)
+
+func NewDerivationEventFeed() *DerivationEventFeed {
+	return &DerivationEventFeed{subs: make(map[*DerivationEventSubscription]struct{})}
+}
+
+// Subscribe returns a subscription buffering up to the given number of events.
+func (f *DerivationEventFeed) Subscribe(buffer int) *DerivationEventSubscription {
+	sub := &DerivationEventSubscription{feed: f, ch: make(chan DerivationEvent, buffer)}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.subs[sub] = struct{}{}
+	return sub
+}
+
+func (f *DerivationEventFeed) Enabled() bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return len(f.subs) > 0
+}
+
+func (f *DerivationEventFeed) OnDerivationEvent(ev DerivationEvent) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	for sub := range f.subs {
+		select {
+		case sub.ch <- ev:
+		default:
+			sub.dropped.Add(1)
+		}
+	}
+}
+
+// DerivationEventSubscription is a subscription to a DerivationEventFeed.
+type DerivationEventSubscription struct {
+	feed    *DerivationEventFeed
+	ch      chan DerivationEvent
+	dropped atomic.Uint64
+	once    sync.Once
+}
+
+// Events returns the channel of the events, which is closed on Unsubscribe.
+func (s *DerivationEventSubscription) Events() <-chan DerivationEvent {
+	return s.ch
+}
+
+// Dropped returns the number of events dropped because the buffer of the subscription was full.
+func (s *DerivationEventSubscription) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+func (s *DerivationEventSubscription) Unsubscribe() {
+	s.once.Do(func() {
+		s.feed.mu.Lock()
+		defer s.feed.mu.Unlock()
+		delete(s.feed.subs, s)
+		close(s.ch)
+	})
+}
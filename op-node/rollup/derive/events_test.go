@@ -0,0 +1,97 @@
+package derive
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestDerivationEventFeed(t *testing.T) {
+	feed := NewDerivationEventFeed()
+	require.False(t, feed.Enabled())
+
+	sub := feed.Subscribe(1)
+	require.True(t, feed.Enabled())
+
+	// the second event doesn't fit in the buffer and is dropped instead of blocking
+	feed.OnDerivationEvent(DerivationEvent{Type: FrameAcceptedEvent})
+	feed.OnDerivationEvent(DerivationEvent{Type: FrameRejectedEvent})
+	require.Equal(t, FrameAcceptedEvent, (<-sub.Events()).Type)
+	require.Equal(t, uint64(1), sub.Dropped())
+
+	sub.Unsubscribe()
+	sub.Unsubscribe()
+	require.False(t, feed.Enabled())
+	_, ok := <-sub.Events()
+	require.False(t, ok)
+}
+
+func TestBatchQueueDerivationEvents(t *testing.T) {
+	log := testlog.Logger(t, log.LevelCrit)
+	l1 := L1Chain([]uint64{10, 20, 30})
+	safeHead := eth.L2BlockRef{
+		Hash:           mockHash(10, 2),
+		Number:         0,
+		ParentHash:     common.Hash{},
+		Time:           10,
+		L1Origin:       l1[0].ID(),
+		SequenceNumber: 0,
+	}
+	cfg := &rollup.Config{
+		Genesis: rollup.Genesis{
+			L2Time: 10,
+		},
+		BlockTime:         2,
+		MaxSequencerDrift: 600,
+		SeqWindowSize:     30,
+		L2ChainID:         big.NewInt(1234),
+	}
+	oldBatch := b(cfg.L2ChainID, 10, l1[0])
+	nextBatch := b(cfg.L2ChainID, 12, l1[0])
+	input := &fakeBatchQueueInput{
+		batches: []Batch{oldBatch, nextBatch},
+		errors:  []error{nil, nil},
+		origin:  l1[0],
+	}
+
+	feed := NewDerivationEventFeed()
+	sub := feed.Subscribe(10)
+	defer sub.Unsubscribe()
+	bq := NewBatchQueue(log, cfg, input, nil)
+	bq.events = feed
+	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
+
+	_, _, err := bq.NextBatch(context.Background(), safeHead)
+	require.ErrorIs(t, err, NotEnoughData)
+	ev := <-sub.Events()
+	require.Equal(t, BatchCheckedEvent, ev.Type)
+	require.Equal(t, l1[0].ID(), ev.Origin)
+	require.Equal(t, "dropping batch with old timestamp", ev.Reason)
+	require.Equal(t, &BatchEventInfo{
+		BatchType:        SingularBatchType,
+		Timestamp:        oldBatch.Timestamp,
+		BlockCount:       1,
+		L1InclusionBlock: l1[0].ID(),
+		Parent:           safeHead.ID(),
+		Validity:         "drop",
+	}, ev.Batch)
+
+	batch, _, err := bq.NextBatch(context.Background(), safeHead)
+	require.NoError(t, err)
+	require.Equal(t, nextBatch, batch)
+	// the batch is checked when it's added to the queue, and again when it's derived
+	for i := 0; i < 2; i++ {
+		ev = <-sub.Events()
+		require.Equal(t, BatchCheckedEvent, ev.Type)
+		require.Equal(t, "accept", ev.Batch.Validity)
+		require.Equal(t, nextBatch.Timestamp, ev.Batch.Timestamp)
+	}
+}
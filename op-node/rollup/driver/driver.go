@@ -41,6 +41,8 @@ type Metrics interface {
 
 	RecordL1ReorgDepth(d uint64)
 
+	RecordELSyncProgress(target uint64, current uint64, remaining time.Duration)
+
 	EngineMetrics
 	L1FetcherMetrics
 	SequencerMetrics
@@ -148,6 +150,7 @@ func NewDriver(
 	metrics Metrics,
 	sequencerStateListener SequencerStateListener,
 	safeHeadListener derive.SafeHeadListener,
+	derivationEvents derive.DerivationEventListener,
 	syncCfg *sync.Config,
 	sequencerConductor conductor.SequencerConductor,
 	plasma PlasmaIface,
@@ -178,7 +181,7 @@ func NewDriver(
 
 	attributesHandler := attributes.NewAttributesHandler(log, cfg, engine, l2, driverCfg.L2P2PNode)
 	derivationPipeline := derive.NewDerivationPipeline(log, cfg, verifConfDepth, l1Blobs, plasma, l2, engine,
-		metrics, syncCfg, safeHeadListener, finalizer, attributesHandler)
+		metrics, syncCfg, safeHeadListener, finalizer, attributesHandler, derivationEvents)
 	attrBuilder := derive.NewFetchingAttributesBuilder(cfg, l1, l2)
 	meteredEngine := NewMeteredEngine(cfg, engine, metrics, log) // Only use the metered engine in the sequencer b/c it records sequencing metrics.
 	sequencer := NewSequencer(log, cfg, meteredEngine, attrBuilder, findL1Origin, metrics)
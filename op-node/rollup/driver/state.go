@@ -639,6 +639,7 @@ func (s *Driver) syncStatus() *eth.SyncStatus {
 		SafeL2:             s.engineController.SafeL2Head(),
 		FinalizedL2:        s.engineController.Finalized(),
 		PendingSafeL2:      s.engineController.PendingSafeL2Head(),
+		ELSync:             s.engineController.ELSyncProgress(),
 	}
 }
 
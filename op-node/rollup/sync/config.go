@@ -3,6 +3,8 @@ package sync
 import (
 	"fmt"
 	"strings"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
 type Mode int
@@ -72,4 +74,13 @@ type Config struct {
 	SkipSyncStartCheck bool `json:"skip_sync_start_check"`
 	// gap for trigger el-sync
 	ELTriggerGap int `json:"el_trigger_gap"`
+	// ELCheckpoint is a trusted L2 block, zero if not set.
+	// EL sync is started when the engine head is behind it, and the sync-start check does not verify
+	// the L1 origins of the blocks before it, which replaces SkipSyncStartCheck for nodes bootstrapped with EL sync.
+	ELCheckpoint eth.BlockID `json:"el_checkpoint"`
+}
+
+// HasELCheckpoint returns true if a trusted EL sync checkpoint is configured.
+func (c *Config) HasELCheckpoint() bool {
+	return c.ELCheckpoint != (eth.BlockID{})
 }
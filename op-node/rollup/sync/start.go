@@ -215,6 +215,24 @@ func FindL2Heads(ctx context.Context, cfg *rollup.Config, l1 L1Chain, l2 L2Chain
 			return result, nil
 		}
 
+		// The trusted checkpoint is not verified against L1, neither are the blocks before it.
+		if syncCfg.HasELCheckpoint() && n.Number == syncCfg.ELCheckpoint.Number {
+			if n.Hash != syncCfg.ELCheckpoint.Hash {
+				return nil, fmt.Errorf("%w: EL sync checkpoint %s, got %s", WrongChainErr, syncCfg.ELCheckpoint, n)
+			}
+			if result.Unsafe == (eth.L2BlockRef{}) {
+				result.Unsafe = n
+			}
+			if result.Safe.Number >= n.Number {
+				// the safe head builds on the trusted checkpoint, keep it rather than rewinding to the checkpoint
+				lgr.Info("Reached trusted EL sync checkpoint at or below the safe head, keeping the safe head", "checkpoint", n, "safe", result.Safe)
+				return result, nil
+			}
+			lgr.Info("Reached trusted EL sync checkpoint. Skip further sanity check and jump to the safe head", "checkpoint", n)
+			n = result.Safe
+			continue
+		}
+
 		if syncCfg.SkipSyncStartCheck && highestL2WithCanonicalL1Origin.Hash == n.Hash {
 			lgr.Info("Found highest L2 block with canonical L1 origin. Skip further sanity check and jump to the safe head")
 			n = result.Safe
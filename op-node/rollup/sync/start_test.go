@@ -55,6 +55,10 @@ type syncStartTestCase struct {
 	GenesisL1Num uint64
 	GenesisL2    rune
 
+	// ELCheckpoint is the trusted EL sync checkpoint at ELCheckpointNum, not set if zero.
+	ELCheckpoint    rune
+	ELCheckpointNum uint64
+
 	SeqWindowSize uint64
 	SafeL2Head    rune
 	UnsafeL2Head  rune
@@ -74,8 +78,12 @@ func (c *syncStartTestCase) Run(t *testing.T) {
 		Genesis:       genesis,
 		SeqWindowSize: c.SeqWindowSize,
 	}
+	syncCfg := &Config{}
+	if c.ELCheckpoint != 0 {
+		syncCfg.ELCheckpoint = eth.BlockID{Hash: runeToHash(c.ELCheckpoint), Number: c.ELCheckpointNum}
+	}
 	lgr := log.NewLogger(log.DiscardHandler())
-	result, err := FindL2Heads(context.Background(), cfg, chain, chain, lgr, &Config{})
+	result, err := FindL2Heads(context.Background(), cfg, chain, chain, lgr, syncCfg)
 	if c.ExpectedErr != nil {
 		require.ErrorIs(t, err, c.ExpectedErr, "expected error")
 		return
@@ -333,6 +341,56 @@ func TestFindSyncStart(t *testing.T) {
 			SeqWindowSize:  1,
 			ExpectedErr:    TooDeepReorgErr,
 		},
+		{
+			// The safe head building on the trusted checkpoint is kept instead of walking back to the finalized head
+			Name:            "EL sync checkpoint before safe head",
+			GenesisL1Num:    0,
+			L1:              "abcdefghij",
+			L2:              "ABCDEFGHIJ",
+			NewL1:           "abcdefghij",
+			PreFinalizedL2:  'A',
+			PreSafeL2:       'H',
+			GenesisL1:       'a',
+			GenesisL2:       'A',
+			ELCheckpoint:    'F',
+			ELCheckpointNum: 5,
+			UnsafeL2Head:    'J',
+			SeqWindowSize:   20,
+			SafeL2Head:      'H',
+			ExpectedErr:     nil,
+		},
+		{
+			Name:            "EL sync checkpoint after safe head",
+			GenesisL1Num:    0,
+			L1:              "abcdefghij",
+			L2:              "ABCDEFGHIJ",
+			NewL1:           "abcdefghij",
+			PreFinalizedL2:  'A',
+			PreSafeL2:       'C',
+			GenesisL1:       'a',
+			GenesisL2:       'A',
+			ELCheckpoint:    'F',
+			ELCheckpointNum: 5,
+			UnsafeL2Head:    'J',
+			SeqWindowSize:   20,
+			SafeL2Head:      'A',
+			ExpectedErr:     nil,
+		},
+		{
+			Name:            "EL sync checkpoint on another chain",
+			GenesisL1Num:    0,
+			L1:              "abcdefghij",
+			L2:              "ABCDEFGHIJ",
+			NewL1:           "abcdefghij",
+			PreFinalizedL2:  'A',
+			PreSafeL2:       'H',
+			GenesisL1:       'a',
+			GenesisL2:       'A',
+			ELCheckpoint:    'X',
+			ELCheckpointNum: 5,
+			SeqWindowSize:   20,
+			ExpectedErr:     WrongChainErr,
+		},
 	}
 
 	for _, testCase := range testCases {
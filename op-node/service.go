@@ -25,6 +25,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	opflags "github.com/ethereum-optimism/optimism/op-service/flags"
 )
 
@@ -83,9 +84,10 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 		Beacon: NewBeaconEndpointConfig(ctx),
 		L1Blob: NewL1BlobEndpointConfig(ctx),
 		RPC: node.RPCConfig{
-			ListenAddr:  ctx.String(flags.RPCListenAddr.Name),
-			ListenPort:  ctx.Int(flags.RPCListenPort.Name),
-			EnableAdmin: ctx.Bool(flags.RPCEnableAdmin.Name),
+			ListenAddr:             ctx.String(flags.RPCListenAddr.Name),
+			ListenPort:             ctx.Int(flags.RPCListenPort.Name),
+			EnableAdmin:            ctx.Bool(flags.RPCEnableAdmin.Name),
+			EnableDerivationEvents: ctx.Bool(flags.RPCEnableDerivationEvents.Name),
 		},
 		Metrics: node.MetricsConfig{
 			Enabled:    ctx.Bool(flags.MetricsEnabledFlag.Name),
@@ -104,11 +106,16 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 			Moniker: ctx.String(flags.HeartbeatMonikerFlag.Name),
 			URL:     ctx.String(flags.HeartbeatURLFlag.Name),
 		},
-		ConfigPersistence: configPersistence,
-		SafeDBPath:        ctx.String(flags.SafeDBPath.Name),
-		Sync:              *syncConfig,
-		RollupHalt:        haltOption,
-		RethDBPath:        ctx.String(flags.L1RethDBPath.Name),
+		ConfigPersistence:    configPersistence,
+		SafeDBPath:           ctx.String(flags.SafeDBPath.Name),
+		BlobDBPath:           ctx.String(flags.BlobDBPath.Name),
+		BlobDBRetention:      ctx.Uint64(flags.BlobDBRetention.Name),
+		PayloadDBPath:        ctx.String(flags.PayloadDBPath.Name),
+		PayloadDBMaxPayloads: ctx.Uint64(flags.PayloadDBMaxPayloads.Name),
+		PayloadDBMaxAge:      ctx.Duration(flags.PayloadDBMaxAge.Name),
+		Sync:                 *syncConfig,
+		RollupHalt:           haltOption,
+		RethDBPath:           ctx.String(flags.L1RethDBPath.Name),
 
 		ConductorEnabled:    ctx.Bool(flags.ConductorEnabledFlag.Name),
 		ConductorRpc:        ctx.String(flags.ConductorRpcFlag.Name),
@@ -143,14 +150,18 @@ func NewBeaconEndpointConfig(ctx *cli.Context) node.L1BeaconEndpointSetup {
 }
 
 func NewL1BlobEndpointConfig(ctx *cli.Context) node.L1BlobEndpointSetup {
-	nodeAddrs := ctx.String(flags.L1NodeAddr.Name)
-	if ctx.IsSet(flags.L1ArchiveBlobRpcAddr.Name) {
-		nodeAddrs = nodeAddrs + "," + ctx.String(flags.L1ArchiveBlobRpcAddr.Name)
+	var providers []sources.BlobProviderKind
+	for _, p := range ctx.StringSlice(flags.L1BlobProviders.Name) {
+		providers = append(providers, sources.BlobProviderKind(strings.ToLower(strings.TrimSpace(p))))
 	}
 	return &node.L1BlobEndpointConfig{
-		NodeAddrs: nodeAddrs,
-		RateLimit: ctx.Float64(flags.L1BlobRpcRateLimit.Name),
-		BatchSize: ctx.Int(flags.L1BlobRpcMaxBatchSize.Name),
+		NodeAddrs:        ctx.String(flags.L1NodeAddr.Name),
+		ArchiveNodeAddrs: ctx.String(flags.L1ArchiveBlobRpcAddr.Name),
+		ArchiveDir:       ctx.String(flags.L1BlobArchiveDir.Name),
+		ArchiveHTTPAddr:  ctx.String(flags.L1BlobArchiveHTTPAddr.Name),
+		Providers:        providers,
+		RateLimit:        ctx.Float64(flags.L1BlobRpcRateLimit.Name),
+		BatchSize:        ctx.Int(flags.L1BlobRpcMaxBatchSize.Name),
 	}
 }
 
@@ -329,6 +340,18 @@ func NewSyncConfig(ctx *cli.Context, log log.Logger) (*sync.Config, error) {
 	if ctx.Bool(flags.L2EngineSyncEnabled.Name) {
 		cfg.SyncMode = sync.ELSync
 	}
+	if ctx.IsSet(flags.ELCheckpointNumber.Name) != ctx.IsSet(flags.ELCheckpointHash.Name) {
+		return nil, fmt.Errorf("--%s and --%s must be set together", flags.ELCheckpointNumber.Name, flags.ELCheckpointHash.Name)
+	} else if ctx.IsSet(flags.ELCheckpointHash.Name) {
+		if cfg.SyncMode != sync.ELSync {
+			return nil, errors.New("an EL sync checkpoint requires --syncmode=execution-layer")
+		}
+		var hash common.Hash
+		if err := hash.UnmarshalText([]byte(ctx.String(flags.ELCheckpointHash.Name))); err != nil {
+			return nil, fmt.Errorf("invalid EL sync checkpoint hash: %w", err)
+		}
+		cfg.ELCheckpoint = eth.BlockID{Hash: hash, Number: ctx.Uint64(flags.ELCheckpointNumber.Name)}
+	}
 
 	return cfg, nil
 }
@@ -34,4 +34,23 @@ type SyncStatus struct {
 	FinalizedL2 L2BlockRef `json:"finalized_l2"`
 	// PendingSafeL2 points to the L2 block processed from the batch, but not consolidated to the safe block yet.
 	PendingSafeL2 L2BlockRef `json:"pending_safe_l2"`
+	// ELSync is the progress of the execution-layer sync, only set while the engine is EL syncing.
+	ELSync *ELSyncProgress `json:"el_sync,omitempty"`
+}
+
+// ELSyncProgress describes how far the execution engine got in syncing towards the tip of the L2 chain.
+type ELSyncProgress struct {
+	// Start is the engine head when EL sync started.
+	Start L2BlockRef `json:"start"`
+	// Current is the engine head as last reported by the engine.
+	// Engines that snap sync may only move it once the sync is complete.
+	Current L2BlockRef `json:"current"`
+	// Target is the latest unsafe block the engine was directed to sync towards,
+	// or the trusted checkpoint if no unsafe block was received yet.
+	Target BlockID `json:"target"`
+	// StartTime is the unix timestamp in seconds at which EL sync started.
+	StartTime uint64 `json:"start_time"`
+	// EstimatedRemaining is the estimated number of seconds until the engine reaches the target,
+	// based on the sync rate so far. It is zero if no progress was observed yet.
+	EstimatedRemaining uint64 `json:"estimated_remaining"`
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -13,29 +14,64 @@ import (
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 )
 
+// BSCBlobClient fetches blobs from a list of BlobSidecarsProvider, consulted in priority order.
+// The next provider is tried whenever a provider runs into an error, or returns sidecars that fail validation.
 type BSCBlobClient struct {
-	// BSCBlobClient will rotate client.RPC in pool whenever a client runs into an error or return nil while fetching blobs
-	pool *ClientPool[client.RPC]
+	providers []BlobSidecarsProvider
+	metrics   BlobProviderMetrics
+	listener  BlobSidecarsListener
 }
 
 func NewBSCBlobClient(clients []client.RPC) *BSCBlobClient {
+	return NewBSCBlobClientWithProviders(NoopBlobProviderMetrics, NewRPCBlobSidecarsProvider("rpc", clients))
+}
+
+// NewBSCBlobClientWithProviders returns a BSCBlobClient that consults the given providers,
+// in the order they are given.
+func NewBSCBlobClientWithProviders(m BlobProviderMetrics, providers ...BlobSidecarsProvider) *BSCBlobClient {
+	if m == nil {
+		m = NoopBlobProviderMetrics
+	}
 	return &BSCBlobClient{
-		pool: NewClientPool[client.RPC](clients...),
+		providers: providers,
+		metrics:   m,
 	}
 }
 
+// SetSidecarsListener registers a listener that is notified of all sidecars that passed validation.
+func (s *BSCBlobClient) SetSidecarsListener(listener BlobSidecarsListener) {
+	s.listener = listener
+}
+
 func (s *BSCBlobClient) GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error) {
 	if len(hashes) == 0 {
 		return []*eth.Blob{}, nil
 	}
 
-	blobSidecars, err := s.GetBlobSidecars(ctx, ref)
+	var errs []error
+	for _, p := range s.providers {
+		blobs, err := s.getBlobsFrom(ctx, p, ref, hashes)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("provider %s: %w", p.Name(), err))
+			continue
+		}
+		return blobs, nil
+	}
+	return nil, fmt.Errorf("failed to get blobs for L1BlockRef %s: %w", ref, errors.Join(errs...))
+}
+
+// getBlobsFrom fetches the blobs from a single provider, and validates the result.
+func (s *BSCBlobClient) getBlobsFrom(ctx context.Context, p BlobSidecarsProvider, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error) {
+	start := time.Now()
+	blobSidecars, err := p.GetBlobSidecars(ctx, ref, hashes)
 	if err != nil {
+		s.metrics.RecordBlobProviderRequest(p.Name(), blobProviderResult(err), time.Since(start))
 		return nil, fmt.Errorf("failed to get blob sidecars for L1BlockRef %s: %w", ref, err)
 	}
 
 	validatedBlobs, err := validateBlobSidecars(blobSidecars, ref)
 	if err != nil {
+		s.metrics.RecordBlobProviderRequest(p.Name(), BlobProviderResultInvalid, time.Since(start))
 		return nil, fmt.Errorf("failed to validate blob sidecars for L1BlockRef %s: %w", ref, err)
 	}
 
@@ -43,32 +79,40 @@ func (s *BSCBlobClient) GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes
 	for i, indexedBlobHash := range hashes {
 		blob, ok := validatedBlobs[indexedBlobHash.Hash]
 		if !ok {
-			return nil, fmt.Errorf("blob sidecars fetched from rpc mismatched with expected hash %s for L1BlockRef %s :%w", indexedBlobHash.Hash, ref, ethereum.NotFound)
+			s.metrics.RecordBlobProviderRequest(p.Name(), BlobProviderResultNotFound, time.Since(start))
+			return nil, fmt.Errorf("blob sidecars fetched from provider mismatched with expected hash %s for L1BlockRef %s :%w", indexedBlobHash.Hash, ref, ethereum.NotFound)
 		}
 		blobs[i] = blob
 	}
+	s.metrics.RecordBlobProviderRequest(p.Name(), BlobProviderResultSuccess, time.Since(start))
+	if s.listener != nil {
+		s.listener.BlobSidecarsFetched(ref, blobSidecars)
+	}
 	return blobs, nil
 }
 
+// GetBlobSidecars returns the validated sidecars of the given L1 block,
+// from the first provider that is able to serve all sidecars of the block.
 func (s *BSCBlobClient) GetBlobSidecars(ctx context.Context, ref eth.L1BlockRef) (eth.BSCBlobSidecars, error) {
 	var errs []error
-	for i := 0; i < s.pool.Len(); i++ {
-		var blobSidecars eth.BSCBlobSidecars
-
-		f := s.pool.Get()
-		err := f.CallContext(ctx, &blobSidecars, "eth_getBlobSidecars", numberID(ref.Number).Arg())
+	for _, p := range s.providers {
+		start := time.Now()
+		blobSidecars, err := p.GetBlobSidecars(ctx, ref, nil)
 		if err != nil {
-			s.pool.MoveToNext()
-			errs = append(errs, err)
-		} else {
-			if len(blobSidecars) == 0 {
-				err = ethereum.NotFound
-				errs = append(errs, err)
-				s.pool.MoveToNext()
-			} else {
-				return blobSidecars, nil
-			}
+			s.metrics.RecordBlobProviderRequest(p.Name(), blobProviderResult(err), time.Since(start))
+			errs = append(errs, fmt.Errorf("provider %s: %w", p.Name(), err))
+			continue
+		}
+		if _, err := validateBlobSidecars(blobSidecars, ref); err != nil {
+			s.metrics.RecordBlobProviderRequest(p.Name(), BlobProviderResultInvalid, time.Since(start))
+			errs = append(errs, fmt.Errorf("provider %s: %w", p.Name(), err))
+			continue
+		}
+		s.metrics.RecordBlobProviderRequest(p.Name(), BlobProviderResultSuccess, time.Since(start))
+		if s.listener != nil {
+			s.listener.BlobSidecarsFetched(ref, blobSidecars)
 		}
+		return blobSidecars, nil
 	}
 	return nil, errors.Join(errs...)
 }
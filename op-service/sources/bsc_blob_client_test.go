@@ -2,14 +2,22 @@ package sources
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -125,3 +133,78 @@ func TestBSCBlobClient(t *testing.T) {
 	_, err = bscBlobClient.GetBlobs(ctx, eth.L1BlockRef{}, ibhs)
 	require.ErrorIs(t, err, ethereum.NotFound)
 }
+
+type testBlobProviderMetrics struct {
+	results map[string][]string
+}
+
+func (m *testBlobProviderMetrics) RecordBlobProviderRequest(provider string, result string, duration time.Duration) {
+	m.results[provider] = append(m.results[provider], result)
+}
+
+func TestBSCBlobClientProviderFallback(t *testing.T) {
+	blockHash := common.BytesToHash([]byte{1})
+	blob1 := eth.Blob{}
+	blob1[0] = 1
+	blob2 := eth.Blob{}
+	blob2[0] = 2
+	ibhs, sidecar := makeTestBSCBlobSidecar(blockHash, []eth.Blob{blob1, blob2})
+	ref := eth.L1BlockRef{
+		Hash: blockHash,
+	}
+	ctx := context.Background()
+
+	// the RPC node has pruned the sidecars
+	m := new(mockRPC)
+	m.On("CallContext", ctx, new(eth.BSCBlobSidecars),
+		"eth_getBlobSidecars", []any{"0x0"}).Return([]error{nil})
+
+	// the on-disk archive holds a corrupted copy
+	encoded, err := json.Marshal(eth.BSCBlobSidecars{sidecar})
+	require.NoError(t, err)
+	dir := t.TempDir()
+	var mangled eth.BSCBlobSidecars
+	require.NoError(t, json.Unmarshal(encoded, &mangled))
+	mangled[0].Blobs[0][11]++
+	data, err := json.Marshal(mangled)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, blockHash.Hex()+".json"), data, 0o644))
+
+	// the blob archive service serves each blob by versioned hash
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i, h := range ibhs {
+			if r.URL.Path != "/blob_sidecars/"+h.Hash.Hex() {
+				continue
+			}
+			single := *sidecar
+			single.Blobs = []eth.Blob{sidecar.Blobs[i]}
+			single.Commitments = []eth.Bytes48{sidecar.Commitments[i]}
+			single.Proofs = []eth.Bytes48{sidecar.Proofs[i]}
+			require.NoError(t, json.NewEncoder(w).Encode(&single))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	metrics := &testBlobProviderMetrics{results: make(map[string][]string)}
+	bscBlobClient := NewBSCBlobClientWithProviders(metrics,
+		NewRPCBlobSidecarsProvider("rpc", []client.RPC{m}),
+		NewDirBlobSidecarsProvider("archive-dir", dir),
+		NewHTTPBlobSidecarsProvider("archive-http", client.NewBasicHTTPClient(srv.URL, testlog.Logger(t, log.LevelInfo))),
+	)
+
+	gotBlobs, err := bscBlobClient.GetBlobs(ctx, ref, ibhs)
+	require.NoError(t, err)
+	require.Len(t, gotBlobs, 2)
+	require.Equal(t, blob1, *gotBlobs[0])
+	require.Equal(t, blob2, *gotBlobs[1])
+	require.Equal(t, []string{BlobProviderResultNotFound}, metrics.results["rpc"])
+	require.Equal(t, []string{BlobProviderResultInvalid}, metrics.results["archive-dir"])
+	require.Equal(t, []string{BlobProviderResultSuccess}, metrics.results["archive-http"])
+
+	// unknown blobs are not found by any provider
+	ibhs[0].Hash[10]++
+	_, err = bscBlobClient.GetBlobs(ctx, ref, ibhs)
+	require.ErrorIs(t, err, ethereum.NotFound)
+}
@@ -0,0 +1,233 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const (
+	BlobProviderResultSuccess  = "success"
+	BlobProviderResultNotFound = "not_found"
+	BlobProviderResultInvalid  = "invalid"
+	BlobProviderResultError    = "error"
+
+	blobArchiveSidecarsMethodPrefix = "blob_sidecars"
+)
+
+// BlobProviderKind identifies a kind of BlobSidecarsProvider, used to configure the provider priority order.
+type BlobProviderKind string
+
+const (
+	BlobProviderRPC         BlobProviderKind = "rpc"
+	BlobProviderArchiveRPC  BlobProviderKind = "archive-rpc"
+	BlobProviderArchiveDir  BlobProviderKind = "archive-dir"
+	BlobProviderArchiveHTTP BlobProviderKind = "archive-http"
+)
+
+var BlobProviderKinds = []BlobProviderKind{
+	BlobProviderRPC,
+	BlobProviderArchiveRPC,
+	BlobProviderArchiveDir,
+	BlobProviderArchiveHTTP,
+}
+
+func (kind BlobProviderKind) String() string {
+	return string(kind)
+}
+
+func ValidBlobProviderKind(value BlobProviderKind) bool {
+	for _, k := range BlobProviderKinds {
+		if k == value {
+			return true
+		}
+	}
+	return false
+}
+
+// BlobSidecarsProvider is a source of BSC blob sidecars.
+// Results returned by a provider are untrusted: BSCBlobClient validates every result before use.
+type BlobSidecarsProvider interface {
+	// Name identifies the provider in logs and metrics.
+	Name() string
+	// GetBlobSidecars returns the sidecars of the given L1 block. Providers that can only address blobs by
+	// versioned hash use the given hashes, and may return a subset of the sidecars of the block.
+	// ethereum.NotFound is returned if the provider does not have the requested sidecars.
+	GetBlobSidecars(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) (eth.BSCBlobSidecars, error)
+}
+
+// BlobSidecarsListener is notified of every set of sidecars that passed validation.
+type BlobSidecarsListener interface {
+	BlobSidecarsFetched(ref eth.L1BlockRef, blobSidecars eth.BSCBlobSidecars)
+}
+
+// BlobProviderMetrics records the outcome of each request made to a BlobSidecarsProvider.
+type BlobProviderMetrics interface {
+	RecordBlobProviderRequest(provider string, result string, duration time.Duration)
+}
+
+type noopBlobProviderMetrics struct{}
+
+func (noopBlobProviderMetrics) RecordBlobProviderRequest(provider string, result string, duration time.Duration) {
+}
+
+var NoopBlobProviderMetrics BlobProviderMetrics = noopBlobProviderMetrics{}
+
+// RPCBlobSidecarsProvider fetches sidecars with eth_getBlobSidecars,
+// rotating through the RPC pool whenever a client runs into an error or returns no sidecars.
+type RPCBlobSidecarsProvider struct {
+	name string
+	pool *ClientPool[client.RPC]
+}
+
+var _ BlobSidecarsProvider = (*RPCBlobSidecarsProvider)(nil)
+
+func NewRPCBlobSidecarsProvider(name string, clients []client.RPC) *RPCBlobSidecarsProvider {
+	return &RPCBlobSidecarsProvider{
+		name: name,
+		pool: NewClientPool[client.RPC](clients...),
+	}
+}
+
+func (p *RPCBlobSidecarsProvider) Name() string {
+	return p.name
+}
+
+func (p *RPCBlobSidecarsProvider) GetBlobSidecars(ctx context.Context, ref eth.L1BlockRef, _ []eth.IndexedBlobHash) (eth.BSCBlobSidecars, error) {
+	var errs []error
+	for i := 0; i < p.pool.Len(); i++ {
+		var blobSidecars eth.BSCBlobSidecars
+
+		f := p.pool.Get()
+		err := f.CallContext(ctx, &blobSidecars, "eth_getBlobSidecars", numberID(ref.Number).Arg())
+		if err != nil {
+			p.pool.MoveToNext()
+			errs = append(errs, err)
+		} else {
+			if len(blobSidecars) == 0 {
+				err = ethereum.NotFound
+				errs = append(errs, err)
+				p.pool.MoveToNext()
+			} else {
+				return blobSidecars, nil
+			}
+		}
+	}
+	return nil, errors.Join(errs...)
+}
+
+// DirBlobSidecarsProvider serves sidecars from an on-disk archive.
+// Every L1 block is stored as <dir>/<block-hash>.json, containing the JSON-encoded
+// eth.BSCBlobSidecars, as returned by eth_getBlobSidecars.
+type DirBlobSidecarsProvider struct {
+	name string
+	dir  string
+}
+
+var _ BlobSidecarsProvider = (*DirBlobSidecarsProvider)(nil)
+
+func NewDirBlobSidecarsProvider(name string, dir string) *DirBlobSidecarsProvider {
+	return &DirBlobSidecarsProvider{
+		name: name,
+		dir:  dir,
+	}
+}
+
+func (p *DirBlobSidecarsProvider) Name() string {
+	return p.name
+}
+
+func (p *DirBlobSidecarsProvider) GetBlobSidecars(_ context.Context, ref eth.L1BlockRef, _ []eth.IndexedBlobHash) (eth.BSCBlobSidecars, error) {
+	f, err := os.Open(filepath.Join(p.dir, ref.Hash.Hex()+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("no archived blob sidecars for block %s: %w", ref, ethereum.NotFound)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to open archived blob sidecars for block %s: %w", ref, err)
+	}
+	defer f.Close()
+	var blobSidecars eth.BSCBlobSidecars
+	if err := json.NewDecoder(f).Decode(&blobSidecars); err != nil {
+		return nil, fmt.Errorf("failed to decode archived blob sidecars for block %s: %w", ref, err)
+	}
+	return blobSidecars, nil
+}
+
+// HTTPBlobSidecarsProvider fetches sidecars from a blob-archive service that addresses blobs by versioned hash.
+// The service serves GET <endpoint>/blob_sidecars/<versioned-hash>, and responds with a JSON-encoded
+// eth.BSCBlobSidecar that holds the single blob matching the versioned hash.
+type HTTPBlobSidecarsProvider struct {
+	name string
+	cl   client.HTTP
+}
+
+var _ BlobSidecarsProvider = (*HTTPBlobSidecarsProvider)(nil)
+
+func NewHTTPBlobSidecarsProvider(name string, cl client.HTTP) *HTTPBlobSidecarsProvider {
+	return &HTTPBlobSidecarsProvider{
+		name: name,
+		cl:   cl,
+	}
+}
+
+func (p *HTTPBlobSidecarsProvider) Name() string {
+	return p.name
+}
+
+func (p *HTTPBlobSidecarsProvider) GetBlobSidecars(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) (eth.BSCBlobSidecars, error) {
+	if len(hashes) == 0 {
+		return nil, fmt.Errorf("blob archive can only serve blobs by versioned hash: %w", ethereum.NotFound)
+	}
+	blobSidecars := make(eth.BSCBlobSidecars, 0, len(hashes))
+	for _, h := range hashes {
+		sidecar, err := p.getBlobSidecar(ctx, h)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch blob %s of block %s: %w", h.Hash, ref, err)
+		}
+		blobSidecars = append(blobSidecars, sidecar)
+	}
+	return blobSidecars, nil
+}
+
+func (p *HTTPBlobSidecarsProvider) getBlobSidecar(ctx context.Context, h eth.IndexedBlobHash) (*eth.BSCBlobSidecar, error) {
+	headers := http.Header{}
+	headers.Add("Accept", "application/json")
+	resp, err := p.cl.Get(ctx, path.Join(blobArchiveSidecarsMethodPrefix, h.Hash.Hex()), nil, headers)
+	if err != nil {
+		return nil, fmt.Errorf("http Get failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("failed request with status %d: %w", resp.StatusCode, ethereum.NotFound)
+	} else if resp.StatusCode != http.StatusOK {
+		errMsg, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed request with status %d: %s", resp.StatusCode, string(errMsg))
+	}
+	var sidecar eth.BSCBlobSidecar
+	if err := json.NewDecoder(resp.Body).Decode(&sidecar); err != nil {
+		return nil, err
+	}
+	return &sidecar, nil
+}
+
+func blobProviderResult(err error) string {
+	switch {
+	case err == nil:
+		return BlobProviderResultSuccess
+	case errors.Is(err, ethereum.NotFound):
+		return BlobProviderResultNotFound
+	default:
+		return BlobProviderResultError
+	}
+}
@@ -33,6 +33,18 @@ func (r *RollupClient) SafeHeadAtL1Block(ctx context.Context, blockNum uint64) (
 	return output, err
 }
 
+func (r *RollupClient) SafeHeadUpdates(ctx context.Context, fromL1 uint64, toL1 uint64) ([]eth.SafeHeadResponse, error) {
+	var output []eth.SafeHeadResponse
+	err := r.rpc.CallContext(ctx, &output, "optimism_safeHeadUpdates", hexutil.Uint64(fromL1), hexutil.Uint64(toL1))
+	return output, err
+}
+
+func (r *RollupClient) SafeHeadAtL2Block(ctx context.Context, blockNum uint64) (*eth.SafeHeadResponse, error) {
+	var output *eth.SafeHeadResponse
+	err := r.rpc.CallContext(ctx, &output, "optimism_safeHeadAtL2Block", hexutil.Uint64(blockNum))
+	return output, err
+}
+
 func (r *RollupClient) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
 	var output *eth.SyncStatus
 	err := r.rpc.CallContext(ctx, &output, "optimism_syncStatus")
@@ -71,6 +83,10 @@ func (r *RollupClient) PostUnsafePayload(ctx context.Context, payload *eth.Execu
 	return r.rpc.CallContext(ctx, nil, "admin_postUnsafePayload", payload)
 }
 
+func (r *RollupClient) ImportSafeHeads(ctx context.Context, updates []eth.SafeHeadResponse) error {
+	return r.rpc.CallContext(ctx, nil, "admin_importSafeHeads", updates)
+}
+
 func (r *RollupClient) SetLogLevel(ctx context.Context, lvl slog.Level) error {
 	return r.rpc.CallContext(ctx, nil, "admin_setLogLevel", lvl.String())
 }
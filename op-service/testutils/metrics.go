@@ -61,6 +61,9 @@ func (n *TestDerivationMetrics) RecordDerivedBatches(batchType string) {
 func (n *TestDerivationMetrics) RecordSequencerStepTime(step string, duration time.Duration) {
 }
 
+func (n *TestDerivationMetrics) RecordELSyncProgress(target uint64, current uint64, remaining time.Duration) {
+}
+
 type TestRPCMetrics struct{}
 
 func (n *TestRPCMetrics) RecordRPCServerRequest(method string) func() {
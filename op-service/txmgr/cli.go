@@ -5,9 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"os"
 	"time"
 
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/client"
 	opcrypto "github.com/ethereum-optimism/optimism/op-service/crypto"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/fallbackclient"
@@ -38,6 +40,14 @@ const (
 	TxSendTimeoutFlagName             = "txmgr.send-timeout"
 	TxNotInMempoolTimeoutFlagName     = "txmgr.not-in-mempool-timeout"
 	ReceiptQueryIntervalFlagName      = "txmgr.receipt-query-interval"
+	NonceLeaseFlagName                = "txmgr.nonce-lease"
+	NonceLeaseFileFlagName            = "txmgr.nonce-lease.file"
+	NonceLeaseHolderFlagName          = "txmgr.nonce-lease.holder"
+	NonceLeaseTTLFlagName             = "txmgr.nonce-lease.ttl"
+	NonceLeaseConductorRPCFlagName    = "txmgr.nonce-lease.conductor-rpc"
+	NonceLeaseRedisURLFlagName        = "txmgr.nonce-lease.redis-url"
+	JournalFlagName                   = "txmgr.journal"
+	FeeEstimatorFlagName              = "txmgr.fee-estimator"
 )
 
 var (
@@ -68,6 +78,7 @@ type DefaultFlagValues struct {
 	TxSendTimeout             time.Duration
 	TxNotInMempoolTimeout     time.Duration
 	ReceiptQueryInterval      time.Duration
+	NonceLeaseTTL             time.Duration
 }
 
 var (
@@ -84,6 +95,7 @@ var (
 		TxSendTimeout:             0 * time.Second,
 		TxNotInMempoolTimeout:     2 * time.Minute,
 		ReceiptQueryInterval:      12 * time.Second,
+		NonceLeaseTTL:             30 * time.Second,
 	}
 	DefaultChallengerFlagValues = DefaultFlagValues{
 		NumConfirmations:          uint64(3),
@@ -98,6 +110,7 @@ var (
 		TxSendTimeout:             2 * time.Minute,
 		TxNotInMempoolTimeout:     1 * time.Minute,
 		ReceiptQueryInterval:      12 * time.Second,
+		NonceLeaseTTL:             30 * time.Second,
 	}
 )
 
@@ -197,6 +210,52 @@ func CLIFlagsWithDefaults(envPrefix string, defaults DefaultFlagValues) []cli.Fl
 			Value:   defaults.ReceiptQueryInterval,
 			EnvVars: prefixEnvVars("TXMGR_RECEIPT_QUERY_INTERVAL"),
 		},
+		&cli.StringFlag{
+			Name: NonceLeaseFlagName,
+			Usage: "Nonce lease backend that lets several replicas share the sending key, only the lease holder sends transactions. " +
+				fmt.Sprintf("Valid options: %v", NonceLeaseKinds),
+			Value:   NonceLeaseNone.String(),
+			EnvVars: prefixEnvVars("TXMGR_NONCE_LEASE"),
+		},
+		&cli.StringFlag{
+			Name:    NonceLeaseFileFlagName,
+			Usage:   "Path of the nonce lease file, on storage shared by all replicas. Used by the file nonce lease.",
+			EnvVars: prefixEnvVars("TXMGR_NONCE_LEASE_FILE"),
+		},
+		&cli.StringFlag{
+			Name:    NonceLeaseHolderFlagName,
+			Usage:   "Unique ID of this replica in the nonce lease. Defaults to the hostname.",
+			EnvVars: prefixEnvVars("TXMGR_NONCE_LEASE_HOLDER"),
+		},
+		&cli.DurationFlag{
+			Name:    NonceLeaseTTLFlagName,
+			Usage:   "Duration after which the nonce lease expires if the holder does not renew it. The lease is renewed at a third of the TTL while txs are in flight.",
+			Value:   defaults.NonceLeaseTTL,
+			EnvVars: prefixEnvVars("TXMGR_NONCE_LEASE_TTL"),
+		},
+		&cli.StringFlag{
+			Name:    NonceLeaseConductorRPCFlagName,
+			Usage:   "RPC endpoint of the op-conductor of this replica. Used by the conductor nonce lease.",
+			EnvVars: prefixEnvVars("TXMGR_NONCE_LEASE_CONDUCTOR_RPC"),
+		},
+		&cli.StringFlag{
+			Name:    NonceLeaseRedisURLFlagName,
+			Usage:   "URL of the Redis server shared by all replicas, e.g. redis://host:6379/0. Used by the redis nonce lease.",
+			EnvVars: prefixEnvVars("TXMGR_NONCE_LEASE_REDIS_URL"),
+		},
+		&cli.StringFlag{
+			Name:    JournalFlagName,
+			Usage:   "Path of the journal of transactions in flight, resumed after a restart. Disabled if empty.",
+			EnvVars: prefixEnvVars("TXMGR_JOURNAL"),
+		},
+		&cli.StringFlag{
+			Name: FeeEstimatorFlagName,
+			Usage: "Strategy to suggest tx fees with. 'bsc' derives the gas price from the median gas prices of recent blocks, " +
+				"and raises it by a flat step to replace txs. " +
+				fmt.Sprintf("Valid options: %v", FeeEstimatorKinds),
+			Value:   FeeEstimatorEthereum.String(),
+			EnvVars: prefixEnvVars("TXMGR_FEE_ESTIMATOR"),
+		},
 	}, opsigner.CLIFlags(envPrefix)...)
 }
 
@@ -220,6 +279,14 @@ type CLIConfig struct {
 	NetworkTimeout            time.Duration
 	TxSendTimeout             time.Duration
 	TxNotInMempoolTimeout     time.Duration
+	NonceLease                NonceLeaseKind
+	NonceLeaseFile            string
+	NonceLeaseHolder          string
+	NonceLeaseTTL             time.Duration
+	NonceLeaseConductorRPC    string
+	NonceLeaseRedisURL        string
+	JournalPath               string
+	FeeEstimator              FeeEstimatorKind
 }
 
 func NewCLIConfig(l1RPCURL string, defaults DefaultFlagValues) CLIConfig {
@@ -237,6 +304,9 @@ func NewCLIConfig(l1RPCURL string, defaults DefaultFlagValues) CLIConfig {
 		TxSendTimeout:             defaults.TxSendTimeout,
 		TxNotInMempoolTimeout:     defaults.TxNotInMempoolTimeout,
 		ReceiptQueryInterval:      defaults.ReceiptQueryInterval,
+		NonceLease:                NonceLeaseNone,
+		FeeEstimator:              FeeEstimatorEthereum,
+		NonceLeaseTTL:             defaults.NonceLeaseTTL,
 		SignerCLIConfig:           opsigner.NewCLIConfig(),
 	}
 }
@@ -270,12 +340,48 @@ func (m CLIConfig) Check() error {
 	if m.SafeAbortNonceTooLowCount == 0 {
 		return errors.New("SafeAbortNonceTooLowCount must not be 0")
 	}
+	if err := m.checkNonceLease(); err != nil {
+		return err
+	}
+	if m.FeeEstimator != "" && !ValidFeeEstimatorKind(m.FeeEstimator) {
+		return fmt.Errorf("unknown fee estimator %q, valid options: %v", m.FeeEstimator, FeeEstimatorKinds)
+	}
 	if err := m.SignerCLIConfig.Check(); err != nil {
 		return err
 	}
 	return nil
 }
 
+func (m CLIConfig) checkNonceLease() error {
+	switch m.NonceLease {
+	case "", NonceLeaseNone:
+	case NonceLeaseFile:
+		if m.NonceLeaseFile == "" {
+			return errors.New("must provide NonceLeaseFile for the file nonce lease")
+		}
+		if m.NonceLeaseTTL == 0 {
+			return errors.New("must provide NonceLeaseTTL for the file nonce lease")
+		}
+	case NonceLeaseRedis:
+		if m.NonceLeaseRedisURL == "" {
+			return errors.New("must provide NonceLeaseRedisURL for the redis nonce lease")
+		}
+		if m.NonceLeaseTTL == 0 {
+			return errors.New("must provide NonceLeaseTTL for the redis nonce lease")
+		}
+	case NonceLeaseConductor:
+		if m.NonceLeaseConductorRPC == "" {
+			return errors.New("must provide NonceLeaseConductorRPC for the conductor nonce lease")
+		}
+		if m.NonceLeaseTTL == 0 {
+			return errors.New("must provide NonceLeaseTTL for the conductor nonce lease")
+		}
+	default:
+		return fmt.Errorf("unknown nonce lease %q, valid options: %v", m.NonceLease, NonceLeaseKinds)
+	}
+	return nil
+}
+
 func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 	return CLIConfig{
 		L1RPCURL:                  ctx.String(L1RPCFlagName),
@@ -297,6 +403,14 @@ func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 		NetworkTimeout:            ctx.Duration(NetworkTimeoutFlagName),
 		TxSendTimeout:             ctx.Duration(TxSendTimeoutFlagName),
 		TxNotInMempoolTimeout:     ctx.Duration(TxNotInMempoolTimeoutFlagName),
+		NonceLease:                NonceLeaseKind(ctx.String(NonceLeaseFlagName)),
+		NonceLeaseFile:            ctx.String(NonceLeaseFileFlagName),
+		NonceLeaseHolder:          ctx.String(NonceLeaseHolderFlagName),
+		NonceLeaseTTL:             ctx.Duration(NonceLeaseTTLFlagName),
+		NonceLeaseConductorRPC:    ctx.String(NonceLeaseConductorRPCFlagName),
+		NonceLeaseRedisURL:        ctx.String(NonceLeaseRedisURLFlagName),
+		JournalPath:               ctx.String(JournalFlagName),
+		FeeEstimator:              FeeEstimatorKind(ctx.String(FeeEstimatorFlagName)),
 	}
 }
 
@@ -353,6 +467,24 @@ func NewConfig(cfg CLIConfig, l log.Logger, m txmetrics.TxMetricer) (Config, err
 		return Config{}, fmt.Errorf("invalid min tip cap: %w", err)
 	}
 
+	nonceLease, err := newNonceLease(cfg, l, from)
+	if err != nil {
+		return Config{}, fmt.Errorf("could not init nonce lease: %w", err)
+	}
+
+	var feeEstimator FeeEstimator = NewEthereumFeeEstimator(l1, cfg.NetworkTimeout)
+	if cfg.FeeEstimator == FeeEstimatorBSC {
+		feeEstimator = NewBSCFeeEstimator(l1, cfg.NetworkTimeout)
+	}
+
+	var journal *TxJournal
+	if cfg.JournalPath != "" {
+		journal, err = NewTxJournal(cfg.JournalPath)
+		if err != nil {
+			return Config{}, fmt.Errorf("could not open tx journal: %w", err)
+		}
+	}
+
 	return Config{
 		Backend:                   l1,
 		ResubmissionTimeout:       cfg.ResubmissionTimeout,
@@ -370,9 +502,57 @@ func NewConfig(cfg CLIConfig, l log.Logger, m txmetrics.TxMetricer) (Config, err
 		SafeAbortNonceTooLowCount: cfg.SafeAbortNonceTooLowCount,
 		Signer:                    signerFactory(chainID),
 		From:                      from,
+		NonceLease:                nonceLease,
+		NonceLeaseRenewInterval:   cfg.NonceLeaseTTL / 3,
+		Journal:                   journal,
+		FeeEstimator:              feeEstimator,
 	}, nil
 }
 
+func newNonceLease(cfg CLIConfig, l log.Logger, from common.Address) (NonceLease, error) {
+	switch cfg.NonceLease {
+	case NonceLeaseFile:
+		holder, err := nonceLeaseHolder(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return NewFileNonceLease(cfg.NonceLeaseFile, holder, cfg.NonceLeaseTTL), nil
+	case NonceLeaseRedis:
+		holder, err := nonceLeaseHolder(cfg)
+		if err != nil {
+			return nil, err
+		}
+		store, err := NewRedisLeaseStore(cfg.NonceLeaseRedisURL)
+		if err != nil {
+			return nil, err
+		}
+		// replicas sharing the sending key share the lease
+		return NewStoreNonceLease(store, "txmgr-nonce-lease:"+from.Hex(), holder, cfg.NonceLeaseTTL), nil
+	case NonceLeaseConductor:
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.NetworkTimeout)
+		defer cancel()
+		rpc, err := client.NewRPC(ctx, l, cfg.NonceLeaseConductorRPC)
+		if err != nil {
+			return nil, fmt.Errorf("could not dial conductor: %w", err)
+		}
+		return NewConductorNonceLease(rpc, cfg.NonceLeaseTTL), nil
+	default:
+		return nil, nil
+	}
+}
+
+// nonceLeaseHolder returns the configured nonce lease holder, which defaults to the hostname.
+func nonceLeaseHolder(cfg CLIConfig) (string, error) {
+	if cfg.NonceLeaseHolder != "" {
+		return cfg.NonceLeaseHolder, nil
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", fmt.Errorf("could not determine nonce lease holder: %w", err)
+	}
+	return hostname, nil
+}
+
 // Config houses parameters for altering the behavior of a SimpleTxManager.
 type Config struct {
 	Backend ETHBackend
@@ -432,6 +612,20 @@ type Config struct {
 	// Signer is used to sign transactions when the gas price is increased.
 	Signer opcrypto.SignerFn
 	From   common.Address
+
+	// NonceLease (optional) coordinates several replicas sharing the From key.
+	// If set, transactions are only signed while the lease is held.
+	NonceLease NonceLease
+
+	// NonceLeaseRenewInterval is the interval at which the nonce lease is renewed while txs are in flight.
+	// It must be well below the TTL of the lease.
+	NonceLeaseRenewInterval time.Duration
+
+	// Journal (optional) persists the txs in flight, to resume them when the tx manager is restarted.
+	Journal *TxJournal
+
+	// FeeEstimator suggests the tx fees. The EthereumFeeEstimator is used if nil.
+	FeeEstimator FeeEstimator
 }
 
 func (m Config) Check() error {
@@ -469,5 +663,8 @@ func (m Config) Check() error {
 	if m.ChainID == nil {
 		return errors.New("must provide the ChainID")
 	}
+	if m.NonceLease != nil && m.NonceLeaseRenewInterval == 0 {
+		return errors.New("must provide NonceLeaseRenewInterval for the nonce lease")
+	}
 	return nil
 }
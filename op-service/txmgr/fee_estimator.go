@@ -0,0 +1,214 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/ethereum-optimism/optimism/op-service/bsc"
+)
+
+// FeeEstimatorKind identifies a FeeEstimator strategy, used to configure the fee estimation from the CLI.
+type FeeEstimatorKind string
+
+const (
+	FeeEstimatorEthereum FeeEstimatorKind = "ethereum"
+	FeeEstimatorBSC      FeeEstimatorKind = "bsc"
+)
+
+var FeeEstimatorKinds = []FeeEstimatorKind{
+	FeeEstimatorEthereum,
+	FeeEstimatorBSC,
+}
+
+func (kind FeeEstimatorKind) String() string {
+	return string(kind)
+}
+
+func ValidFeeEstimatorKind(value FeeEstimatorKind) bool {
+	for _, k := range FeeEstimatorKinds {
+		if k == value {
+			return true
+		}
+	}
+	return false
+}
+
+// FeeEstimator suggests the fees of the txs crafted by the tx manager, and of their replacements.
+// The tx manager enforces the configured minimums and limits on top of the suggestions.
+type FeeEstimator interface {
+	// SuggestGasPriceCaps returns the suggested gas tip cap and base fee,
+	// and the blob base fee, which is nil if the L1 doesn't support blobs yet.
+	SuggestGasPriceCaps(ctx context.Context) (tip *big.Int, baseFee *big.Int, blobBaseFee *big.Int, err error)
+}
+
+// FeeBumper is implemented by the FeeEstimators of chains that replace txs differently than updateFees does.
+type FeeBumper interface {
+	// BumpFees returns the tip and fee cap of the replacement of a tx with the given old tip and fee cap,
+	// given the newly suggested tip and base fee.
+	BumpFees(oldTip, oldFeeCap, newTip, newBaseFee *big.Int, isBlobTx bool) (tip *big.Int, feeCap *big.Int)
+}
+
+// EthereumFeeEstimator follows the EIP-1559 fee market, with the tip suggested by the node.
+// The base fee is taken as 0 since BSC blocks have none, so only the configured minimum base fee applies.
+type EthereumFeeEstimator struct {
+	backend        ETHBackend
+	networkTimeout time.Duration
+}
+
+var _ FeeEstimator = (*EthereumFeeEstimator)(nil)
+
+func NewEthereumFeeEstimator(backend ETHBackend, networkTimeout time.Duration) *EthereumFeeEstimator {
+	return &EthereumFeeEstimator{
+		backend:        backend,
+		networkTimeout: networkTimeout,
+	}
+}
+
+func (e *EthereumFeeEstimator) SuggestGasPriceCaps(ctx context.Context) (*big.Int, *big.Int, *big.Int, error) {
+	cCtx, cancel := context.WithTimeout(ctx, e.networkTimeout)
+	defer cancel()
+	tip, err := e.backend.SuggestGasTipCap(cCtx)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to fetch the suggested gas tip cap: %w", err)
+	} else if tip == nil {
+		return nil, nil, nil, errors.New("the suggested tip was nil")
+	}
+	cCtx, cancel = context.WithTimeout(ctx, e.networkTimeout)
+	defer cancel()
+	head, err := e.backend.HeaderByNumber(cCtx, nil)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to fetch the suggested base fee: %w", err)
+	}
+	// basefee of BSC block is 0
+	baseFee := big.NewInt(0)
+	return tip, baseFee, blobBaseFee(head), nil
+}
+
+// BlockBackend is the subset of the L1 client used by the BSCFeeEstimator.
+type BlockBackend interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error)
+}
+
+// bscGasPriceBumpStep is the flat increase of the gas price of a replaced tx on BSC.
+var bscGasPriceBumpStep = big.NewInt(params.GWei / 10)
+
+// BSCFeeEstimator prices txs like opBNB prices its L1 data: BSC blocks have no base fee,
+// so the tip is the median of the median gas prices of the last bsc.CountBlockSize blocks.
+// The median gas price of every block is cached, like bsc.BlockInfoCache.
+// Replacements raise the gas price by a flat step, instead of doubling the base fee into the fee cap.
+type BSCFeeEstimator struct {
+	backend        BlockBackend
+	networkTimeout time.Duration
+	blockInfos     *lru.Cache[common.Hash, bsc.BlockInfo]
+
+	mu sync.Mutex
+	// latest head the gas price got computed for, and the resulting gas price
+	latestHead     common.Hash
+	latestGasPrice *big.Int
+}
+
+var (
+	_ FeeEstimator = (*BSCFeeEstimator)(nil)
+	_ FeeBumper    = (*BSCFeeEstimator)(nil)
+)
+
+func NewBSCFeeEstimator(backend BlockBackend, networkTimeout time.Duration) *BSCFeeEstimator {
+	blockInfos, _ := lru.New[common.Hash, bsc.BlockInfo](bsc.BlockInfoCacheCap)
+	return &BSCFeeEstimator{
+		backend:        backend,
+		networkTimeout: networkTimeout,
+		blockInfos:     blockInfos,
+	}
+}
+
+func (e *BSCFeeEstimator) SuggestGasPriceCaps(ctx context.Context) (*big.Int, *big.Int, *big.Int, error) {
+	cCtx, cancel := context.WithTimeout(ctx, e.networkTimeout)
+	defer cancel()
+	head, err := e.backend.HeaderByNumber(cCtx, nil)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to fetch the latest header: %w", err)
+	}
+	gasPrice, err := e.gasPrice(ctx, head)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return gasPrice, big.NewInt(0), blobBaseFee(head), nil
+}
+
+// BumpFees raises the gas price of the replacement by bscGasPriceBumpStep, or to the newly suggested tip if that
+// is higher. It is still raised by at least the price bump that the tx pool requires for replacements.
+// BSC blocks have no base fee, so the fee cap is the gas price plus the configured minimum base fee, if any.
+func (e *BSCFeeEstimator) BumpFees(oldTip, oldFeeCap, newTip, newBaseFee *big.Int, isBlobTx bool) (*big.Int, *big.Int) {
+	tip := new(big.Int).Add(oldTip, bscGasPriceBumpStep)
+	tip = bigMax(tip, calcThresholdValue(oldTip, isBlobTx), newTip)
+	feeCap := new(big.Int).Add(tip, newBaseFee)
+	feeCap = bigMax(feeCap, calcThresholdValue(oldFeeCap, isBlobTx))
+	return tip, feeCap
+}
+
+// gasPrice returns the median of the median gas prices of the last bsc.CountBlockSize blocks up to head.
+func (e *BSCFeeEstimator) gasPrice(ctx context.Context, head *types.Header) (*big.Int, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.latestHead == head.Hash() {
+		return new(big.Int).Set(e.latestGasPrice), nil
+	}
+	// near genesis, there are not enough blocks to take the median of
+	if head.Number.Uint64() < bsc.CountBlockSize-1 {
+		return new(big.Int).Set(bsc.DefaultBaseFee), nil
+	}
+
+	var allMedianGasPrice []*big.Int
+	blockHash := head.Hash()
+	for len(allMedianGasPrice) < bsc.CountBlockSize {
+		if blockInfo, ok := e.blockInfos.Get(blockHash); ok {
+			allMedianGasPrice = append(allMedianGasPrice, blockInfo.MedianGasPrice)
+			blockHash = blockInfo.ParentHash
+			continue
+		}
+		cCtx, cancel := context.WithTimeout(ctx, e.networkTimeout)
+		block, err := e.backend.BlockByHash(cCtx, blockHash)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch block %s: %w", blockHash, err)
+		}
+		medianGasPrice := bsc.MedianGasPrice(block.Transactions())
+		allMedianGasPrice = append(allMedianGasPrice, medianGasPrice)
+		e.blockInfos.Add(block.Hash(), bsc.BlockInfo{
+			BlockHash:      block.Hash(),
+			ParentHash:     block.ParentHash(),
+			MedianGasPrice: medianGasPrice,
+		})
+		blockHash = block.ParentHash()
+	}
+	e.latestHead = head.Hash()
+	e.latestGasPrice = bsc.FinalGasPrice(allMedianGasPrice)
+	return new(big.Int).Set(e.latestGasPrice), nil
+}
+
+func bigMax(x *big.Int, ys ...*big.Int) *big.Int {
+	for _, y := range ys {
+		if y.Cmp(x) > 0 {
+			x = y
+		}
+	}
+	return x
+}
+
+func blobBaseFee(head *types.Header) *big.Int {
+	if head.ExcessBlobGas == nil {
+		return nil
+	}
+	return eip4844.CalcBlobFee(*head.ExcessBlobGas)
+}
@@ -0,0 +1,182 @@
+package txmgr
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/bsc"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
+)
+
+type testBlockBackend struct {
+	blocks  map[common.Hash]*types.Block
+	head    *types.Block
+	fetches int
+}
+
+func (b *testBlockBackend) HeaderByNumber(_ context.Context, _ *big.Int) (*types.Header, error) {
+	return b.head.Header(), nil
+}
+
+func (b *testBlockBackend) BlockByHash(_ context.Context, hash common.Hash) (*types.Block, error) {
+	b.fetches++
+	block, ok := b.blocks[hash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return block, nil
+}
+
+// addBlock adds a block on top of the head, with txs of the given gas prices in gwei.
+func (b *testBlockBackend) addBlock(gasPrices ...int64) {
+	header := &types.Header{Number: big.NewInt(0)}
+	if b.head != nil {
+		header.ParentHash = b.head.Hash()
+		header.Number = new(big.Int).Add(b.head.Number(), common.Big1)
+	}
+	var txs []*types.Transaction
+	for i, gasPrice := range gasPrices {
+		txs = append(txs, types.NewTx(&types.LegacyTx{Nonce: uint64(i), GasPrice: big.NewInt(gasPrice * 1e9)}))
+	}
+	block := types.NewBlockWithHeader(header).WithBody(txs, nil)
+	b.blocks[block.Hash()] = block
+	b.head = block
+}
+
+func TestBSCFeeEstimator(t *testing.T) {
+	ctx := context.Background()
+	backend := &testBlockBackend{blocks: make(map[common.Hash]*types.Block)}
+	backend.addBlock()
+	e := NewBSCFeeEstimator(backend, time.Second)
+
+	// near genesis, the default gas price is used
+	tip, baseFee, blobFee, err := e.SuggestGasPriceCaps(ctx)
+	require.NoError(t, err)
+	require.Equal(t, bsc.DefaultBaseFee, tip)
+	require.Zero(t, baseFee.Sign())
+	require.Nil(t, blobFee)
+
+	for i := 0; i < bsc.CountBlockSize; i++ {
+		// block medians are 1, 2 or 5 gwei, zero gas price txs are ignored
+		switch i % 3 {
+		case 0:
+			backend.addBlock(0, 1, 1, 100)
+		case 1:
+			backend.addBlock(2)
+		default:
+			backend.addBlock(5, 1, 5)
+		}
+	}
+	backend.fetches = 0
+	tip, _, _, err = e.SuggestGasPriceCaps(ctx)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(2e9), tip)
+	require.Equal(t, bsc.CountBlockSize, backend.fetches)
+
+	// only the new block is fetched once the head moves
+	backend.addBlock(5)
+	_, _, _, err = e.SuggestGasPriceCaps(ctx)
+	require.NoError(t, err)
+	require.Equal(t, bsc.CountBlockSize+1, backend.fetches)
+	_, _, _, err = e.SuggestGasPriceCaps(ctx)
+	require.NoError(t, err)
+	require.Equal(t, bsc.CountBlockSize+1, backend.fetches)
+}
+
+func TestBSCFeeEstimatorBumpFees(t *testing.T) {
+	gwei := func(tenths int64) *big.Int { return big.NewInt(tenths * params.GWei / 10) }
+	tests := []struct {
+		name               string
+		oldTip, oldFeeCap  *big.Int
+		newTip, newBaseFee *big.Int
+		isBlobTx           bool
+		expTip, expFeeCap  *big.Int
+	}{
+		{
+			name:   "flat step",
+			oldTip: gwei(5), oldFeeCap: gwei(5),
+			newTip: gwei(5), newBaseFee: gwei(0),
+			expTip: gwei(6), expFeeCap: gwei(6),
+		},
+		{
+			name:   "required price bump",
+			oldTip: gwei(30), oldFeeCap: gwei(30),
+			newTip: gwei(30), newBaseFee: gwei(0),
+			expTip: gwei(33), expFeeCap: gwei(33),
+		},
+		{
+			name:   "new tip",
+			oldTip: gwei(10), oldFeeCap: gwei(10),
+			newTip: gwei(20), newBaseFee: gwei(0),
+			expTip: gwei(20), expFeeCap: gwei(20),
+		},
+		{
+			name:   "base fee is not doubled",
+			oldTip: gwei(10), oldFeeCap: gwei(10),
+			newTip: gwei(10), newBaseFee: gwei(10),
+			expTip: gwei(11), expFeeCap: gwei(21),
+		},
+		{
+			name:   "blob tx",
+			oldTip: gwei(10), oldFeeCap: gwei(10),
+			newTip: gwei(10), newBaseFee: gwei(0),
+			isBlobTx: true,
+			expTip:   gwei(20), expFeeCap: gwei(20),
+		},
+	}
+	e := NewBSCFeeEstimator(&testBlockBackend{}, time.Second)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tip, feeCap := e.BumpFees(test.oldTip, test.oldFeeCap, test.newTip, test.newBaseFee, test.isBlobTx)
+			require.Equal(t, test.expTip, tip)
+			require.Equal(t, test.expFeeCap, feeCap)
+		})
+	}
+}
+
+func TestIncreaseGasPriceBSC(t *testing.T) {
+	backend := &testBlockBackend{blocks: make(map[common.Hash]*types.Block)}
+	backend.addBlock()
+	mgr := &SimpleTxManager{
+		cfg: Config{
+			NumConfirmations:          1,
+			SafeAbortNonceTooLowCount: 3,
+			FeeLimitMultiplier:        5,
+			NetworkTimeout:            time.Second,
+			FeeEstimator:              NewBSCFeeEstimator(backend, time.Second),
+			Signer: func(ctx context.Context, from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+				return tx, nil
+			},
+		},
+		name:    "TEST",
+		backend: &failingBackend{baseFee: big.NewInt(21_000)},
+		l:       testlog.Logger(t, log.LevelCrit),
+		metr:    &metrics.NoopTxMetrics{},
+	}
+
+	// the suggested gas price near genesis is bsc.DefaultBaseFee
+	tx := types.NewTx(&types.DynamicFeeTx{GasTipCap: bsc.DefaultBaseFee, GasFeeCap: bsc.DefaultBaseFee})
+	newTx, err := mgr.increaseGasPrice(context.Background(), tx)
+	require.NoError(t, err)
+	expected := calcThresholdValue(bsc.DefaultBaseFee, false)
+	require.Equal(t, expected, newTx.GasTipCap())
+	require.Equal(t, expected, newTx.GasFeeCap(), "the fee cap equals the gas price")
+
+	// replacements by Cancel and Replace are bumped the same way, the min base fee is not doubled either
+	mgr.cfg.MinBaseFee = big.NewInt(params.GWei)
+	to := common.Address{0xaa}
+	replacement, err := mgr.craftTxMessage(context.Background(), TxCandidate{To: &to, GasLimit: 21_000}, tx)
+	require.NoError(t, err)
+	require.Equal(t, expected, replacement.(*types.DynamicFeeTx).GasTipCap)
+	require.Equal(t, new(big.Int).Add(expected, mgr.cfg.MinBaseFee), replacement.(*types.DynamicFeeTx).GasFeeCap)
+}
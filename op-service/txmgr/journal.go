@@ -0,0 +1,153 @@
+package txmgr
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+const txJournalVersion = 1
+
+// TxJournal persists the transactions that are published but not yet mined,
+// so a restarted tx manager can resume their submission instead of colliding on their nonces.
+type TxJournal struct {
+	path string
+
+	mu  sync.Mutex
+	txs map[uint64]*JournaledTx
+}
+
+// JournaledTx is a transaction in flight, keyed by its nonce, along with its SendState.
+type JournaledTx struct {
+	// Tx is the binary encoding of the latest published version of the tx.
+	Tx hexutil.Bytes `json:"tx"`
+	// Hashes are the hashes of all published versions of the tx, any of which may get mined.
+	Hashes                 []common.Hash `json:"hashes"`
+	BumpCount              int           `json:"bumpCount"`
+	SuccessfulPublishCount uint64        `json:"successfulPublishCount"`
+	NonceTooLowCount       uint64        `json:"nonceTooLowCount"`
+}
+
+type txJournalState struct {
+	Version int            `json:"version"`
+	Txs     []*JournaledTx `json:"txs"`
+}
+
+// NewTxJournal opens the journal at the given path, loading the txs of a previous run if any.
+func NewTxJournal(path string) (*TxJournal, error) {
+	j := &TxJournal{
+		path: path,
+		txs:  make(map[uint64]*JournaledTx),
+	}
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return j, nil
+	}
+	state, err := jsonutil.LoadJSON[txJournalState](path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tx journal: %w", err)
+	}
+	if state.Version != txJournalVersion {
+		return nil, fmt.Errorf("unsupported tx journal version %d", state.Version)
+	}
+	for _, jtx := range state.Txs {
+		tx, err := jtx.Transaction()
+		if err != nil {
+			return nil, err
+		}
+		j.txs[tx.Nonce()] = jtx
+	}
+	return j, nil
+}
+
+// Transaction decodes the latest published version of the tx.
+func (jtx *JournaledTx) Transaction() (*types.Transaction, error) {
+	var tx types.Transaction
+	if err := tx.UnmarshalBinary(jtx.Tx); err != nil {
+		return nil, fmt.Errorf("failed to decode journaled tx: %w", err)
+	}
+	return &tx, nil
+}
+
+// Txs returns the journaled txs, ordered by nonce.
+func (j *TxJournal) Txs() []*JournaledTx {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.sortedTxs()
+}
+
+// Tx returns the journaled tx at the given nonce, or nil if there is none.
+func (j *TxJournal) Tx(nonce uint64) *JournaledTx {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.txs[nonce]
+}
+
+// Published records a newly published version of the tx at the nonce of tx.
+func (j *TxJournal) Published(tx *types.Transaction, sendState *SendState) error {
+	data, err := tx.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("failed to encode tx: %w", err)
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	jtx, ok := j.txs[tx.Nonce()]
+	if !ok {
+		jtx = &JournaledTx{}
+		j.txs[tx.Nonce()] = jtx
+	}
+	jtx.Tx = data
+	if len(jtx.Hashes) == 0 || jtx.Hashes[len(jtx.Hashes)-1] != tx.Hash() {
+		jtx.Hashes = append(jtx.Hashes, tx.Hash())
+	}
+	sendState.mu.RLock()
+	jtx.BumpCount = sendState.bumpCount
+	jtx.SuccessfulPublishCount = sendState.successFullPublishCount
+	jtx.NonceTooLowCount = sendState.nonceTooLowCount
+	sendState.mu.RUnlock()
+	return j.write()
+}
+
+// Remove forgets the tx at the given nonce, once it got mined or its submission was given up.
+func (j *TxJournal) Remove(nonce uint64) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if _, ok := j.txs[nonce]; !ok {
+		return nil
+	}
+	delete(j.txs, nonce)
+	return j.write()
+}
+
+func (j *TxJournal) sortedTxs() []*JournaledTx {
+	nonces := make([]uint64, 0, len(j.txs))
+	for nonce := range j.txs {
+		nonces = append(nonces, nonce)
+	}
+	sort.Slice(nonces, func(i, k int) bool { return nonces[i] < nonces[k] })
+	txs := make([]*JournaledTx, 0, len(nonces))
+	for _, nonce := range nonces {
+		txs = append(txs, j.txs[nonce])
+	}
+	return txs
+}
+
+func (j *TxJournal) write() error {
+	return jsonutil.WriteJSON(j.path, &txJournalState{Version: txJournalVersion, Txs: j.sortedTxs()}, 0o600)
+}
+
+// restore applies the journaled counters to a fresh SendState.
+func (jtx *JournaledTx) restore(sendState *SendState) {
+	sendState.mu.Lock()
+	defer sendState.mu.Unlock()
+	sendState.bumpCount = jtx.BumpCount
+	sendState.successFullPublishCount = jtx.SuccessfulPublishCount
+	sendState.nonceTooLowCount = jtx.NonceTooLowCount
+}
@@ -0,0 +1,64 @@
+package txmgr
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxMgrJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "txs.json")
+	journal, err := NewTxJournal(path)
+	require.NoError(t, err)
+	cfg := configWithNumConfs(1)
+	cfg.NetworkTimeout = time.Second
+	cfg.Journal = journal
+	h := newTestHarnessWithConfig(t, cfg)
+
+	gasTipCap, gasFeeCap, _ := h.gasPricer.sample()
+	pending := types.NewTx(&types.DynamicFeeTx{Nonce: 3, GasTipCap: gasTipCap, GasFeeCap: gasFeeCap})
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error { return nil })
+
+	// the tx stays journaled if the send is cancelled
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = h.mgr.sendTx(ctx, pending, nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	reopened, err := NewTxJournal(path)
+	require.NoError(t, err)
+	txs := reopened.Txs()
+	require.Len(t, txs, 1)
+	require.Equal(t, pending.Hash(), txs[0].Hashes[0])
+
+	// and is resumed by the next tx manager
+	mined := types.NewTx(&types.DynamicFeeTx{Nonce: 4, GasTipCap: gasTipCap, GasFeeCap: gasFeeCap})
+	minedHash := mined.Hash()
+	require.NoError(t, reopened.Published(mined, testSendState()))
+
+	cfg.Journal = reopened
+	resumed := newTestHarnessWithConfig(t, cfg)
+	resumed.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		txHash := tx.Hash()
+		resumed.backend.mine(&txHash, tx.GasFeeCap(), nil)
+		return nil
+	})
+	resumed.backend.mine(&minedHash, gasFeeCap, nil)
+	require.NoError(t, resumed.mgr.resumeJournaled())
+	require.Equal(t, uint64(4), *resumed.mgr.nonce)
+	require.Eventually(t, func() bool {
+		return len(reopened.Txs()) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// closing the tx manager stops the resumed sends, and the txs stay journaled
+	require.NoError(t, reopened.Published(pending, testSendState()))
+	stopped := newTestHarnessWithConfig(t, cfg)
+	stopped.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error { return nil })
+	require.NoError(t, stopped.mgr.resumeJournaled())
+	stopped.mgr.Close()
+	require.Zero(t, stopped.mgr.pending.Load())
+	require.Len(t, reopened.Txs(), 1)
+}
@@ -0,0 +1,343 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+// NonceLeaseKind identifies a NonceLease backend, used to configure the nonce lease from the CLI.
+type NonceLeaseKind string
+
+const (
+	NonceLeaseNone      NonceLeaseKind = "none"
+	NonceLeaseFile      NonceLeaseKind = "file"
+	NonceLeaseRedis     NonceLeaseKind = "redis"
+	NonceLeaseConductor NonceLeaseKind = "conductor"
+)
+
+var NonceLeaseKinds = []NonceLeaseKind{
+	NonceLeaseNone,
+	NonceLeaseFile,
+	NonceLeaseRedis,
+	NonceLeaseConductor,
+}
+
+func (kind NonceLeaseKind) String() string {
+	return string(kind)
+}
+
+func ValidNonceLeaseKind(value NonceLeaseKind) bool {
+	for _, k := range NonceLeaseKinds {
+		if k == value {
+			return true
+		}
+	}
+	return false
+}
+
+var ErrNonceLeaseHeld = errors.New("nonce lease is held by another replica")
+
+// NonceLease coordinates several tx manager replicas that share a single sending key.
+// Only the replica holding the lease signs transactions, so a standby replica can take over
+// the key without racing the nonces of the active one.
+type NonceLease interface {
+	// Acquire acquires the lease, or renews it if it is already held by this replica.
+	// It returns whether the lease was newly granted, rather than renewed. A newly granted lease
+	// may have been held by another replica in the meantime, which may have used the key.
+	// ErrNonceLeaseHeld is returned if another replica holds the lease.
+	Acquire(ctx context.Context) (granted bool, err error)
+	// Release gives up the lease if it is held by this replica,
+	// so a standby replica can take over without waiting for the lease to expire.
+	Release(ctx context.Context) error
+}
+
+// fileLeaseState is the content of the lease file of a FileNonceLease.
+type fileLeaseState struct {
+	Holder string    `json:"holder"`
+	Expiry time.Time `json:"expiry"`
+}
+
+// FileNonceLease is a NonceLease backed by a file on storage shared by all replicas,
+// e.g. a network file system. The lease file is guarded by an adjacent lock file,
+// and the lease expires if it is not renewed within the TTL.
+type FileNonceLease struct {
+	path   string
+	lock   *flock.Flock
+	holder string
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+var _ NonceLease = (*FileNonceLease)(nil)
+
+func NewFileNonceLease(path string, holder string, ttl time.Duration) *FileNonceLease {
+	return &FileNonceLease{
+		path:   path,
+		lock:   flock.New(path + ".lock"),
+		holder: holder,
+		ttl:    ttl,
+		now:    time.Now,
+	}
+}
+
+func (l *FileNonceLease) Acquire(ctx context.Context) (bool, error) {
+	var granted bool
+	err := l.update(ctx, func(state *fileLeaseState) (*fileLeaseState, error) {
+		valid := state != nil && l.now().Before(state.Expiry)
+		if valid && state.Holder != l.holder {
+			return nil, fmt.Errorf("%w: %s until %s", ErrNonceLeaseHeld, state.Holder, state.Expiry)
+		}
+		// an expired lease is granted anew, even if this replica held it last
+		granted = !valid
+		return &fileLeaseState{Holder: l.holder, Expiry: l.now().Add(l.ttl)}, nil
+	})
+	return granted, err
+}
+
+func (l *FileNonceLease) Release(ctx context.Context) error {
+	return l.update(ctx, func(state *fileLeaseState) (*fileLeaseState, error) {
+		if state == nil || state.Holder != l.holder {
+			return state, nil
+		}
+		return nil, nil
+	})
+}
+
+// update applies fn to the lease state while holding the file lock.
+// A nil state means there is no lease. The lease file is removed if fn returns a nil state.
+func (l *FileNonceLease) update(ctx context.Context, fn func(state *fileLeaseState) (*fileLeaseState, error)) error {
+	locked, err := l.lock.TryLockContext(ctx, 10*time.Millisecond)
+	if err != nil {
+		return fmt.Errorf("failed to lock nonce lease file: %w", err)
+	} else if !locked {
+		return errors.New("failed to lock nonce lease file")
+	}
+	defer l.lock.Unlock()
+
+	var state *fileLeaseState
+	if _, err := os.Stat(l.path); err == nil {
+		state, err = jsonutil.LoadJSON[fileLeaseState](l.path)
+		if err != nil {
+			return fmt.Errorf("failed to read nonce lease file: %w", err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read nonce lease file: %w", err)
+	}
+
+	next, err := fn(state)
+	if err != nil {
+		return err
+	}
+	if next == nil {
+		if err := os.Remove(l.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove nonce lease file: %w", err)
+		}
+		return nil
+	}
+	if next == state {
+		return nil
+	}
+	return jsonutil.WriteJSON(l.path, next, 0o600)
+}
+
+// LeaseStore is a key-value store with the atomic primitives needed to hold a NonceLease,
+// like the RedisLeaseStore.
+type LeaseStore interface {
+	// SetIfAbsent sets the key to value with the given TTL if the key is not set or expired.
+	// It returns whether the key was set.
+	SetIfAbsent(ctx context.Context, key string, value string, ttl time.Duration) (bool, error)
+	// CompareAndRefresh resets the TTL of the key if it is set to value.
+	// It returns whether the key was refreshed.
+	CompareAndRefresh(ctx context.Context, key string, value string, ttl time.Duration) (bool, error)
+	// CompareAndDelete deletes the key if it is set to value.
+	CompareAndDelete(ctx context.Context, key string, value string) error
+}
+
+// StoreNonceLease is a NonceLease held in a LeaseStore, under a key shared by all replicas.
+type StoreNonceLease struct {
+	store  LeaseStore
+	key    string
+	holder string
+	ttl    time.Duration
+}
+
+var _ NonceLease = (*StoreNonceLease)(nil)
+
+func NewStoreNonceLease(store LeaseStore, key string, holder string, ttl time.Duration) *StoreNonceLease {
+	return &StoreNonceLease{
+		store:  store,
+		key:    key,
+		holder: holder,
+		ttl:    ttl,
+	}
+}
+
+func (l *StoreNonceLease) Acquire(ctx context.Context) (bool, error) {
+	if ok, err := l.store.CompareAndRefresh(ctx, l.key, l.holder, l.ttl); err != nil {
+		return false, fmt.Errorf("failed to renew nonce lease: %w", err)
+	} else if ok {
+		return false, nil
+	}
+	if ok, err := l.store.SetIfAbsent(ctx, l.key, l.holder, l.ttl); err != nil {
+		return false, fmt.Errorf("failed to acquire nonce lease: %w", err)
+	} else if !ok {
+		return false, ErrNonceLeaseHeld
+	}
+	return true, nil
+}
+
+func (l *StoreNonceLease) Release(ctx context.Context) error {
+	return l.store.CompareAndDelete(ctx, l.key, l.holder)
+}
+
+type memoryLeaseEntry struct {
+	value  string
+	expiry time.Time
+}
+
+// MemoryLeaseStore is an in-memory LeaseStore. It stands in for the RedisLeaseStore
+// when all replicas run in the same process, e.g. in tests.
+type MemoryLeaseStore struct {
+	mu      sync.Mutex
+	entries map[string]memoryLeaseEntry
+	now     func() time.Time
+}
+
+var _ LeaseStore = (*MemoryLeaseStore)(nil)
+
+func NewMemoryLeaseStore() *MemoryLeaseStore {
+	return &MemoryLeaseStore{
+		entries: make(map[string]memoryLeaseEntry),
+		now:     time.Now,
+	}
+}
+
+func (s *MemoryLeaseStore) SetIfAbsent(_ context.Context, key string, value string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.entries[key]; ok && s.now().Before(e.expiry) {
+		return false, nil
+	}
+	s.entries[key] = memoryLeaseEntry{value: value, expiry: s.now().Add(ttl)}
+	return true, nil
+}
+
+func (s *MemoryLeaseStore) CompareAndRefresh(_ context.Context, key string, value string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.entries[key]; !ok || e.value != value || !s.now().Before(e.expiry) {
+		return false, nil
+	}
+	s.entries[key] = memoryLeaseEntry{value: value, expiry: s.now().Add(ttl)}
+	return true, nil
+}
+
+func (s *MemoryLeaseStore) CompareAndDelete(_ context.Context, key string, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.entries[key]; ok && e.value == value {
+		delete(s.entries, key)
+	}
+	return nil
+}
+
+// RedisLeaseStore is a LeaseStore backed by Redis, shared by replicas running on different hosts.
+type RedisLeaseStore struct {
+	client *redis.Client
+}
+
+var _ LeaseStore = (*RedisLeaseStore)(nil)
+
+var (
+	compareAndRefreshScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+	compareAndDeleteScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+)
+
+func NewRedisLeaseStore(url string) (*RedisLeaseStore, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis URL: %w", err)
+	}
+	return &RedisLeaseStore{client: redis.NewClient(opts)}, nil
+}
+
+func (s *RedisLeaseStore) SetIfAbsent(ctx context.Context, key string, value string, ttl time.Duration) (bool, error) {
+	return s.client.SetNX(ctx, key, value, ttl).Result()
+}
+
+func (s *RedisLeaseStore) CompareAndRefresh(ctx context.Context, key string, value string, ttl time.Duration) (bool, error) {
+	refreshed, err := compareAndRefreshScript.Run(ctx, s.client, []string{key}, value, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, err
+	}
+	return refreshed == 1, nil
+}
+
+func (s *RedisLeaseStore) CompareAndDelete(ctx context.Context, key string, value string) error {
+	return compareAndDeleteScript.Run(ctx, s.client, []string{key}, value).Err()
+}
+
+// ConductorNonceLease grants the lease to the replica whose op-conductor is the raft leader,
+// so the key follows the sequencer leadership of the conductor cluster.
+// Leadership may be lost and regained between two checks unnoticed, so the lease is only
+// reported as renewed if this replica was the leader at a check less than the TTL ago.
+type ConductorNonceLease struct {
+	rpc client.RPC
+	ttl time.Duration
+	now func() time.Time
+
+	mu sync.Mutex
+	// leaderAt is the time of the last check at which this replica was the leader, zero if it wasn't
+	leaderAt time.Time
+}
+
+var _ NonceLease = (*ConductorNonceLease)(nil)
+
+func NewConductorNonceLease(rpc client.RPC, ttl time.Duration) *ConductorNonceLease {
+	return &ConductorNonceLease{
+		rpc: rpc,
+		ttl: ttl,
+		now: time.Now,
+	}
+}
+
+func (l *ConductorNonceLease) Acquire(ctx context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var leader bool
+	if err := l.rpc.CallContext(ctx, &leader, "conductor_leader"); err != nil {
+		l.leaderAt = time.Time{}
+		return false, fmt.Errorf("failed to query conductor leadership: %w", err)
+	}
+	if !leader {
+		l.leaderAt = time.Time{}
+		return false, fmt.Errorf("%w: conductor is not the leader", ErrNonceLeaseHeld)
+	}
+	now := l.now()
+	granted := l.leaderAt.IsZero() || !now.Before(l.leaderAt.Add(l.ttl))
+	l.leaderAt = now
+	return granted, nil
+}
+
+// Release is a no-op, leadership is transferred through the conductor cluster.
+func (l *ConductorNonceLease) Release(context.Context) error {
+	return nil
+}
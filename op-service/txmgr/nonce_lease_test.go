@@ -0,0 +1,237 @@
+package txmgr
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+)
+
+func TestFileNonceLease(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "nonce.lease")
+	now := time.Unix(1000, 0)
+	newLease := func(holder string) *FileNonceLease {
+		l := NewFileNonceLease(path, holder, time.Minute)
+		l.now = func() time.Time { return now }
+		return l
+	}
+	a, b := newLease("a"), newLease("b")
+
+	requireAcquire(t, a, true)
+	_, err := b.Acquire(ctx)
+	require.ErrorIs(t, err, ErrNonceLeaseHeld)
+
+	// renewals extend the lease
+	now = now.Add(50 * time.Second)
+	requireAcquire(t, a, false)
+	now = now.Add(50 * time.Second)
+	_, err = b.Acquire(ctx)
+	require.ErrorIs(t, err, ErrNonceLeaseHeld)
+
+	// an expired lease can be taken over
+	now = now.Add(time.Minute)
+	requireAcquire(t, b, true)
+	_, err = a.Acquire(ctx)
+	require.ErrorIs(t, err, ErrNonceLeaseHeld)
+
+	// only the holder can release the lease
+	require.NoError(t, a.Release(ctx))
+	_, err = a.Acquire(ctx)
+	require.ErrorIs(t, err, ErrNonceLeaseHeld)
+	require.NoError(t, b.Release(ctx))
+	requireAcquire(t, a, true)
+
+	// an expired lease is granted anew to its last holder
+	now = now.Add(2 * time.Minute)
+	requireAcquire(t, a, true)
+}
+
+func TestStoreNonceLease(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryLeaseStore()
+	now := time.Unix(1000, 0)
+	store.now = func() time.Time { return now }
+	a := NewStoreNonceLease(store, "key", "a", time.Minute)
+	b := NewStoreNonceLease(store, "key", "b", time.Minute)
+
+	requireAcquire(t, a, true)
+	requireAcquire(t, a, false)
+	_, err := b.Acquire(ctx)
+	require.ErrorIs(t, err, ErrNonceLeaseHeld)
+
+	now = now.Add(2 * time.Minute)
+	requireAcquire(t, b, true)
+	_, err = a.Acquire(ctx)
+	require.ErrorIs(t, err, ErrNonceLeaseHeld)
+
+	require.NoError(t, b.Release(ctx))
+	requireAcquire(t, a, true)
+}
+
+// conductorRPC answers the leadership queries of a ConductorNonceLease.
+type conductorRPC struct {
+	client.RPC
+	leader bool
+}
+
+func (r *conductorRPC) CallContext(_ context.Context, result any, method string, _ ...any) error {
+	if method != "conductor_leader" {
+		return fmt.Errorf("unexpected method %s", method)
+	}
+	*result.(*bool) = r.leader
+	return nil
+}
+
+func TestConductorNonceLease(t *testing.T) {
+	ctx := context.Background()
+	rpc := &conductorRPC{leader: true}
+	now := time.Unix(1000, 0)
+	l := NewConductorNonceLease(rpc, time.Minute)
+	l.now = func() time.Time { return now }
+
+	requireAcquire(t, l, true)
+	now = now.Add(50 * time.Second)
+	requireAcquire(t, l, false)
+
+	// leadership may have changed unnoticed between checks further apart than the TTL
+	now = now.Add(2 * time.Minute)
+	requireAcquire(t, l, true)
+
+	rpc.leader = false
+	_, err := l.Acquire(ctx)
+	require.ErrorIs(t, err, ErrNonceLeaseHeld)
+	rpc.leader = true
+	requireAcquire(t, l, true)
+}
+
+func requireAcquire(t *testing.T, l NonceLease, granted bool) {
+	t.Helper()
+	ok, err := l.Acquire(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, granted, ok, "granted")
+}
+
+type testNonceLease struct {
+	held bool
+	// granted reports the lease as newly granted on the next acquire
+	granted  bool
+	acquires atomic.Int64
+}
+
+func (l *testNonceLease) Acquire(context.Context) (bool, error) {
+	l.acquires.Add(1)
+	if !l.held {
+		return false, ErrNonceLeaseHeld
+	}
+	granted := l.granted
+	l.granted = false
+	return granted, nil
+}
+
+func (l *testNonceLease) Release(context.Context) error {
+	l.held = false
+	return nil
+}
+
+func TestTxMgrNonceLease(t *testing.T) {
+	lease := &testNonceLease{held: true}
+	cfg := configWithNumConfs(1)
+	cfg.NetworkTimeout = time.Second
+	cfg.NonceLease = lease
+	h := newTestHarnessWithConfig(t, cfg)
+	ctx := context.Background()
+
+	tx, err := h.mgr.signWithNextNonce(ctx, &types.DynamicFeeTx{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(startingNonce), tx.Nonce())
+	tx, err = h.mgr.signWithNextNonce(ctx, &types.DynamicFeeTx{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(startingNonce+1), tx.Nonce())
+
+	// no txs are signed without the lease
+	lease.held = false
+	_, err = h.mgr.signWithNextNonce(ctx, &types.DynamicFeeTx{})
+	require.ErrorIs(t, err, ErrNonceLeaseHeld)
+	_, err = h.mgr.increaseGasPrice(ctx, tx)
+	require.ErrorIs(t, err, ErrNonceLeaseHeld)
+
+	// the nonce is fetched again once the lease is reacquired
+	lease.held = true
+	tx, err = h.mgr.signWithNextNonce(ctx, &types.DynamicFeeTx{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(startingNonce), tx.Nonce())
+
+	h.mgr.Close()
+	require.False(t, lease.held)
+}
+
+func TestTxMgrNonceLeaseExpired(t *testing.T) {
+	lease := &testNonceLease{held: true}
+	cfg := configWithNumConfs(1)
+	cfg.NetworkTimeout = time.Second
+	cfg.NonceLease = lease
+	h := newTestHarnessWithConfig(t, cfg)
+	ctx := context.Background()
+
+	for i := uint64(0); i < 2; i++ {
+		tx, err := h.mgr.signWithNextNonce(ctx, &types.DynamicFeeTx{})
+		require.NoError(t, err)
+		require.Equal(t, startingNonce+i, tx.Nonce())
+	}
+
+	// the lease expired while idle and got granted anew, so the nonce is fetched again
+	lease.granted = true
+	tx, err := h.mgr.signWithNextNonce(ctx, &types.DynamicFeeTx{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(startingNonce), tx.Nonce())
+}
+
+func TestTxMgrNonceLeaseKeepsJournaledNonce(t *testing.T) {
+	lease := &testNonceLease{held: true, granted: true}
+	cfg := configWithNumConfs(1)
+	cfg.NetworkTimeout = time.Second
+	cfg.NonceLease = lease
+	h := newTestHarnessWithConfig(t, cfg)
+
+	// the nonce continues after the journaled txs, resumed before the lease is acquired
+	journaled := uint64(startingNonce + 5)
+	h.mgr.nonce = &journaled
+	tx, err := h.mgr.signWithNextNonce(context.Background(), &types.DynamicFeeTx{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(startingNonce+6), tx.Nonce())
+}
+
+func TestTxMgrNonceLeaseRenewal(t *testing.T) {
+	lease := &testNonceLease{held: true}
+	cfg := configWithNumConfs(1)
+	cfg.NetworkTimeout = time.Second
+	cfg.NonceLease = lease
+	cfg.NonceLeaseRenewInterval = 10 * time.Millisecond
+	h := newTestHarnessWithConfig(t, cfg)
+	h.mgr.wg.Add(1)
+	go h.mgr.renewNonceLease()
+
+	// the lease is left to expire while no txs are in flight
+	time.Sleep(50 * time.Millisecond)
+	require.Zero(t, lease.acquires.Load())
+
+	// and renewed while a tx is in flight
+	h.mgr.pending.Add(1)
+	require.Eventually(t, func() bool {
+		return lease.acquires.Load() >= 3
+	}, time.Second, 10*time.Millisecond)
+	h.mgr.pending.Add(-1)
+
+	h.mgr.Close()
+	acquires := lease.acquires.Load()
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, acquires, lease.acquires.Load(), "the lease is not renewed after close")
+}
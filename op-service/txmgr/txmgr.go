@@ -46,6 +46,11 @@ var (
 
 	ErrBlobFeeLimit = errors.New("blob fee limit reached")
 	ErrClosed       = errors.New("transaction manager is closed")
+	// ErrReplaced is returned by Send if its tx got replaced by Cancel or Replace.
+	ErrReplaced    = errors.New("transaction replaced")
+	ErrNoPendingTx = errors.New("no pending transaction at nonce")
+	// ErrReplacementTypeMismatch is returned by Replace if a blob tx would replace a non-blob tx, or vice versa.
+	ErrReplacementTypeMismatch = errors.New("blob and non-blob txs cannot replace each other")
 )
 
 // TxManager is an interface that allows callers to reliably publish txs,
@@ -65,6 +70,16 @@ type TxManager interface {
 	// mempool and is in need of replacement or cancellation.
 	Send(ctx context.Context, candidate TxCandidate) (*types.Receipt, error)
 
+	// Cancel replaces the pending tx at the given nonce with a zero-value transfer to the sender itself.
+	// It blocks until the cancellation tx confirms, like Send. The Send of the replaced tx returns ErrReplaced.
+	Cancel(ctx context.Context, nonce uint64) (*types.Receipt, error)
+
+	// Replace replaces the pending tx at the given nonce with a tx crafted from the candidate.
+	// It blocks until the replacement tx confirms, like Send. The Send of the replaced tx returns ErrReplaced.
+	// Blob txs can only be replaced by blob txs, and non-blob txs by non-blob txs.
+	// ErrReplacementTypeMismatch is returned otherwise.
+	Replace(ctx context.Context, nonce uint64, candidate TxCandidate) (*types.Receipt, error)
+
 	// From returns the sending address associated with the instance of the transaction manager.
 	// It is static for a single instance of a TxManager.
 	From() common.Address
@@ -123,10 +138,20 @@ type SimpleTxManager struct {
 
 	nonce     *uint64
 	nonceLock sync.RWMutex
+	// leaseHeld tracks whether the nonce lease was held on the last attempt to acquire it, guarded by nonceLock
+	leaseHeld bool
+
+	// inflight tracks the txs being sent by nonce, so they can be cancelled or replaced
+	inflight     map[uint64]*inflightTx
+	inflightLock sync.Mutex
 
 	pending atomic.Int64
 
 	closed atomic.Bool
+	// closing is cancelled on Close, to stop the background work of the tx manager
+	closing       context.Context
+	cancelClosing context.CancelFunc
+	wg            sync.WaitGroup
 }
 
 // NewSimpleTxManager initializes a new SimpleTxManager with the passed Config.
@@ -143,14 +168,25 @@ func NewSimpleTxManagerFromConfig(name string, l log.Logger, m metrics.TxMetrice
 	if err := conf.Check(); err != nil {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
-	return &SimpleTxManager{
+	mgr := &SimpleTxManager{
 		chainID: conf.ChainID,
 		name:    name,
 		cfg:     conf,
 		backend: conf.Backend,
 		l:       l.New("service", name),
 		metr:    m,
-	}, nil
+	}
+	mgr.closing, mgr.cancelClosing = context.WithCancel(context.Background())
+	if conf.Journal != nil {
+		if err := mgr.resumeJournaled(); err != nil {
+			return nil, fmt.Errorf("failed to resume journaled txs: %w", err)
+		}
+	}
+	if conf.NonceLease != nil {
+		mgr.wg.Add(1)
+		go mgr.renewNonceLease()
+	}
+	return mgr, nil
 }
 
 func (m *SimpleTxManager) From() common.Address {
@@ -164,6 +200,15 @@ func (m *SimpleTxManager) BlockNumber(ctx context.Context) (uint64, error) {
 // Close closes the underlying connection, and sets the closed flag.
 // once closed, the tx manager will refuse to send any new transactions, and may abandon pending ones.
 func (m *SimpleTxManager) Close() {
+	m.cancelClosing()
+	m.wg.Wait()
+	if m.cfg.NonceLease != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), m.cfg.NetworkTimeout)
+		if err := m.cfg.NonceLease.Release(ctx); err != nil {
+			m.l.Warn("Failed to release nonce lease", "err", err)
+		}
+		cancel()
+	}
 	m.backend.Close()
 	m.closed.Store(true)
 }
@@ -194,6 +239,9 @@ type TxCandidate struct {
 	GasLimit uint64
 	// Value is the value to be used in the constructed tx.
 	Value *big.Int
+	// Published is called (optional) with every version of the tx that got successfully published,
+	// including fee-bumped replacements, so callers can keep track of the tx hashes in flight.
+	Published func(tx *types.Transaction)
 }
 
 // Send is used to publish a transaction with incrementally higher gas prices
@@ -215,12 +263,51 @@ func (m *SimpleTxManager) Send(ctx context.Context, candidate TxCandidate) (*typ
 		m.metr.RecordPendingTx(m.pending.Add(-1))
 	}()
 	receipt, err := m.send(ctx, candidate)
-	if err != nil {
+	// the nonce of a replaced tx is still taken by its replacement
+	if err != nil && !errors.Is(err, ErrReplaced) {
 		m.resetNonce()
 	}
 	return receipt, err
 }
 
+// Cancel replaces the pending tx at the given nonce with a zero-value transfer to the sender itself.
+// A pending blob tx is cancelled with a blob tx carrying a single empty blob, since the tx pool
+// doesn't allow blob and non-blob txs to replace each other.
+func (m *SimpleTxManager) Cancel(ctx context.Context, nonce uint64) (*types.Receipt, error) {
+	candidate := TxCandidate{
+		To:       &m.cfg.From,
+		Value:    big.NewInt(0),
+		GasLimit: params.TxGas,
+	}
+	if pending := m.pendingTx(nonce); pending != nil && pending.Type() == types.BlobTxType {
+		candidate.Blobs = []*eth.Blob{{}}
+	}
+	return m.Replace(ctx, nonce, candidate)
+}
+
+// Replace replaces the pending tx at the given nonce with a tx crafted from the candidate.
+// The fees of the replacement are bumped over the latest known version of the pending tx,
+// following the same tx replacement rules as the fee bumps of Send.
+func (m *SimpleTxManager) Replace(ctx context.Context, nonce uint64, candidate TxCandidate) (*types.Receipt, error) {
+	if m.closed.Load() {
+		return nil, ErrClosed
+	}
+	m.metr.RecordPendingTx(m.pending.Add(1))
+	defer func() {
+		m.metr.RecordPendingTx(m.pending.Add(-1))
+	}()
+	if m.cfg.TxSendTimeout != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.cfg.TxSendTimeout)
+		defer cancel()
+	}
+	tx, err := m.craftReplacement(ctx, nonce, candidate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the replacement tx: %w", err)
+	}
+	return m.sendTx(ctx, tx, candidate.Published)
+}
+
 // send performs the actual transaction creation and sending.
 func (m *SimpleTxManager) send(ctx context.Context, candidate TxCandidate) (*types.Receipt, error) {
 	if m.cfg.TxSendTimeout != 0 {
@@ -241,7 +328,7 @@ func (m *SimpleTxManager) send(ctx context.Context, candidate TxCandidate) (*typ
 	if err != nil {
 		return nil, fmt.Errorf("failed to create the tx: %w", err)
 	}
-	return m.sendTx(ctx, tx)
+	return m.sendTx(ctx, tx, candidate.Published)
 }
 
 // craftTx creates the signed transaction
@@ -250,6 +337,16 @@ func (m *SimpleTxManager) send(ctx context.Context, candidate TxCandidate) (*typ
 // NOTE: If the [TxCandidate.GasLimit] is non-zero, it will be used as the transaction's gas.
 // NOTE: Otherwise, the [SimpleTxManager] will query the specified backend for an estimate.
 func (m *SimpleTxManager) craftTx(ctx context.Context, candidate TxCandidate) (*types.Transaction, error) {
+	txMessage, err := m.craftTxMessage(ctx, candidate, nil)
+	if err != nil {
+		return nil, err
+	}
+	return m.signWithNextNonce(ctx, txMessage) // signer sets the nonce field of the tx
+}
+
+// craftTxMessage creates the unsigned tx message of the candidate, without nonce.
+// If the tx replaces a pending tx, its fees are bumped to satisfy the tx replacement rules.
+func (m *SimpleTxManager) craftTxMessage(ctx context.Context, candidate TxCandidate, replaced *types.Transaction) (types.TxData, error) {
 	m.l.Debug("crafting Transaction", "blobs", len(candidate.Blobs), "calldata_size", len(candidate.TxData))
 	gasTipCap, baseFee, blobBaseFee, err := m.suggestGasPriceCaps(ctx)
 	if err != nil {
@@ -257,6 +354,13 @@ func (m *SimpleTxManager) craftTx(ctx context.Context, candidate TxCandidate) (*
 		return nil, fmt.Errorf("failed to get gas price info: %w", err)
 	}
 	gasFeeCap := calcGasFeeCap(baseFee, gasTipCap)
+	if replaced != nil {
+		bumpedTip, bumpedFee := m.bumpFees(replaced, gasTipCap, baseFee)
+		if err := m.checkLimits(gasTipCap, baseFee, bumpedTip, bumpedFee); err != nil {
+			return nil, err
+		}
+		gasTipCap, gasFeeCap = bumpedTip, bumpedFee
+	}
 
 	gasLimit := candidate.GasLimit
 
@@ -296,6 +400,16 @@ func (m *SimpleTxManager) craftTx(ctx context.Context, candidate TxCandidate) (*
 		// no need to calcBlobFeeCap, prefer raw blobBaseFee
 		// blobFeeCap := calcBlobFeeCap(blobBaseFee)
 		blobFeeCap := blobBaseFee
+		if replaced != nil {
+			// a replacement must get in, so it does not skimp on the blob fee, and must bump the replaced blob fee
+			blobFeeCap = calcBlobFeeCap(blobBaseFee)
+			if threshold := calcThresholdValue(replaced.BlobGasFeeCap(), true); blobFeeCap.Cmp(threshold) < 0 {
+				blobFeeCap = threshold
+			}
+			if err := m.checkBlobFeeLimits(blobBaseFee, blobFeeCap); err != nil {
+				return nil, err
+			}
+		}
 		message := &types.BlobTx{
 			To:         *candidate.To,
 			Data:       candidate.TxData,
@@ -318,7 +432,67 @@ func (m *SimpleTxManager) craftTx(ctx context.Context, candidate TxCandidate) (*
 			Gas:       gasLimit,
 		}
 	}
-	return m.signWithNextNonce(ctx, txMessage) // signer sets the nonce field of the tx
+	return txMessage, nil
+}
+
+// craftReplacement creates the signed replacement of the pending tx at the given nonce.
+// The send of the replaced tx, if any, is stopped.
+func (m *SimpleTxManager) craftReplacement(ctx context.Context, nonce uint64, candidate TxCandidate) (*types.Transaction, error) {
+	if err := m.checkPendingNonce(ctx, nonce); err != nil {
+		return nil, err
+	}
+	replaced := m.pendingTx(nonce)
+	if replaced == nil {
+		m.l.Warn("Replacing unknown pending transaction, fees are not bumped", "nonce", nonce)
+	} else if (replaced.Type() == types.BlobTxType) != (len(candidate.Blobs) > 0) {
+		return nil, ErrReplacementTypeMismatch
+	}
+	txMessage, err := m.craftTxMessage(ctx, candidate, replaced)
+	if err != nil {
+		return nil, err
+	}
+	switch x := txMessage.(type) {
+	case *types.DynamicFeeTx:
+		x.Nonce = nonce
+	case *types.BlobTx:
+		x.Nonce = nonce
+	default:
+		return nil, fmt.Errorf("unrecognized tx type: %T", x)
+	}
+	if err := m.checkNonceLease(ctx); err != nil {
+		return nil, err
+	}
+	sCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+	defer cancel()
+	tx, err := m.cfg.Signer(sCtx, m.cfg.From, types.NewTx(txMessage))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign the replacement tx: %w", err)
+	}
+	m.stopInflight(nonce)
+	return tx, nil
+}
+
+// checkPendingNonce checks that the given nonce is taken by a tx that is pending in the tx pool.
+func (m *SimpleTxManager) checkPendingNonce(ctx context.Context, nonce uint64) error {
+	cCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+	defer cancel()
+	latest, err := m.backend.NonceAt(cCtx, m.cfg.From, nil)
+	if err != nil {
+		m.metr.RPCError()
+		return fmt.Errorf("failed to get nonce: %w", err)
+	}
+	pending, err := m.backend.PendingNonceAt(cCtx, m.cfg.From)
+	if err != nil {
+		m.metr.RPCError()
+		return fmt.Errorf("failed to get pending nonce: %w", err)
+	}
+	if nonce < latest {
+		return fmt.Errorf("%w: nonce %d is already mined, next nonce is %d", ErrNoPendingTx, nonce, latest)
+	}
+	if nonce >= pending {
+		return fmt.Errorf("%w: nonce %d is not pending, pending nonce is %d", ErrNoPendingTx, nonce, pending)
+	}
+	return nil
 }
 
 // MakeSidecar builds & returns the BlobTxSidecar and corresponding blob hashes from the raw blob
@@ -348,11 +522,16 @@ func MakeSidecar(blobs []*eth.Blob) (*types.BlobTxSidecar, []common.Hash, error)
 // The nonce is fetched once using eth_getTransactionCount with "latest", and
 // then subsequent calls simply increment this number. If the transaction manager
 // is reset, it will query the eth_getTransactionCount nonce again. If signing
-// fails, the nonce is not incremented.
+// fails, the nonce is not incremented. If a nonce lease is configured, it must be
+// held to sign.
 func (m *SimpleTxManager) signWithNextNonce(ctx context.Context, txMessage types.TxData) (*types.Transaction, error) {
 	m.nonceLock.Lock()
 	defer m.nonceLock.Unlock()
 
+	if err := m.acquireNonceLease(ctx); err != nil {
+		return nil, err
+	}
+
 	if m.nonce == nil {
 		// Fetch the sender's nonce from the latest known block (nil `blockNumber`)
 		childCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
@@ -396,20 +575,96 @@ func (m *SimpleTxManager) resetNonce() {
 	m.nonce = nil
 }
 
+// checkNonceLease ensures the nonce lease is held, if configured.
+func (m *SimpleTxManager) checkNonceLease(ctx context.Context) error {
+	m.nonceLock.Lock()
+	defer m.nonceLock.Unlock()
+	return m.acquireNonceLease(ctx)
+}
+
+// acquireNonceLease acquires or renews the nonce lease, if configured. The nonce lock must be held.
+// The nonce is reset whenever the lease is lost, or was granted anew after it expired, because another
+// replica may use the key in the meantime, and the nonce must not be reused once the lease is lost.
+// The nonce of the journaled txs, resumed before the lease is first acquired, is kept.
+func (m *SimpleTxManager) acquireNonceLease(ctx context.Context) error {
+	if m.cfg.NonceLease == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+	defer cancel()
+	granted, err := m.cfg.NonceLease.Acquire(ctx)
+	if err != nil {
+		if m.leaseHeld {
+			m.l.Warn("Lost nonce lease", "err", err)
+		}
+		m.leaseHeld = false
+		m.nonce = nil
+		return fmt.Errorf("nonce lease not held: %w", err)
+	}
+	if !m.leaseHeld {
+		m.l.Info("Acquired nonce lease")
+		m.leaseHeld = true
+	} else if granted {
+		// the lease expired while no txs were in flight, another replica may have used the key meanwhile
+		m.l.Info("Reacquired expired nonce lease")
+		m.nonce = nil
+	}
+	return nil
+}
+
+// renewNonceLease renews the nonce lease while txs are in flight, so the lease doesn't expire
+// between the signatures of the fee bumps, which are ResubmissionTimeout apart.
+func (m *SimpleTxManager) renewNonceLease() {
+	defer m.wg.Done()
+	ticker := time.NewTicker(m.cfg.NonceLeaseRenewInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if m.pending.Load() == 0 {
+				continue
+			}
+			if err := m.checkNonceLease(m.closing); err != nil {
+				m.l.Debug("Failed to renew nonce lease", "err", err)
+			}
+		case <-m.closing.Done():
+			return
+		}
+	}
+}
+
 // send submits the same transaction several times with increasing gas prices as necessary.
 // It waits for the transaction to be confirmed on chain.
-func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+// The optional published callback is called with every version of the tx that got published.
+func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction, published func(tx *types.Transaction)) (*types.Receipt, error) {
+	sendState := NewSendState(m.cfg.SafeAbortNonceTooLowCount, m.cfg.TxNotInMempoolTimeout)
+	return m.sendTxWithState(ctx, tx, sendState, published)
+}
+
+// sendTxWithState is sendTx, continuing from the given send state.
+func (m *SimpleTxManager) sendTxWithState(ctx context.Context, tx *types.Transaction, sendState *SendState, published func(tx *types.Transaction)) (*types.Receipt, error) {
 	var wg sync.WaitGroup
 	defer wg.Wait()
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	inflight := m.trackInflight(tx, cancel)
+	defer m.untrackInflight(inflight)
 
-	sendState := NewSendState(m.cfg.SafeAbortNonceTooLowCount, m.cfg.TxNotInMempoolTimeout)
 	receiptChan := make(chan *types.Receipt, 1)
 	publishAndWait := func(tx *types.Transaction, bumpFees bool) *types.Transaction {
 		wg.Add(1)
-		tx, published := m.publishTx(ctx, tx, sendState, bumpFees)
-		if published {
+		tx, ok := m.publishTx(ctx, tx, sendState, bumpFees)
+		if ok {
+			m.inflightPublished(inflight, tx)
+			if m.cfg.Journal != nil {
+				if err := m.cfg.Journal.Published(tx, sendState); err != nil {
+					m.txLogger(tx, false).Warn("Failed to journal tx", "err", err)
+				}
+			}
+			if published != nil {
+				published(tx)
+			}
 			go func() {
 				defer wg.Done()
 				m.waitForTx(ctx, tx, sendState, receiptChan)
@@ -429,6 +684,7 @@ func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction) (*t
 	for {
 		if err := sendState.CriticalError(); err != nil {
 			m.txLogger(tx, false).Warn("Aborting transaction submission", "err", err)
+			m.unjournalTx(tx)
 			return nil, fmt.Errorf("aborted tx send due to critical error: %w", err)
 		}
 		select {
@@ -445,16 +701,152 @@ func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction) (*t
 			tx = publishAndWait(tx, true)
 
 		case <-ctx.Done():
-			return nil, ctx.Err()
+			return nil, context.Cause(ctx)
 
 		case receipt := <-receiptChan:
 			m.metr.RecordGasBumpCount(sendState.bumpCount)
 			m.metr.TxConfirmed(receipt)
+			m.unjournalTx(tx)
 			return receipt, nil
 		}
 	}
 }
 
+// inflightTx is a tx that is being sent.
+type inflightTx struct {
+	// tx is the latest published version of the tx
+	tx     *types.Transaction
+	cancel context.CancelCauseFunc
+}
+
+func (m *SimpleTxManager) trackInflight(tx *types.Transaction, cancel context.CancelCauseFunc) *inflightTx {
+	m.inflightLock.Lock()
+	defer m.inflightLock.Unlock()
+	if m.inflight == nil {
+		m.inflight = make(map[uint64]*inflightTx)
+	}
+	inflight := &inflightTx{tx: tx, cancel: cancel}
+	m.inflight[tx.Nonce()] = inflight
+	return inflight
+}
+
+func (m *SimpleTxManager) inflightPublished(inflight *inflightTx, tx *types.Transaction) {
+	m.inflightLock.Lock()
+	defer m.inflightLock.Unlock()
+	inflight.tx = tx
+}
+
+func (m *SimpleTxManager) untrackInflight(inflight *inflightTx) {
+	m.inflightLock.Lock()
+	defer m.inflightLock.Unlock()
+	nonce := inflight.tx.Nonce()
+	// the nonce may be tracked by a replacement already
+	if m.inflight[nonce] == inflight {
+		delete(m.inflight, nonce)
+	}
+}
+
+// stopInflight stops the send of the tx at the given nonce, if any, because it got replaced.
+func (m *SimpleTxManager) stopInflight(nonce uint64) {
+	m.inflightLock.Lock()
+	defer m.inflightLock.Unlock()
+	if inflight, ok := m.inflight[nonce]; ok {
+		inflight.cancel(ErrReplaced)
+		delete(m.inflight, nonce)
+	}
+}
+
+// pendingTx returns the latest known version of the pending tx at the given nonce,
+// from the txs being sent, or the journal. It returns nil if the tx is unknown.
+func (m *SimpleTxManager) pendingTx(nonce uint64) *types.Transaction {
+	m.inflightLock.Lock()
+	inflight, ok := m.inflight[nonce]
+	m.inflightLock.Unlock()
+	if ok {
+		return inflight.tx
+	}
+	if m.cfg.Journal != nil {
+		if jtx := m.cfg.Journal.Tx(nonce); jtx != nil {
+			if tx, err := jtx.Transaction(); err == nil {
+				return tx
+			}
+		}
+	}
+	return nil
+}
+
+// unjournalTx removes the tx from the journal once its submission is over.
+// Txs of cancelled sends stay journaled, they may still get mined, or get replaced at the same nonce.
+func (m *SimpleTxManager) unjournalTx(tx *types.Transaction) {
+	if m.cfg.Journal == nil {
+		return
+	}
+	if err := m.cfg.Journal.Remove(tx.Nonce()); err != nil {
+		m.txLogger(tx, false).Warn("Failed to remove tx from journal", "err", err)
+	}
+}
+
+// resumeJournaled resumes the submission of the txs journaled by a previous run of the tx manager,
+// in the background. Txs of which any version got mined meanwhile are dropped from the journal.
+// The nonce continues after the highest journaled nonce.
+func (m *SimpleTxManager) resumeJournaled() error {
+	var last *types.Transaction
+	for _, jtx := range m.cfg.Journal.Txs() {
+		tx, err := jtx.Transaction()
+		if err != nil {
+			return err
+		}
+		last = tx
+		m.metr.RecordPendingTx(m.pending.Add(1))
+		m.wg.Add(1)
+		go m.resumeJournaledTx(jtx, tx)
+	}
+	if last != nil {
+		m.nonceLock.Lock()
+		nonce := last.Nonce()
+		m.nonce = &nonce
+		m.nonceLock.Unlock()
+	}
+	return nil
+}
+
+// resumeJournaledTx resumes the submission of a journaled tx, unless any version of it got mined already.
+// The submission is stopped when the tx manager is closed.
+func (m *SimpleTxManager) resumeJournaledTx(jtx *JournaledTx, tx *types.Transaction) {
+	defer m.wg.Done()
+	defer func() {
+		m.metr.RecordPendingTx(m.pending.Add(-1))
+	}()
+	if receipt := m.journaledReceipt(m.closing, jtx); receipt != nil {
+		m.txLogger(tx, false).Info("Journaled transaction got mined", "minedTx", receipt.TxHash)
+		m.unjournalTx(tx)
+		return
+	}
+	sendState := NewSendState(m.cfg.SafeAbortNonceTooLowCount, m.cfg.TxNotInMempoolTimeout)
+	jtx.restore(sendState)
+
+	m.txLogger(tx, true).Info("Resuming journaled transaction", "bumpCount", sendState.bumpCount)
+	if _, err := m.sendTxWithState(m.closing, tx, sendState, nil); errors.Is(err, ErrReplaced) {
+		m.txLogger(tx, false).Info("Journaled transaction got replaced")
+	} else if err != nil {
+		m.txLogger(tx, false).Warn("Failed to resume journaled transaction", "err", err)
+		m.resetNonce()
+	}
+}
+
+// journaledReceipt returns the receipt of any published version of the journaled tx, or nil if none got mined.
+func (m *SimpleTxManager) journaledReceipt(ctx context.Context, jtx *JournaledTx) *types.Receipt {
+	for _, h := range jtx.Hashes {
+		cCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+		receipt, err := m.backend.TransactionReceipt(cCtx, h)
+		cancel()
+		if err == nil && receipt != nil {
+			return receipt
+		}
+	}
+	return nil
+}
+
 // publishTx publishes the transaction to the transaction pool. If it receives any underpriced errors
 // it will bump the fees and retry.
 // Returns the latest fee bumped tx, and a boolean indicating whether the tx was sent or not
@@ -645,12 +1037,16 @@ func (m *SimpleTxManager) queryReceipt(ctx context.Context, txHash common.Hash,
 // multiple of the suggested values.
 func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
 	m.txLogger(tx, true).Info("bumping gas price for transaction")
+	// stop replacing the tx once the key is handed over to another replica
+	if err := m.checkNonceLease(ctx); err != nil {
+		return nil, err
+	}
 	tip, baseFee, blobBaseFee, err := m.suggestGasPriceCaps(ctx)
 	if err != nil {
 		m.txLogger(tx, false).Warn("failed to get suggested gas tip and base fee", "err", err)
 		return nil, err
 	}
-	bumpedTip, bumpedFee := updateFees(tx.GasTipCap(), tx.GasFeeCap(), tip, baseFee, tx.Type() == types.BlobTxType, m.l)
+	bumpedTip, bumpedFee := m.bumpFees(tx, tip, baseFee)
 
 	if err := m.checkLimits(tip, baseFee, bumpedTip, bumpedFee); err != nil {
 		return nil, err
@@ -728,28 +1124,28 @@ func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transa
 	return signedTx, nil
 }
 
+// bumpFees returns the tip and fee cap of a replacement of the tx, given the newly suggested tip and base fee.
+// The FeeEstimator decides on the fee bumps if it is a FeeBumper, updateFees otherwise.
+func (m *SimpleTxManager) bumpFees(tx *types.Transaction, tip, baseFee *big.Int) (*big.Int, *big.Int) {
+	isBlobTx := tx.Type() == types.BlobTxType
+	if bumper, ok := m.cfg.FeeEstimator.(FeeBumper); ok {
+		return bumper.BumpFees(tx.GasTipCap(), tx.GasFeeCap(), tip, baseFee, isBlobTx)
+	}
+	return updateFees(tx.GasTipCap(), tx.GasFeeCap(), tip, baseFee, isBlobTx, m.l)
+}
+
 // suggestGasPriceCaps suggests what the new tip, base fee, and blob base fee should be based on
 // the current L1 conditions. blobfee will be nil if 4844 is not yet active.
 func (m *SimpleTxManager) suggestGasPriceCaps(ctx context.Context) (*big.Int, *big.Int, *big.Int, error) {
-	cCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
-	defer cancel()
-	tip, err := m.backend.SuggestGasTipCap(cCtx)
-	if err != nil {
-		m.metr.RPCError()
-		return nil, nil, nil, fmt.Errorf("failed to fetch the suggested gas tip cap: %w", err)
-	} else if tip == nil {
-		return nil, nil, nil, errors.New("the suggested tip was nil")
+	feeEstimator := m.cfg.FeeEstimator
+	if feeEstimator == nil {
+		feeEstimator = NewEthereumFeeEstimator(m.backend, m.cfg.NetworkTimeout)
 	}
-	cCtx, cancel = context.WithTimeout(ctx, m.cfg.NetworkTimeout)
-	defer cancel()
-	head, err := m.backend.HeaderByNumber(cCtx, nil)
+	tip, baseFee, blobFee, err := feeEstimator.SuggestGasPriceCaps(ctx)
 	if err != nil {
 		m.metr.RPCError()
-		return nil, nil, nil, fmt.Errorf("failed to fetch the suggested base fee: %w", err)
+		return nil, nil, nil, err
 	}
-
-	// basefee of BSC block is 0
-	baseFee := big.NewInt(0)
 	m.metr.RecordBaseFee(baseFee)
 	m.metr.RecordTipCap(tip)
 
@@ -763,9 +1159,7 @@ func (m *SimpleTxManager) suggestGasPriceCaps(ctx context.Context) (*big.Int, *b
 		baseFee = new(big.Int).Set(m.cfg.MinBaseFee)
 	}
 
-	var blobFee *big.Int
-	if head.ExcessBlobGas != nil {
-		blobFee = eip4844.CalcBlobFee(*head.ExcessBlobGas)
+	if blobFee != nil {
 		m.metr.RecordBlobBaseFee(blobFee)
 	}
 	return tip, baseFee, blobFee, nil
@@ -64,6 +64,7 @@ func newTestHarnessWithConfig(t *testing.T, cfg Config) *testHarness {
 		l:       testlog.Logger(t, log.LevelCrit),
 		metr:    &metrics.NoopTxMetrics{},
 	}
+	mgr.closing, mgr.cancelClosing = context.WithCancel(context.Background())
 
 	return &testHarness{
 		cfg:       cfg,
@@ -208,6 +209,9 @@ type mockBackend struct {
 
 	// minedTxs maps the hash of a mined transaction to its details.
 	minedTxs map[common.Hash]minedTxInfo
+
+	// pendingNonce is the pending nonce, if there are pending txs.
+	pendingNonce uint64
 }
 
 // newMockBackend initializes a new mockBackend.
@@ -296,6 +300,11 @@ func (b *mockBackend) NonceAt(ctx context.Context, account common.Address, block
 }
 
 func (b *mockBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.pendingNonce != 0 {
+		return b.pendingNonce, nil
+	}
 	return startingNonce, nil
 }
 
@@ -359,7 +368,7 @@ func TestTxMgrConfirmAtMinGasPrice(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, nil)
 	require.Nil(t, err)
 	require.NotNil(t, receipt)
 	require.Equal(t, gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -387,7 +396,7 @@ func TestTxMgrNeverConfirmCancel(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, nil)
 	require.Equal(t, err, context.DeadlineExceeded)
 	require.Nil(t, receipt)
 }
@@ -434,7 +443,7 @@ func TestTxMgrConfirmsAtHigherGasPrice(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, nil)
 	require.Nil(t, err)
 	require.NotNil(t, receipt)
 	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -469,7 +478,7 @@ func TestTxMgrConfirmsBlobTxAtHigherGasPrice(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, nil)
 	require.Nil(t, err)
 	require.NotNil(t, receipt)
 	// the fee cap for the blob tx at epoch == 3 should end up higher than the min required gas
@@ -503,7 +512,7 @@ func TestTxMgrBlocksOnFailingRpcCalls(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, nil)
 	require.Equal(t, err, context.DeadlineExceeded)
 	require.Nil(t, receipt)
 }
@@ -690,7 +699,7 @@ func TestTxMgrOnlyOnePublicationSucceeds(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, nil)
 	require.Nil(t, err)
 
 	require.NotNil(t, receipt)
@@ -726,7 +735,7 @@ func TestTxMgrConfirmsMinGasPriceAfterBumping(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, nil)
 	require.Nil(t, err)
 	require.NotNil(t, receipt)
 	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -772,7 +781,7 @@ func TestTxMgrDoesntAbortNonceTooLowAfterMiningTx(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, nil)
 	require.Nil(t, err)
 	require.NotNil(t, receipt)
 	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -1482,3 +1491,53 @@ func TestMakeSidecar(t *testing.T) {
 		require.Equal(t, hashes[i], eth.KZGToVersionedHash(commit))
 	}
 }
+
+func TestTxMgrCancel(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(1)
+	cfg.NetworkTimeout = time.Second
+	h := newTestHarnessWithConfig(t, cfg)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// only self-transfers get mined, so the candidate is stuck
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		if *tx.To() == h.cfg.From {
+			txHash := tx.Hash()
+			h.backend.mine(&txHash, tx.GasFeeCap(), nil)
+		}
+		return nil
+	})
+	sendErr := make(chan error, 1)
+	go func() {
+		_, err := h.mgr.Send(ctx, h.createTxCandidate())
+		sendErr <- err
+	}()
+	require.Eventually(t, func() bool { return h.mgr.pendingTx(startingNonce) != nil }, 5*time.Second, 10*time.Millisecond)
+	stuck := h.mgr.pendingTx(startingNonce)
+
+	// only pending nonces can be cancelled
+	_, err := h.mgr.Cancel(ctx, startingNonce)
+	require.ErrorIs(t, err, ErrNoPendingTx)
+	h.backend.mu.Lock()
+	h.backend.pendingNonce = startingNonce + 1
+	h.backend.mu.Unlock()
+	_, err = h.mgr.Cancel(ctx, startingNonce-1)
+	require.ErrorIs(t, err, ErrNoPendingTx)
+
+	// blob txs can't replace non-blob txs
+	_, err = h.mgr.Replace(ctx, startingNonce, h.createBlobTxCandidate())
+	require.ErrorIs(t, err, ErrReplacementTypeMismatch)
+
+	receipt, err := h.mgr.Cancel(ctx, startingNonce)
+	require.NoError(t, err)
+	// the mock backend reports the fee cap of the mined tx as gas used
+	require.GreaterOrEqual(t, receipt.GasUsed, calcThresholdValue(stuck.GasFeeCap(), false).Uint64())
+	require.ErrorIs(t, <-sendErr, ErrReplaced)
+
+	// the nonce of the replaced tx is not reused
+	h.mgr.nonceLock.Lock()
+	defer h.mgr.nonceLock.Unlock()
+	require.Equal(t, uint64(startingNonce), *h.mgr.nonce)
+}
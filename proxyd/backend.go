@@ -111,6 +111,18 @@ var (
 		HTTPErrorCode: 500,
 	}
 
+	ErrSubscriptionNotFound = &RPCErr{
+		Code:          JSONRPCErrorInternal - 22,
+		Message:       "subscription not found",
+		HTTPErrorCode: 400,
+	}
+
+	ErrOverQuota = &RPCErr{
+		Code:          JSONRPCErrorInternal - 23,
+		Message:       "api key is over quota",
+		HTTPErrorCode: 429,
+	}
+
 	ErrBackendUnexpectedJSONRPC = errors.New("backend returned an unexpected JSON-RPC response")
 
 	ErrConsensusGetReceiptsCantBeBatched = errors.New("consensus_getReceipts cannot be batched")
@@ -968,6 +980,7 @@ type WSProxier struct {
 	backendConn     *websocket.Conn
 	backendConnMu   sync.Mutex
 	methodWhitelist *StringSet
+	usage           *UsageTracker
 	readTimeout     time.Duration
 	writeTimeout    time.Duration
 }
@@ -1022,6 +1035,9 @@ func (w *WSProxier) clientPump(ctx context.Context, errC chan error) {
 		// Don't bother sending invalid requests to the backend,
 		// just handle them here.
 		req, err := w.prepareClientMsg(msg)
+		if err == nil {
+			err = w.usage.Take(ctx, GetAuthCtx(ctx), req.Method)
+		}
 		if err != nil {
 			var id json.RawMessage
 			method := MethodUnknown
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/rpc"
@@ -114,6 +115,64 @@ func (c *cacheWithCompression) Put(ctx context.Context, key string, value string
 	return c.cache.Put(ctx, key, string(encodedVal))
 }
 
+// CacheGeneration is the generation of the cached responses of a backend group. It is part of the
+// cache keys, so incrementing it invalidates all the cached responses of the group.
+type CacheGeneration interface {
+	Get(ctx context.Context) (uint64, error)
+	Incr(ctx context.Context) (uint64, error)
+}
+
+type memoryCacheGeneration struct {
+	generation atomic.Uint64
+}
+
+func newMemoryCacheGeneration() *memoryCacheGeneration {
+	return &memoryCacheGeneration{}
+}
+
+func (g *memoryCacheGeneration) Get(ctx context.Context) (uint64, error) {
+	return g.generation.Load(), nil
+}
+
+func (g *memoryCacheGeneration) Incr(ctx context.Context) (uint64, error) {
+	return g.generation.Add(1), nil
+}
+
+// redisCacheGeneration keeps the generation in Redis, so an invalidation by any proxyd instance
+// applies to the responses cached by all the instances sharing the cache.
+type redisCacheGeneration struct {
+	rdb *redis.Client
+	key string
+}
+
+func newRedisCacheGeneration(rdb *redis.Client, prefix string, backendGroup string) *redisCacheGeneration {
+	key := strings.Join([]string{"cache", "generation", backendGroup}, ":")
+	if prefix != "" {
+		key = strings.Join([]string{prefix, key}, ":")
+	}
+	return &redisCacheGeneration{rdb, key}
+}
+
+func (g *redisCacheGeneration) Get(ctx context.Context) (uint64, error) {
+	val, err := g.rdb.Get(ctx, g.key).Uint64()
+	if err == redis.Nil {
+		return 0, nil
+	} else if err != nil {
+		RecordRedisError("CacheGenerationGet")
+		return 0, err
+	}
+	return val, nil
+}
+
+func (g *redisCacheGeneration) Incr(ctx context.Context) (uint64, error) {
+	val, err := g.rdb.Incr(ctx, g.key).Uint64()
+	if err != nil {
+		RecordRedisError("CacheGenerationIncr")
+		return 0, err
+	}
+	return val, nil
+}
+
 type RPCCache interface {
 	GetRPC(ctx context.Context, req *RPCReq) (*RPCRes, error)
 	PutRPC(ctx context.Context, req *RPCReq, res *RPCRes) error
@@ -124,7 +183,7 @@ type rpcCache struct {
 	handlers map[string]RPCMethodHandler
 }
 
-func newRPCCache(cache Cache) RPCCache {
+func newRPCCache(cache Cache) *rpcCache {
 	staticHandler := &StaticMethodHandler{cache: cache}
 	debugGetRawReceiptsHandler := &StaticMethodHandler{cache: cache,
 		filterGet: func(req *RPCReq) bool {
@@ -165,6 +224,11 @@ func newRPCCache(cache Cache) RPCCache {
 	}
 }
 
+// setHandler overrides the cache handler of the method
+func (c *rpcCache) setHandler(method string, handler RPCMethodHandler) {
+	c.handlers[method] = handler
+}
+
 func (c *rpcCache) GetRPC(ctx context.Context, req *RPCReq) (*RPCRes, error) {
 	handler := c.handlers[req.Method]
 	if handler == nil {
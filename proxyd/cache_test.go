@@ -2,9 +2,13 @@ package proxyd
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 	"testing"
+	"time"
 
+	"github.com/alicebob/miniredis"
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/require"
 )
 
@@ -211,3 +215,157 @@ func TestRPCCacheUnsupportedMethod(t *testing.T) {
 	}
 
 }
+
+func TestRPCCacheBlockAware(t *testing.T) {
+	ctx := context.Background()
+
+	bg := &BackendGroup{Name: "main"}
+	bg.Consensus = NewConsensusPoller(bg, WithAsyncHandler(NewNoopAsyncHandler()))
+	bg.Consensus.tracker.SetSafeBlockNumber(120)
+	bg.Consensus.tracker.SetFinalizedBlockNumber(100)
+
+	cache := newRPCCache(newMemoryCache())
+	handler := NewBlockAwareMethodHandler(cache.cache, bg, "finalized", newMemoryCacheGeneration())
+	for _, method := range blockAwareCacheMethods {
+		cache.setHandler(method, handler)
+	}
+	ID := []byte(strconv.Itoa(1))
+
+	rpcs := []struct {
+		name   string
+		req    *RPCReq
+		res    *RPCRes
+		cached bool
+	}{
+		{
+			name:   "eth_getBlockByNumber finalized block",
+			req:    &RPCReq{JSONRPC: "2.0", Method: "eth_getBlockByNumber", Params: mustMarshalJSON([]interface{}{"0x64", false}), ID: ID},
+			res:    &RPCRes{JSONRPC: "2.0", Result: map[string]interface{}{"number": "0x64"}, ID: ID},
+			cached: true,
+		},
+		{
+			name: "eth_getBlockByNumber unfinalized block",
+			req:  &RPCReq{JSONRPC: "2.0", Method: "eth_getBlockByNumber", Params: mustMarshalJSON([]interface{}{"0x65", false}), ID: ID},
+			res:  &RPCRes{JSONRPC: "2.0", Result: map[string]interface{}{"number": "0x65"}, ID: ID},
+		},
+		{
+			name: "eth_getBlockByNumber tag",
+			req:  &RPCReq{JSONRPC: "2.0", Method: "eth_getBlockByNumber", Params: mustMarshalJSON([]interface{}{"finalized", false}), ID: ID},
+			res:  &RPCRes{JSONRPC: "2.0", Result: map[string]interface{}{"number": "0x64"}, ID: ID},
+		},
+		{
+			name:   "eth_call finalized block",
+			req:    &RPCReq{JSONRPC: "2.0", Method: "eth_call", Params: mustMarshalJSON([]interface{}{map[string]string{"to": "0x01"}, "0x10"}), ID: ID},
+			res:    &RPCRes{JSONRPC: "2.0", Result: "0x01", ID: ID},
+			cached: true,
+		},
+		{
+			name: "eth_call block hash",
+			req:  &RPCReq{JSONRPC: "2.0", Method: "eth_call", Params: mustMarshalJSON([]interface{}{map[string]string{"to": "0x01"}, map[string]string{"blockHash": "0xc6ef2fc5426d6ad6fd9e2a26abeab0aa2411b7ab17f30a99d3cb96aed1d1055b"}}), ID: ID},
+			res:  &RPCRes{JSONRPC: "2.0", Result: "0x01", ID: ID},
+		},
+		{
+			name:   "eth_getLogs finalized range",
+			req:    &RPCReq{JSONRPC: "2.0", Method: "eth_getLogs", Params: mustMarshalJSON([]interface{}{map[string]string{"fromBlock": "0x10", "toBlock": "0x64"}}), ID: ID},
+			res:    &RPCRes{JSONRPC: "2.0", Result: []interface{}{}, ID: ID},
+			cached: true,
+		},
+		{
+			name: "eth_getLogs unfinalized range",
+			req:  &RPCReq{JSONRPC: "2.0", Method: "eth_getLogs", Params: mustMarshalJSON([]interface{}{map[string]string{"fromBlock": "0x10", "toBlock": "0x65"}}), ID: ID},
+			res:  &RPCRes{JSONRPC: "2.0", Result: []interface{}{}, ID: ID},
+		},
+		{
+			name: "eth_getLogs open range",
+			req:  &RPCReq{JSONRPC: "2.0", Method: "eth_getLogs", Params: mustMarshalJSON([]interface{}{map[string]string{"fromBlock": "0x10"}}), ID: ID},
+			res:  &RPCRes{JSONRPC: "2.0", Result: []interface{}{}, ID: ID},
+		},
+		{
+			name:   "eth_getTransactionReceipt finalized block",
+			req:    &RPCReq{JSONRPC: "2.0", Method: "eth_getTransactionReceipt", Params: mustMarshalJSON([]string{"0x01"}), ID: ID},
+			res:    &RPCRes{JSONRPC: "2.0", Result: map[string]interface{}{"blockNumber": "0x64"}, ID: ID},
+			cached: true,
+		},
+		{
+			name: "eth_getTransactionReceipt unfinalized block",
+			req:  &RPCReq{JSONRPC: "2.0", Method: "eth_getTransactionReceipt", Params: mustMarshalJSON([]string{"0x02"}), ID: ID},
+			res:  &RPCRes{JSONRPC: "2.0", Result: map[string]interface{}{"blockNumber": "0x65"}, ID: ID},
+		},
+	}
+
+	for _, rpc := range rpcs {
+		t.Run(rpc.name, func(t *testing.T) {
+			require.NoError(t, cache.PutRPC(ctx, rpc.req, rpc.res))
+			cachedRes, err := cache.GetRPC(ctx, rpc.req)
+			require.NoError(t, err)
+			if rpc.cached {
+				require.Equal(t, rpc.res, cachedRes)
+			} else {
+				require.Nil(t, cachedRes)
+			}
+		})
+	}
+
+	t.Run("invalidated on broken consensus", func(t *testing.T) {
+		req := rpcs[0].req
+		handler.Invalidate()
+		cachedRes, err := cache.GetRPC(ctx, req)
+		require.NoError(t, err)
+		require.Nil(t, cachedRes)
+	})
+
+	t.Run("invalidated when finalized block moves backwards", func(t *testing.T) {
+		req := rpcs[0].req
+		require.NoError(t, cache.PutRPC(ctx, req, rpcs[0].res))
+		cachedRes, err := cache.GetRPC(ctx, req)
+		require.NoError(t, err)
+		require.NotNil(t, cachedRes)
+
+		bg.Consensus.tracker.SetFinalizedBlockNumber(99)
+		require.NoError(t, cache.PutRPC(ctx, rpcs[3].req, rpcs[3].res))
+		cachedRes, err = cache.GetRPC(ctx, req)
+		require.NoError(t, err)
+		require.Nil(t, cachedRes)
+	})
+}
+
+func TestRPCCacheBlockAwareSharedGeneration(t *testing.T) {
+	ctx := context.Background()
+
+	redisServer, err := miniredis.Run()
+	require.NoError(t, err)
+	defer redisServer.Close()
+	redisClient := redis.NewClient(&redis.Options{
+		Addr: fmt.Sprintf("127.0.0.1:%s", redisServer.Port()),
+	})
+
+	bg := &BackendGroup{Name: "main"}
+	bg.Consensus = NewConsensusPoller(bg, WithAsyncHandler(NewNoopAsyncHandler()))
+	bg.Consensus.tracker.SetFinalizedBlockNumber(100)
+
+	// two proxyd instances sharing the cache
+	cache := newRedisCache(redisClient, "proxyd", time.Hour)
+	instances := make([]*BlockAwareMethodHandler, 2)
+	for i := range instances {
+		instances[i] = NewBlockAwareMethodHandler(cache, bg, "finalized", newRedisCacheGeneration(redisClient, "proxyd", bg.Name))
+	}
+
+	ID := []byte(strconv.Itoa(1))
+	req := &RPCReq{JSONRPC: "2.0", Method: "eth_getBlockByNumber", Params: mustMarshalJSON([]interface{}{"0x64", false}), ID: ID}
+	res := &RPCRes{JSONRPC: "2.0", Result: map[string]interface{}{"number": "0x64"}, ID: ID}
+
+	require.NoError(t, instances[0].PutRPCMethod(ctx, req, res))
+	cachedRes, err := instances[1].GetRPCMethod(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, res, cachedRes)
+
+	// an invalidation by one instance applies to the other
+	instances[0].Invalidate()
+	cachedRes, err = instances[1].GetRPCMethod(ctx, req)
+	require.NoError(t, err)
+	require.Nil(t, cachedRes)
+
+	generation, err := redisClient.Get(ctx, "proxyd:cache:generation:main").Uint64()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), generation)
+}
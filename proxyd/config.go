@@ -32,6 +32,10 @@ type ServerConfig struct {
 type CacheConfig struct {
 	Enabled bool         `toml:"enabled"`
 	TTL     TOMLDuration `toml:"ttl"`
+	// BlockAware caches block number addressed methods of consensus-aware backend groups,
+	// for blocks at or below the consensus BlockAwareTag ("finalized" by default, or "safe").
+	BlockAware    bool   `toml:"block_aware"`
+	BlockAwareTag string `toml:"block_aware_tag"`
 }
 
 type RedisConfig struct {
@@ -62,6 +66,45 @@ type RateLimitMethodOverride struct {
 	Global   bool         `toml:"global"`
 }
 
+// RoutingRuleConfig routes the requests matching all of its criteria to a backend group.
+type RoutingRuleConfig struct {
+	// Methods are method names, or prefixes if ending with *, e.g. "optimism_*".
+	Methods []string `toml:"methods"`
+	// BlockTags match the block referenced by the request: latest, pending, safe, finalized,
+	// or "number" and "hash" for blocks referenced by number or hash.
+	BlockTags []string `toml:"block_tags"`
+	// MinBlockAge matches blocks referenced by number at least this many blocks behind the consensus latest block.
+	MinBlockAge uint64 `toml:"min_block_age"`
+	// Headers match request headers by exact value.
+	Headers               map[string]string `toml:"headers"`
+	BackendGroup          string            `toml:"backend_group"`
+	FallbackBackendGroups []string          `toml:"fallback_backend_groups"`
+}
+
+// UsageConfig configures the plans of API keys, and the accounting of their usage.
+type UsageConfig struct {
+	UseRedis bool `toml:"use_redis"`
+	// AdminToken enables the /admin/usage endpoint, authenticated with a bearer token.
+	AdminToken string `toml:"admin_token"`
+	// Keys maps authentication aliases to plans.
+	Keys  map[string]string            `toml:"keys"`
+	Plans map[string]*APIKeyPlanConfig `toml:"plans"`
+}
+
+type APIKeyPlanConfig struct {
+	MethodWhitelist     []string            `toml:"method_whitelist"`
+	MethodBlacklist     []string            `toml:"method_blacklist"`
+	DefaultComputeUnits int64               `toml:"default_compute_units"`
+	ComputeUnits        map[string]int64    `toml:"compute_units"`
+	Quotas              []APIKeyQuotaConfig `toml:"quotas"`
+}
+
+type APIKeyQuotaConfig struct {
+	Interval        TOMLDuration `toml:"interval"`
+	MaxRequests     int64        `toml:"max_requests"`
+	MaxComputeUnits int64        `toml:"max_compute_units"`
+}
+
 type TOMLDuration time.Duration
 
 func (t *TOMLDuration) UnmarshalText(b []byte) error {
@@ -165,6 +208,12 @@ type Config struct {
 	WSMethodWhitelist     []string              `toml:"ws_method_whitelist"`
 	WhitelistErrorMessage string                `toml:"whitelist_error_message"`
 	SenderRateLimit       SenderRateLimitConfig `toml:"sender_rate_limit"`
+	Usage                 UsageConfig           `toml:"usage"`
+	RoutingRules          []*RoutingRuleConfig  `toml:"routing_rules"`
+
+	// WSMultiplexSubscriptions terminates eth_subscribe in proxyd, and shares a single
+	// backend subscription per subscription type and filter between all WS clients.
+	WSMultiplexSubscriptions bool `toml:"ws_multiplex_subscriptions"`
 }
 
 func ReadFromEnvOrConfig(value string) (string, error) {
@@ -0,0 +1,81 @@
+package integration_tests
+
+import (
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/proxyd"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoutingRules(t *testing.T) {
+	mainBackend := NewMockBackend(BatchedResponseHandler(200, goodResponse))
+	defer mainBackend.Close()
+	sequencerBackend := NewMockBackend(BatchedResponseHandler(200, goodResponse))
+	defer sequencerBackend.Close()
+	archiveBackend := NewMockBackend(BatchedResponseHandler(200, goodResponse))
+	defer archiveBackend.Close()
+	nodeBackend := NewMockBackend(BatchedResponseHandler(200, goodResponse))
+	defer nodeBackend.Close()
+
+	require.NoError(t, os.Setenv("MAIN_BACKEND_RPC_URL", mainBackend.URL()))
+	require.NoError(t, os.Setenv("SEQUENCER_BACKEND_RPC_URL", sequencerBackend.URL()))
+	require.NoError(t, os.Setenv("ARCHIVE_BACKEND_RPC_URL", archiveBackend.URL()))
+	require.NoError(t, os.Setenv("NODE_BACKEND_RPC_URL", nodeBackend.URL()))
+
+	config := ReadConfig("routing")
+	client := NewProxydClient("http://127.0.0.1:8545")
+	_, shutdown, err := proxyd.Start(config)
+	require.NoError(t, err)
+	defer shutdown()
+
+	archiveHeaders := make(http.Header)
+	archiveHeaders.Set("X-Proxyd-Route", "archive")
+	archiveClient := NewProxydClientWithHeaders("http://127.0.0.1:8545", archiveHeaders)
+
+	tests := []struct {
+		name    string
+		client  *ProxydHTTPClient
+		method  string
+		params  []interface{}
+		backend *MockBackend
+	}{
+		{"method mapping", client, "eth_chainId", nil, mainBackend},
+		{"method rule", client, "eth_sendRawTransaction", []interface{}{"0x00"}, sequencerBackend},
+		{"method prefix rule", client, "optimism_syncStatus", nil, nodeBackend},
+		{"latest block", client, "eth_getBalance", []interface{}{"0x01", "latest"}, mainBackend},
+		{"omitted block", client, "eth_getBalance", []interface{}{"0x01"}, mainBackend},
+		{"historical block", client, "eth_getBalance", []interface{}{"0x01", "0x10"}, archiveBackend},
+		{"header rule", archiveClient, "eth_getBlockByNumber", []interface{}{"latest", false}, archiveBackend},
+		{"header rule without header", client, "eth_getBlockByNumber", []interface{}{"latest", false}, mainBackend},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, backend := range []*MockBackend{mainBackend, sequencerBackend, archiveBackend, nodeBackend} {
+				backend.Reset()
+			}
+			res, statusCode, err := tt.client.SendRPC(tt.method, tt.params)
+			require.NoError(t, err)
+			require.Equal(t, 200, statusCode)
+			RequireEqualJSON(t, []byte(goodResponse), res)
+			require.Len(t, tt.backend.Requests(), 1)
+		})
+	}
+
+	t.Run("unmapped method", func(t *testing.T) {
+		res, _, err := client.SendRPC("eth_getCode", []interface{}{"0x01", "latest"})
+		require.NoError(t, err)
+		require.Contains(t, string(res), "rpc method is not whitelisted")
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		mainBackend.Reset()
+		sequencerBackend.SetHandler(SingleResponseHandler(503, "unavailable"))
+		res, statusCode, err := client.SendRPC("eth_sendRawTransaction", []interface{}{"0x00"})
+		require.NoError(t, err)
+		require.Equal(t, 200, statusCode)
+		RequireEqualJSON(t, []byte(goodResponse), res)
+		require.Len(t, mainBackend.Requests(), 1)
+	})
+}
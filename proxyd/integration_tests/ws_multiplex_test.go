@@ -0,0 +1,163 @@
+package integration_tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/proxyd"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+// subscriptionBackend is a mock WS backend that accepts subscriptions, and publishes events to them.
+type subscriptionBackend struct {
+	*MockWSBackend
+	name string
+
+	mu         sync.Mutex
+	conn       *websocket.Conn
+	subscribes int
+}
+
+func newSubscriptionBackend(name string) *subscriptionBackend {
+	b := &subscriptionBackend{name: name}
+	b.MockWSBackend = NewMockWSBackend(nil, b.onMessage, nil)
+	return b
+}
+
+func (b *subscriptionBackend) onMessage(conn *websocket.Conn, msgType int, data []byte) {
+	var req proxyd.RPCReq
+	if err := json.Unmarshal(data, &req); err != nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var result string
+	switch req.Method {
+	case "eth_subscribe":
+		b.conn = conn
+		b.subscribes++
+		result = fmt.Sprintf("\"0x%s%d\"", b.name, b.subscribes)
+	default:
+		result = "true"
+	}
+	_ = conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf("{\"jsonrpc\":\"2.0\",\"id\":%s,\"result\":%s}", req.ID, result)))
+}
+
+func (b *subscriptionBackend) Subscribes() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.subscribes
+}
+
+func (b *subscriptionBackend) Publish(t *testing.T, event string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	require.NotNil(t, b.conn)
+	msg := fmt.Sprintf("{\"jsonrpc\":\"2.0\",\"method\":\"eth_subscription\",\"params\":{\"subscription\":\"0x%s%d\",\"result\":%s}}", b.name, b.subscribes, event)
+	require.NoError(t, b.conn.WriteMessage(websocket.TextMessage, []byte(msg)))
+}
+
+type subscriptionClient struct {
+	*ProxydWSClient
+	msgs chan []byte
+}
+
+func newSubscriptionClient(t *testing.T) *subscriptionClient {
+	c := &subscriptionClient{msgs: make(chan []byte, 16)}
+	client, err := NewProxydWSClient("ws://127.0.0.1:8546", func(msgType int, data []byte) {
+		c.msgs <- data
+	}, nil)
+	require.NoError(t, err)
+	c.ProxydWSClient = client
+	return c
+}
+
+func (c *subscriptionClient) Next(t *testing.T) map[string]interface{} {
+	select {
+	case msg := <-c.msgs:
+		var res map[string]interface{}
+		require.NoError(t, json.Unmarshal(msg, &res))
+		return res
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for message")
+		return nil
+	}
+}
+
+func (c *subscriptionClient) Subscribe(t *testing.T) string {
+	require.NoError(t, c.WriteMessage(websocket.TextMessage, []byte("{\"jsonrpc\":\"2.0\",\"id\":1,\"method\":\"eth_subscribe\",\"params\":[\"newHeads\"]}")))
+	res := c.Next(t)
+	require.Nil(t, res["error"])
+	id, ok := res["result"].(string)
+	require.True(t, ok)
+	return id
+}
+
+func (c *subscriptionClient) RequireEvent(t *testing.T, subID string, event string) {
+	res := c.Next(t)
+	require.Equal(t, "eth_subscription", res["method"])
+	params := res["params"].(map[string]interface{})
+	require.Equal(t, subID, params["subscription"])
+	require.Equal(t, event, params["result"])
+}
+
+func TestWSMultiplexSubscriptions(t *testing.T) {
+	first := newSubscriptionBackend("first")
+	defer first.Close()
+	second := newSubscriptionBackend("second")
+	defer second.Close()
+
+	require.NoError(t, os.Setenv("FIRST_BACKEND_RPC_URL", first.URL()))
+	require.NoError(t, os.Setenv("SECOND_BACKEND_RPC_URL", second.URL()))
+
+	config := ReadConfig("ws_multiplex")
+	_, shutdown, err := proxyd.Start(config)
+	require.NoError(t, err)
+	defer shutdown()
+
+	clientA := newSubscriptionClient(t)
+	defer clientA.HardClose()
+	clientB := newSubscriptionClient(t)
+	defer clientB.HardClose()
+
+	// Both clients share a single backend subscription, but get their own subscription IDs
+	subA := clientA.Subscribe(t)
+	subB := clientB.Subscribe(t)
+	require.NotEqual(t, subA, subB)
+	require.Equal(t, 1, first.Subscribes())
+
+	first.Publish(t, "\"0x01\"")
+	clientA.RequireEvent(t, subA, "0x01")
+	clientB.RequireEvent(t, subB, "0x01")
+
+	// Unsubscribing an unknown subscription fails
+	require.NoError(t, clientA.WriteMessage(websocket.TextMessage, []byte("{\"jsonrpc\":\"2.0\",\"id\":2,\"method\":\"eth_unsubscribe\",\"params\":[\"0x1234\"]}")))
+	require.Equal(t, "subscription not found", clientA.Next(t)["error"].(map[string]interface{})["message"])
+
+	// The subscription is resubscribed on the second backend when the first backend fails
+	first.Close()
+	require.Eventually(t, func() bool {
+		return second.Subscribes() == 1
+	}, 10*time.Second, 10*time.Millisecond)
+
+	second.Publish(t, "\"0x02\"")
+	clientA.RequireEvent(t, subA, "0x02")
+	clientB.RequireEvent(t, subB, "0x02")
+
+	// Clients that unsubscribe no longer receive events
+	require.NoError(t, clientA.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf("{\"jsonrpc\":\"2.0\",\"id\":3,\"method\":\"eth_unsubscribe\",\"params\":[\"%s\"]}", subA))))
+	require.Equal(t, true, clientA.Next(t)["result"])
+
+	second.Publish(t, "\"0x03\"")
+	clientB.RequireEvent(t, subB, "0x03")
+	select {
+	case msg := <-clientA.msgs:
+		t.Fatalf("unexpected message after unsubscribing: %s", msg)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
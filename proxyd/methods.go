@@ -5,10 +5,14 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"sync"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
 type RPCMethodHandler interface {
@@ -90,3 +94,217 @@ func (e *StaticMethodHandler) PutRPCMethod(ctx context.Context, req *RPCReq, res
 	}
 	return nil
 }
+
+// BlockAwareMethodHandler caches the responses of requests that reference a block at or below the
+// consensus safe or finalized block of a consensus-aware backend group.
+// Cached responses are invalidated when the consensus of the group breaks, or the safe or finalized
+// block moves backwards, since the referenced blocks may have been reorged.
+type BlockAwareMethodHandler struct {
+	cache Cache
+	bg    *BackendGroup
+	// tag is the consensus block tag, "safe" or "finalized", up to which responses are cached
+	tag string
+	// generation is part of the cache keys, and incremented to invalidate all cached responses
+	generation CacheGeneration
+
+	m sync.Mutex
+	// highest is the highest consensus block seen since the last invalidation
+	highest uint64
+}
+
+func NewBlockAwareMethodHandler(cache Cache, bg *BackendGroup, tag string, generation CacheGeneration) *BlockAwareMethodHandler {
+	return &BlockAwareMethodHandler{
+		cache:      cache,
+		bg:         bg,
+		tag:        tag,
+		generation: generation,
+	}
+}
+
+// Invalidate drops all cached responses of the backend group.
+func (e *BlockAwareMethodHandler) Invalidate() {
+	e.m.Lock()
+	defer e.m.Unlock()
+	e.invalidate(context.Background())
+}
+
+func (e *BlockAwareMethodHandler) invalidate(ctx context.Context) {
+	e.highest = 0
+	generation, err := e.generation.Incr(ctx)
+	if err != nil {
+		log.Error("error invalidating block aware cache", "backend_group", e.bg.Name, "err", err)
+		return
+	}
+	log.Info("invalidated block aware cache", "backend_group", e.bg.Name, "generation", generation)
+}
+
+// maxBlock returns the highest block number that may be cached.
+func (e *BlockAwareMethodHandler) maxBlock(ctx context.Context) uint64 {
+	e.m.Lock()
+	defer e.m.Unlock()
+	cp := e.bg.Consensus
+	if cp == nil {
+		return 0
+	}
+	var block uint64
+	if e.tag == "safe" {
+		block = uint64(cp.GetSafeBlockNumber())
+	} else {
+		block = uint64(cp.GetFinalizedBlockNumber())
+	}
+	if block < e.highest {
+		e.invalidate(ctx)
+	}
+	e.highest = block
+	return block
+}
+
+func (e *BlockAwareMethodHandler) key(req *RPCReq, generation uint64) string {
+	h := sha256.New()
+	h.Write(req.Params)
+	signature := fmt.Sprintf("%x", h.Sum(nil))
+	return strings.Join([]string{"cache", req.Method, e.bg.Name, strconv.FormatUint(generation, 10), signature}, ":")
+}
+
+func (e *BlockAwareMethodHandler) GetRPCMethod(ctx context.Context, req *RPCReq) (*RPCRes, error) {
+	if !blockAwareCacheable(req) {
+		return nil, nil
+	}
+	generation, err := e.generation.Get(ctx)
+	if err != nil {
+		log.Error("error reading cache generation", "backend_group", e.bg.Name, "method", req.Method, "err", err)
+		return nil, err
+	}
+
+	key := e.key(req, generation)
+	val, err := e.cache.Get(ctx, key)
+	if err != nil {
+		log.Error("error reading from cache", "key", key, "method", req.Method, "err", err)
+		return nil, err
+	}
+	if val == "" {
+		return nil, nil
+	}
+
+	var result interface{}
+	if err := json.Unmarshal([]byte(val), &result); err != nil {
+		log.Error("error unmarshalling value from cache", "key", key, "method", req.Method, "err", err)
+		return nil, err
+	}
+	return &RPCRes{
+		JSONRPC: req.JSONRPC,
+		Result:  result,
+		ID:      req.ID,
+	}, nil
+}
+
+func (e *BlockAwareMethodHandler) PutRPCMethod(ctx context.Context, req *RPCReq, res *RPCRes) error {
+	if !blockAwareCacheable(req) {
+		return nil
+	}
+	block, ok := blockAwareReferencedBlock(req, res)
+	if !ok {
+		return nil
+	}
+	maxBlock := e.maxBlock(ctx)
+	if maxBlock == 0 || block > maxBlock {
+		return nil
+	}
+	generation, err := e.generation.Get(ctx)
+	if err != nil {
+		log.Error("error reading cache generation", "backend_group", e.bg.Name, "method", req.Method, "err", err)
+		return err
+	}
+
+	key := e.key(req, generation)
+	value := mustMarshalJSON(res.Result)
+
+	if err := e.cache.Put(ctx, key, string(value)); err != nil {
+		log.Error("error putting into cache", "key", key, "method", req.Method, "err", err)
+		return err
+	}
+	return nil
+}
+
+// blockAwareCacheMethods are the methods cached by the BlockAwareMethodHandler
+var blockAwareCacheMethods = []string{
+	"eth_getBlockByNumber",
+	"eth_getLogs",
+	"eth_call",
+	"eth_getTransactionReceipt",
+}
+
+// blockAwareCacheable returns whether the response to the request depends only on the request,
+// and not on the chain head, i.e. blocks are referenced by number and not by tag.
+func blockAwareCacheable(req *RPCReq) bool {
+	switch req.Method {
+	case "eth_getTransactionReceipt":
+		var p []json.RawMessage
+		return json.Unmarshal(req.Params, &p) == nil && len(p) == 1
+	default:
+		_, ok := blockAwareReferencedBlock(req, nil)
+		return ok
+	}
+}
+
+// blockAwareReferencedBlock returns the highest block number referenced by the request,
+// or by the response for requests that don't reference a block.
+func blockAwareReferencedBlock(req *RPCReq, res *RPCRes) (uint64, bool) {
+	switch req.Method {
+	case "eth_getBlockByNumber":
+		return blockNumberParam(req, 0)
+	case "eth_call":
+		return blockNumberParam(req, 1)
+	case "eth_getLogs":
+		var p []struct {
+			FromBlock *rpc.BlockNumber `json:"fromBlock"`
+			ToBlock   *rpc.BlockNumber `json:"toBlock"`
+			BlockHash *common.Hash     `json:"blockHash"`
+		}
+		if err := json.Unmarshal(req.Params, &p); err != nil || len(p) != 1 {
+			return 0, false
+		}
+		filter := p[0]
+		if filter.BlockHash != nil || filter.FromBlock == nil || filter.ToBlock == nil ||
+			*filter.FromBlock < 0 || *filter.ToBlock < 0 {
+			return 0, false
+		}
+		return uint64(max(*filter.FromBlock, *filter.ToBlock)), true
+	case "eth_getTransactionReceipt":
+		if res == nil {
+			return 0, false
+		}
+		receipt, ok := res.Result.(map[string]interface{})
+		if !ok {
+			return 0, false
+		}
+		s, ok := receipt["blockNumber"].(string)
+		if !ok {
+			return 0, false
+		}
+		block, err := hexutil.DecodeUint64(s)
+		if err != nil {
+			return 0, false
+		}
+		return block, true
+	}
+	return 0, false
+}
+
+// blockNumberParam returns the block number of the block number or hash param at the given position,
+// if the block is referenced by number.
+func blockNumberParam(req *RPCReq, pos int) (uint64, bool) {
+	var p []json.RawMessage
+	if err := json.Unmarshal(req.Params, &p); err != nil || len(p) <= pos {
+		return 0, false
+	}
+	var bnh rpc.BlockNumberOrHash
+	if err := json.Unmarshal(p[pos], &bnh); err != nil {
+		return 0, false
+	}
+	num, ok := bnh.Number()
+	if !ok || num < 0 {
+		return 0, false
+	}
+	return uint64(num), true
+}
@@ -108,6 +108,18 @@ var (
 		"backend_name",
 	})
 
+	activeUpstreamWsSubscriptionsGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: MetricsNamespace,
+		Name:      "active_upstream_ws_subscriptions",
+		Help:      "Gauge of active multiplexed WS subscriptions on the backend.",
+	})
+
+	activeClientWsSubscriptionsGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: MetricsNamespace,
+		Name:      "active_client_ws_subscriptions",
+		Help:      "Gauge of active multiplexed WS subscriptions of clients.",
+	})
+
 	unserviceableRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
 		Namespace: MetricsNamespace,
 		Name:      "unserviceable_requests_total",
@@ -150,6 +162,33 @@ var (
 		"source",
 	})
 
+	apiKeyRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "api_key_requests_total",
+		Help:      "Count of requests metered per API key.",
+	}, []string{
+		"auth",
+		"plan",
+	})
+
+	apiKeyComputeUnitsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "api_key_compute_units_total",
+		Help:      "Count of compute units consumed per API key.",
+	}, []string{
+		"auth",
+		"plan",
+	})
+
+	apiKeyQuotaExceededTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "api_key_quota_exceeded_total",
+		Help:      "Count of requests rejected because the API key is over quota.",
+	}, []string{
+		"auth",
+		"plan",
+	})
+
 	requestPayloadSizesGauge = promauto.NewHistogramVec(prometheus.HistogramOpts{
 		Namespace: MetricsNamespace,
 		Name:      "request_payload_sizes",
@@ -434,6 +473,15 @@ func RecordRedisError(source string) {
 	redisErrorsTotal.WithLabelValues(source).Inc()
 }
 
+func RecordAPIKeyUsage(auth, plan string, computeUnits int64) {
+	apiKeyRequestsTotal.WithLabelValues(auth, plan).Inc()
+	apiKeyComputeUnitsTotal.WithLabelValues(auth, plan).Add(float64(computeUnits))
+}
+
+func RecordAPIKeyQuotaExceeded(auth, plan string) {
+	apiKeyQuotaExceededTotal.WithLabelValues(auth, plan).Inc()
+}
+
 func RecordRPCError(ctx context.Context, backendName, method string, err error) {
 	rpcErr, ok := err.(*RPCErr)
 	var code int
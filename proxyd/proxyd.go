@@ -55,6 +55,10 @@ func Start(config *Config) (*Server, func(), error) {
 		return nil, nil, errors.New("must specify a Redis URL if UseRedis is true in rate limit config")
 	}
 
+	if redisClient == nil && config.Usage.UseRedis {
+		return nil, nil, errors.New("must specify a Redis URL if UseRedis is true in usage config")
+	}
+
 	// While modifying shared globals is a bad practice, the alternative
 	// is to clone these errors on every invocation. This is inefficient.
 	// We'd also have to make sure that errors.Is and errors.As continue
@@ -249,6 +253,15 @@ func Start(config *Config) (*Server, func(), error) {
 		}
 	}
 
+	routingRules := make([]*RoutingRule, 0, len(config.RoutingRules))
+	for _, rcfg := range config.RoutingRules {
+		rule, err := NewRoutingRule(rcfg, backendGroups)
+		if err != nil {
+			return nil, nil, err
+		}
+		routingRules = append(routingRules, rule)
+	}
+
 	var resolvedAuth map[string]string
 
 	if config.Authentication != nil {
@@ -265,6 +278,8 @@ func Start(config *Config) (*Server, func(), error) {
 	var (
 		cache    Cache
 		rpcCache RPCCache
+		// block aware cache handlers by backend group name
+		blockAwareHandlers map[string]*BlockAwareMethodHandler
 	)
 	if config.Cache.Enabled {
 		if redisClient == nil {
@@ -277,7 +292,15 @@ func Start(config *Config) (*Server, func(), error) {
 			}
 			cache = newRedisCache(redisClient, config.Redis.Namespace, ttl)
 		}
-		rpcCache = newRPCCache(newCacheWithCompression(cache))
+		rc := newRPCCache(newCacheWithCompression(cache))
+		if config.Cache.BlockAware {
+			handlers, err := newBlockAwareHandlers(config, backendGroups, rc, redisClient)
+			if err != nil {
+				return nil, nil, err
+			}
+			blockAwareHandlers = handlers
+		}
+		rpcCache = rc
 	}
 
 	srv, err := NewServer(
@@ -310,6 +333,32 @@ func Start(config *Config) (*Server, func(), error) {
 		}
 	}
 
+	srv.routingRules = routingRules
+
+	if len(config.Usage.Keys) > 0 {
+		var store UsageStore
+		if config.Usage.UseRedis {
+			store = NewRedisUsageStore(redisClient, config.Redis.Namespace)
+		} else {
+			store = NewMemoryUsageStore()
+		}
+		srv.usage, err = NewUsageTracker(store, config.Usage)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error creating usage tracker: %w", err)
+		}
+		if config.Usage.AdminToken != "" {
+			srv.adminToken, err = ReadFromEnvOrConfig(config.Usage.AdminToken)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	if config.WSMultiplexSubscriptions && wsBackendGroup != nil {
+		srv.wsSubscriptions = NewWSSubscriptionManager(wsBackendGroup)
+		srv.wsSubscriptions.Start()
+	}
+
 	if config.Metrics.Enabled {
 		addr := fmt.Sprintf("%s:%d", config.Metrics.Host, config.Metrics.Port)
 		log.Info("starting metrics server", "addr", addr)
@@ -410,6 +459,10 @@ func Start(config *Config) (*Server, func(), error) {
 				copts = append(copts, WithTracker(tracker))
 			}
 
+			if h := blockAwareHandlers[bgName]; h != nil {
+				copts = append(copts, WithListener(h.Invalidate))
+			}
+
 			cp := NewConsensusPoller(bg, copts...)
 			bg.Consensus = cp
 
@@ -431,6 +484,38 @@ func Start(config *Config) (*Server, func(), error) {
 	return srv, shutdownFunc, nil
 }
 
+// newBlockAwareHandlers registers the block aware cache handlers of the methods that are mapped to consensus-aware
+// backend groups, and returns the handlers by backend group name.
+// The cache generations are kept in Redis when configured, so invalidations apply to all proxyd instances sharing the cache.
+func newBlockAwareHandlers(config *Config, backendGroups map[string]*BackendGroup, rc *rpcCache, redisClient *redis.Client) (map[string]*BlockAwareMethodHandler, error) {
+	tag := config.Cache.BlockAwareTag
+	if tag == "" {
+		tag = "finalized"
+	}
+	if tag != "finalized" && tag != "safe" {
+		return nil, fmt.Errorf("invalid block_aware_tag %s, must be finalized or safe", tag)
+	}
+	handlers := make(map[string]*BlockAwareMethodHandler)
+	for _, method := range blockAwareCacheMethods {
+		bgName := config.RPCMethodMappings[method]
+		if bgName == "" || !config.BackendGroups[bgName].ConsensusAware {
+			log.Warn("not caching method, as it is not mapped to a consensus aware backend group", "method", method)
+			continue
+		}
+		h := handlers[bgName]
+		if h == nil {
+			var generation CacheGeneration = newMemoryCacheGeneration()
+			if redisClient != nil {
+				generation = newRedisCacheGeneration(redisClient, config.Redis.Namespace, bgName)
+			}
+			h = NewBlockAwareMethodHandler(rc.cache, backendGroups[bgName], tag, generation)
+			handlers[bgName] = h
+		}
+		rc.setHandler(method, h)
+	}
+	return handlers, nil
+}
+
 func validateReceiptsTarget(val string) (string, error) {
 	if val == "" {
 		val = ReceiptsTargetDebugGetRawReceipts
@@ -0,0 +1,175 @@
+package proxyd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// blockTagNumber matches blocks referenced by number
+	blockTagNumber = "number"
+	// blockTagHash matches blocks referenced by hash
+	blockTagHash = "hash"
+)
+
+var validRoutingBlockTags = []string{"latest", "pending", "safe", "finalized", blockTagNumber, blockTagHash}
+
+// blockParamPositions are the positions of the block number or hash param of methods that take one
+var blockParamPositions = map[string]int{
+	"eth_getBalance":                          1,
+	"eth_getCode":                             1,
+	"eth_getTransactionCount":                 1,
+	"eth_call":                                1,
+	"eth_estimateGas":                         1,
+	"eth_getStorageAt":                        2,
+	"eth_getProof":                            2,
+	"eth_getBlockByNumber":                    0,
+	"eth_getBlockTransactionCountByNumber":    0,
+	"eth_getUncleCountByBlockNumber":          0,
+	"eth_getTransactionByBlockNumberAndIndex": 0,
+	"eth_getUncleByBlockNumberAndIndex":       0,
+	"eth_getBlockReceipts":                    0,
+	"debug_getRawReceipts":                    0,
+}
+
+// RoutingRule routes the requests it matches to a backend group, with fallback groups in case it fails.
+// A request matches if it matches all the criteria of the rule.
+type RoutingRule struct {
+	// methods are method names, or prefixes if ending with *
+	methods     []string
+	blockTags   *StringSet
+	minBlockAge uint64
+	headers     map[string]string
+
+	backendGroup          string
+	fallbackBackendGroups []string
+}
+
+func NewRoutingRule(config *RoutingRuleConfig, backendGroups map[string]*BackendGroup) (*RoutingRule, error) {
+	if len(config.Methods) == 0 {
+		return nil, fmt.Errorf("routing rule must match at least one method")
+	}
+	for _, bg := range append([]string{config.BackendGroup}, config.FallbackBackendGroups...) {
+		if backendGroups[bg] == nil {
+			return nil, fmt.Errorf("routing rule references undefined backend group %s", bg)
+		}
+	}
+	validTags := NewStringSetFromStrings(validRoutingBlockTags)
+	for _, tag := range config.BlockTags {
+		if !validTags.Has(tag) {
+			return nil, fmt.Errorf("invalid routing rule block tag %s, must be one of %s", tag, strings.Join(validRoutingBlockTags, ", "))
+		}
+	}
+	rule := &RoutingRule{
+		methods:               config.Methods,
+		minBlockAge:           config.MinBlockAge,
+		headers:               config.Headers,
+		backendGroup:          config.BackendGroup,
+		fallbackBackendGroups: config.FallbackBackendGroups,
+	}
+	if len(config.BlockTags) > 0 {
+		rule.blockTags = NewStringSetFromStrings(config.BlockTags)
+	}
+	return rule, nil
+}
+
+func (r *RoutingRule) matchMethod(method string) bool {
+	for _, m := range r.methods {
+		if prefix, ok := strings.CutSuffix(m, "*"); ok {
+			if strings.HasPrefix(method, prefix) {
+				return true
+			}
+		} else if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// Match returns whether the request matches the rule. latest returns the latest block number,
+// or false if it is not known, in which case the minimum block age is not enforced.
+func (r *RoutingRule) Match(req *RPCReq, headers http.Header, latest func() (uint64, bool)) bool {
+	if !r.matchMethod(req.Method) {
+		return false
+	}
+	for name, val := range r.headers {
+		if headers.Get(name) != val {
+			return false
+		}
+	}
+	if r.blockTags == nil && r.minBlockAge == 0 {
+		return true
+	}
+	tag, num, ok := blockParam(req)
+	if !ok {
+		return false
+	}
+	if r.blockTags != nil && !r.blockTags.Has(tag) {
+		return false
+	}
+	if r.minBlockAge > 0 {
+		if tag != blockTagNumber {
+			return false
+		}
+		if head, ok := latest(); ok && num+r.minBlockAge > head {
+			return false
+		}
+	}
+	return true
+}
+
+// blockParam returns the tag of the block referenced by the request, and the block number
+// if it is referenced by number. It returns false if the method does not reference a block.
+func blockParam(req *RPCReq) (string, uint64, bool) {
+	var p []json.RawMessage
+	if err := json.Unmarshal(req.Params, &p); err != nil {
+		return "", 0, false
+	}
+
+	var raw json.RawMessage
+	if req.Method == "eth_getLogs" {
+		if len(p) < 1 {
+			return "", 0, false
+		}
+		var filter struct {
+			FromBlock json.RawMessage `json:"fromBlock"`
+			BlockHash json.RawMessage `json:"blockHash"`
+		}
+		if err := json.Unmarshal(p[0], &filter); err != nil {
+			return "", 0, false
+		}
+		if filter.BlockHash != nil {
+			return blockTagHash, 0, true
+		}
+		raw = filter.FromBlock
+	} else {
+		pos, ok := blockParamPositions[req.Method]
+		if !ok {
+			return "", 0, false
+		}
+		if len(p) > pos {
+			raw = p[pos]
+		}
+	}
+	// the block param defaults to latest if omitted
+	if raw == nil {
+		return "latest", 0, true
+	}
+
+	var bnh rpc.BlockNumberOrHash
+	if err := json.Unmarshal(raw, &bnh); err != nil {
+		return "", 0, false
+	}
+	if _, ok := bnh.Hash(); ok {
+		return blockTagHash, 0, true
+	}
+	num, _ := bnh.Number()
+	if num >= 0 {
+		return blockTagNumber, uint64(num), true
+	}
+	return num.String(), 0, true
+}
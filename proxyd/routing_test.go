@@ -0,0 +1,53 @@
+package proxyd
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoutingRuleMatch(t *testing.T) {
+	backendGroups := map[string]*BackendGroup{"archive": {Name: "archive"}}
+	rule, err := NewRoutingRule(&RoutingRuleConfig{
+		Methods:      []string{"eth_getBalance", "eth_getLogs"},
+		MinBlockAge:  100,
+		BackendGroup: "archive",
+	}, backendGroups)
+	require.NoError(t, err)
+
+	latest := func() (uint64, bool) { return 1000, true }
+	unknown := func() (uint64, bool) { return 0, false }
+	tests := []struct {
+		name   string
+		req    *RPCReq
+		latest func() (uint64, bool)
+		match  bool
+	}{
+		{"old block", &RPCReq{Method: "eth_getBalance", Params: mustMarshalJSON([]string{"0x01", "0x384"})}, latest, true},
+		{"recent block", &RPCReq{Method: "eth_getBalance", Params: mustMarshalJSON([]string{"0x01", "0x385"})}, latest, false},
+		{"unknown latest block", &RPCReq{Method: "eth_getBalance", Params: mustMarshalJSON([]string{"0x01", "0x385"})}, unknown, true},
+		{"block tag", &RPCReq{Method: "eth_getBalance", Params: mustMarshalJSON([]string{"0x01", "latest"})}, latest, false},
+		{"omitted block", &RPCReq{Method: "eth_getBalance", Params: mustMarshalJSON([]string{"0x01"})}, latest, false},
+		{"old log range", &RPCReq{Method: "eth_getLogs", Params: mustMarshalJSON([]map[string]string{{"fromBlock": "0x10", "toBlock": "latest"}})}, latest, true},
+		{"log block hash", &RPCReq{Method: "eth_getLogs", Params: mustMarshalJSON([]map[string]string{{"blockHash": "0xc6ef2fc5426d6ad6fd9e2a26abeab0aa2411b7ab17f30a99d3cb96aed1d1055b"}})}, latest, false},
+		{"other method", &RPCReq{Method: "eth_getCode", Params: mustMarshalJSON([]string{"0x01", "0x10"})}, latest, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.match, rule.Match(tt.req, http.Header{}, tt.latest))
+		})
+	}
+}
+
+func TestRoutingRuleInvalid(t *testing.T) {
+	backendGroups := map[string]*BackendGroup{"main": {Name: "main"}}
+	_, err := NewRoutingRule(&RoutingRuleConfig{Methods: []string{"eth_call"}, BackendGroup: "missing"}, backendGroups)
+	require.Error(t, err)
+	_, err = NewRoutingRule(&RoutingRuleConfig{Methods: []string{"eth_call"}, BackendGroup: "main", FallbackBackendGroups: []string{"missing"}}, backendGroups)
+	require.Error(t, err)
+	_, err = NewRoutingRule(&RoutingRuleConfig{BackendGroup: "main"}, backendGroups)
+	require.Error(t, err)
+	_, err = NewRoutingRule(&RoutingRuleConfig{Methods: []string{"eth_call"}, BlockTags: []string{"earliest"}, BackendGroup: "main"}, backendGroups)
+	require.Error(t, err)
+}
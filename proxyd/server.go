@@ -3,6 +3,7 @@ package proxyd
 import (
 	"context"
 	"crypto/rand"
+	"crypto/subtle"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -34,6 +35,7 @@ const (
 	ContextKeyAuth               = "authorization"
 	ContextKeyReqID              = "req_id"
 	ContextKeyXForwardedFor      = "x_forwarded_for"
+	ContextKeyHeaders            = "headers"
 	DefaultMaxBatchRPCCallsLimit = 100
 	MaxBatchRPCCallsHardLimit    = 1000
 	cacheStatusHdr               = "X-Proxyd-Cache-Status"
@@ -54,6 +56,10 @@ type Server struct {
 	BackendGroups          map[string]*BackendGroup
 	wsBackendGroup         *BackendGroup
 	wsMethodWhitelist      *StringSet
+	wsSubscriptions        *WSSubscriptionManager
+	routingRules           []*RoutingRule
+	usage                  *UsageTracker
+	adminToken             string
 	rpcMethodMappings      map[string]string
 	maxBodySize            int64
 	enableRequestLog       bool
@@ -204,6 +210,9 @@ func (s *Server) RPCListenAndServe(host string, port int) error {
 	s.srvMu.Lock()
 	hdlr := mux.NewRouter()
 	hdlr.HandleFunc("/healthz", s.HandleHealthz).Methods("GET")
+	if s.usage != nil && s.adminToken != "" {
+		hdlr.HandleFunc("/admin/usage", s.HandleUsage).Methods("GET")
+	}
 	hdlr.HandleFunc("/", s.HandleRPC).Methods("POST")
 	hdlr.HandleFunc("/{authorization}", s.HandleRPC).Methods("POST")
 	c := cors.New(cors.Options{
@@ -246,6 +255,9 @@ func (s *Server) Shutdown() {
 	if s.wsServer != nil {
 		_ = s.wsServer.Shutdown(context.Background())
 	}
+	if s.wsSubscriptions != nil {
+		s.wsSubscriptions.Shutdown()
+	}
 	for _, bg := range s.BackendGroups {
 		bg.Shutdown()
 	}
@@ -255,11 +267,30 @@ func (s *Server) HandleHealthz(w http.ResponseWriter, r *http.Request) {
 	_, _ = w.Write([]byte("OK"))
 }
 
+// HandleUsage serves the usage of all API keys with a plan, to callers authenticated with the admin token.
+func (s *Server) HandleUsage(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(s.adminToken)) != 1 {
+		httpResponseCodesTotal.WithLabelValues("401").Inc()
+		w.WriteHeader(401)
+		return
+	}
+	report, err := s.usage.Report(r.Context())
+	if err != nil {
+		log.Error("error reading api key usage", "err", err)
+		w.WriteHeader(500)
+		return
+	}
+	w.Header().Set("content-type", "application/json")
+	_, _ = w.Write(mustMarshalJSON(report))
+}
+
 func (s *Server) HandleRPC(w http.ResponseWriter, r *http.Request) {
 	ctx := s.populateContext(w, r)
 	if ctx == nil {
 		return
 	}
+	ctx = context.WithValue(ctx, ContextKeyHeaders, r.Header) // nolint:staticcheck
 	var cancel context.CancelFunc
 	ctx, cancel = context.WithTimeout(ctx, s.timeout)
 	defer cancel()
@@ -418,6 +449,7 @@ func (s *Server) handleBatchRPC(ctx context.Context, reqs []json.RawMessage, isL
 	type batchGroup struct {
 		groupID      int
 		backendGroup string
+		rule         *RoutingRule
 	}
 
 	responses := make([]*RPCRes, len(reqs))
@@ -454,7 +486,7 @@ func (s *Server) handleBatchRPC(ctx context.Context, reqs []json.RawMessage, isL
 			continue
 		}
 
-		group := s.rpcMethodMappings[parsedReq.Method]
+		group, rule := s.routeRequest(ctx, parsedReq)
 		if group == "" {
 			// use unknown below to prevent DOS vector that fills up memory
 			// with arbitrary method names.
@@ -469,6 +501,21 @@ func (s *Server) handleBatchRPC(ctx context.Context, reqs []json.RawMessage, isL
 			continue
 		}
 
+		// Enforce the plan of the API key
+		if err := s.usage.Take(ctx, GetAuthCtx(ctx), parsedReq.Method); err != nil {
+			log.Info(
+				"rejected request by api key plan",
+				"source", "rpc",
+				"req_id", GetReqID(ctx),
+				"auth", GetAuthCtx(ctx),
+				"method", parsedReq.Method,
+				"err", err,
+			)
+			RecordRPCError(ctx, BackendProxyd, parsedReq.Method, err)
+			responses[i] = NewRPCErrorRes(parsedReq.ID, err)
+			continue
+		}
+
 		// Take rate limit for specific methods.
 		// NOTE: eventually, this should apply to all batch requests. However,
 		// since we don't have data right now on the size of each batch, we
@@ -500,7 +547,7 @@ func (s *Server) handleBatchRPC(ctx context.Context, reqs []json.RawMessage, isL
 		// If this is a duplicate Request ID, move the Request to a new batchGroup
 		ids[id]++
 		batchGroupID := ids[id]
-		batchGroup := batchGroup{groupID: batchGroupID, backendGroup: group}
+		batchGroup := batchGroup{groupID: batchGroupID, backendGroup: group, rule: rule}
 		batches[batchGroup] = append(batches[batchGroup], batchElem{parsedReq, i})
 	}
 
@@ -536,6 +583,9 @@ func (s *Server) handleBatchRPC(ctx context.Context, reqs []json.RawMessage, isL
 			end := int(math.Min(float64(start+s.maxUpstreamBatchSize), float64(len(cacheMisses))))
 			elems := cacheMisses[start:end]
 			res, sb, err := s.BackendGroups[group.backendGroup].Forward(ctx, createBatchRequest(elems), isBatch)
+			if err != nil && group.rule != nil {
+				res, sb, err = s.forwardFallback(ctx, group.rule, elems, isBatch, err)
+			}
 			servedBy[sb] = true
 			if err != nil {
 				if errors.Is(err, ErrConsensusGetReceiptsCantBeBatched) ||
@@ -598,6 +648,20 @@ func (s *Server) HandleWS(w http.ResponseWriter, r *http.Request) {
 	}
 	clientConn.SetReadLimit(s.maxBodySize)
 
+	if s.wsSubscriptions != nil {
+		activeClientWsConnsGauge.WithLabelValues(GetAuthCtx(ctx)).Inc()
+		// The request context is canceled once the connection is hijacked, but its values are still needed.
+		ctx := context.WithoutCancel(ctx)
+		go func() {
+			if err := s.wsSubscriptions.ServeWS(ctx, clientConn, s.wsMethodWhitelist, s.usage); err != nil {
+				log.Error("error serving websocket", "auth", GetAuthCtx(ctx), "req_id", GetReqID(ctx), "err", err)
+			}
+			activeClientWsConnsGauge.WithLabelValues(GetAuthCtx(ctx)).Dec()
+		}()
+		log.Info("accepted WS connection", "auth", GetAuthCtx(ctx), "req_id", GetReqID(ctx))
+		return
+	}
+
 	proxier, err := s.wsBackendGroup.ProxyWS(ctx, clientConn, s.wsMethodWhitelist)
 	if err != nil {
 		if errors.Is(err, ErrNoBackends) {
@@ -608,6 +672,8 @@ func (s *Server) HandleWS(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	proxier.usage = s.usage
+
 	activeClientWsConnsGauge.WithLabelValues(GetAuthCtx(ctx)).Inc()
 	go func() {
 		// Below call blocks so run it in a goroutine.
@@ -805,6 +871,52 @@ func instrumentedHdlr(h http.Handler) http.HandlerFunc {
 	}
 }
 
+// routeRequest returns the backend group to forward the request to, and the routing rule that matched it, if any.
+// Requests that don't match any routing rule are routed by their method mapping.
+func (s *Server) routeRequest(ctx context.Context, req *RPCReq) (string, *RoutingRule) {
+	if len(s.routingRules) == 0 {
+		return s.rpcMethodMappings[req.Method], nil
+	}
+	headers := GetHeaders(ctx)
+	latest := func() (uint64, bool) {
+		bg := s.BackendGroups[s.rpcMethodMappings[req.Method]]
+		if bg == nil || bg.Consensus == nil {
+			return 0, false
+		}
+		return uint64(bg.Consensus.GetLatestBlockNumber()), true
+	}
+	for _, rule := range s.routingRules {
+		if rule.Match(req, headers, latest) {
+			return rule.backendGroup, rule
+		}
+	}
+	return s.rpcMethodMappings[req.Method], nil
+}
+
+// forwardFallback forwards the requests to the fallback backend groups of the routing rule in order,
+// until one of them serves the requests.
+func (s *Server) forwardFallback(ctx context.Context, rule *RoutingRule, elems []batchElem, isBatch bool, err error) ([]*RPCRes, string, error) {
+	if errors.Is(err, ErrConsensusGetReceiptsCantBeBatched) ||
+		errors.Is(err, ErrConsensusGetReceiptsInvalidTarget) {
+		return nil, "", err
+	}
+	for _, group := range rule.fallbackBackendGroups {
+		log.Warn(
+			"falling back to backend group",
+			"backend_group", group,
+			"req_id", GetReqID(ctx),
+			"err", err,
+		)
+		var res []*RPCRes
+		var sb string
+		res, sb, err = s.BackendGroups[group].Forward(ctx, createBatchRequest(elems), isBatch)
+		if err == nil {
+			return res, sb, nil
+		}
+	}
+	return nil, "", err
+}
+
 func GetAuthCtx(ctx context.Context) string {
 	authUser, ok := ctx.Value(ContextKeyAuth).(string)
 	if !ok {
@@ -830,6 +942,14 @@ func GetXForwardedFor(ctx context.Context) string {
 	return xff
 }
 
+func GetHeaders(ctx context.Context) http.Header {
+	headers, ok := ctx.Value(ContextKeyHeaders).(http.Header)
+	if !ok {
+		return http.Header{}
+	}
+	return headers
+}
+
 type recordLenWriter struct {
 	io.Writer
 	Len int
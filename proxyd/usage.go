@@ -0,0 +1,342 @@
+package proxyd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/redis/go-redis/v9"
+)
+
+// Usage is the number of requests and compute units consumed by an API key.
+type Usage struct {
+	Requests     int64 `json:"requests"`
+	ComputeUnits int64 `json:"compute_units"`
+}
+
+// UsageStore persists the usage of API keys.
+type UsageStore interface {
+	// IncrWindow adds to the usage of the key in the current window of the given duration,
+	// and returns the usage of the window including the increment.
+	IncrWindow(ctx context.Context, key string, dur time.Duration, incr Usage) (Usage, error)
+	// Window returns the usage of the key in the current window of the given duration.
+	Window(ctx context.Context, key string, dur time.Duration) (Usage, error)
+	// IncrTotal adds to the total usage of the key.
+	IncrTotal(ctx context.Context, key string, incr Usage) error
+	// Total returns the total usage of the key.
+	Total(ctx context.Context, key string) (Usage, error)
+}
+
+type windowUsage struct {
+	truncTS int64
+	usage   Usage
+}
+
+// MemoryUsageStore is a UsageStore that keeps the usage in local memory.
+// Usage is lost on restart, and not shared between proxyd instances.
+type MemoryUsageStore struct {
+	mtx     sync.Mutex
+	windows map[string]*windowUsage
+	totals  map[string]Usage
+}
+
+func NewMemoryUsageStore() *MemoryUsageStore {
+	return &MemoryUsageStore{
+		windows: make(map[string]*windowUsage),
+		totals:  make(map[string]Usage),
+	}
+}
+
+func (m *MemoryUsageStore) window(key string, dur time.Duration) *windowUsage {
+	truncTS := truncateNow(dur)
+	windowKey := fmt.Sprintf("%s:%d", key, dur.Milliseconds())
+	w := m.windows[windowKey]
+	if w == nil || w.truncTS != truncTS {
+		w = &windowUsage{truncTS: truncTS}
+		m.windows[windowKey] = w
+	}
+	return w
+}
+
+func (m *MemoryUsageStore) IncrWindow(ctx context.Context, key string, dur time.Duration, incr Usage) (Usage, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	w := m.window(key, dur)
+	w.usage.Requests += incr.Requests
+	w.usage.ComputeUnits += incr.ComputeUnits
+	return w.usage, nil
+}
+
+func (m *MemoryUsageStore) Window(ctx context.Context, key string, dur time.Duration) (Usage, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.window(key, dur).usage, nil
+}
+
+func (m *MemoryUsageStore) IncrTotal(ctx context.Context, key string, incr Usage) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	total := m.totals[key]
+	total.Requests += incr.Requests
+	total.ComputeUnits += incr.ComputeUnits
+	m.totals[key] = total
+	return nil
+}
+
+func (m *MemoryUsageStore) Total(ctx context.Context, key string) (Usage, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.totals[key], nil
+}
+
+// RedisUsageStore is a UsageStore that keeps the usage in Redis, so it survives restarts
+// and is shared between proxyd instances. Each window is a hash that expires with the window.
+type RedisUsageStore struct {
+	r      *redis.Client
+	prefix string
+}
+
+func NewRedisUsageStore(r *redis.Client, prefix string) *RedisUsageStore {
+	return &RedisUsageStore{
+		r:      r,
+		prefix: prefix,
+	}
+}
+
+func (r *RedisUsageStore) windowKey(key string, dur time.Duration) string {
+	return fmt.Sprintf("usage:%s:%s:%d:%d", r.prefix, key, dur.Milliseconds(), truncateNow(dur))
+}
+
+func (r *RedisUsageStore) totalKey(key string) string {
+	return fmt.Sprintf("usage:%s:%s:total", r.prefix, key)
+}
+
+func (r *RedisUsageStore) incr(ctx context.Context, fullKey string, incr Usage, expiry time.Duration) (Usage, error) {
+	var requests, computeUnits *redis.IntCmd
+	_, err := r.r.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		requests = pipe.HIncrBy(ctx, fullKey, "requests", incr.Requests)
+		computeUnits = pipe.HIncrBy(ctx, fullKey, "compute_units", incr.ComputeUnits)
+		if expiry > 0 {
+			pipe.PExpire(ctx, fullKey, expiry)
+		}
+		return nil
+	})
+	if err != nil {
+		RecordRedisError("UsageIncr")
+		return Usage{}, err
+	}
+	return Usage{Requests: requests.Val(), ComputeUnits: computeUnits.Val()}, nil
+}
+
+func (r *RedisUsageStore) get(ctx context.Context, fullKey string) (Usage, error) {
+	var usage Usage
+	vals, err := r.r.HMGet(ctx, fullKey, "requests", "compute_units").Result()
+	if err != nil {
+		RecordRedisError("UsageGet")
+		return usage, err
+	}
+	for i, val := range vals {
+		s, ok := val.(string)
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return usage, err
+		}
+		if i == 0 {
+			usage.Requests = n
+		} else {
+			usage.ComputeUnits = n
+		}
+	}
+	return usage, nil
+}
+
+func (r *RedisUsageStore) IncrWindow(ctx context.Context, key string, dur time.Duration, incr Usage) (Usage, error) {
+	return r.incr(ctx, r.windowKey(key, dur), incr, dur-time.Millisecond)
+}
+
+func (r *RedisUsageStore) Window(ctx context.Context, key string, dur time.Duration) (Usage, error) {
+	return r.get(ctx, r.windowKey(key, dur))
+}
+
+func (r *RedisUsageStore) IncrTotal(ctx context.Context, key string, incr Usage) error {
+	_, err := r.incr(ctx, r.totalKey(key), incr, 0)
+	return err
+}
+
+func (r *RedisUsageStore) Total(ctx context.Context, key string) (Usage, error) {
+	return r.get(ctx, r.totalKey(key))
+}
+
+type apiKeyPlan struct {
+	name                string
+	methodWhitelist     *StringSet
+	methodBlacklist     *StringSet
+	defaultComputeUnits int64
+	computeUnits        map[string]int64
+	quotas              []APIKeyQuotaConfig
+	// windows groups the quotas by interval, so each window is incremented once per request
+	windows []quotaWindow
+}
+
+type quotaWindow struct {
+	interval time.Duration
+	quotas   []APIKeyQuotaConfig
+}
+
+func (p *apiKeyPlan) methodComputeUnits(method string) int64 {
+	if cu, ok := p.computeUnits[method]; ok {
+		return cu
+	}
+	return p.defaultComputeUnits
+}
+
+// UsageTracker enforces the plans of API keys, identified by their authentication alias,
+// and accounts for their usage. Keys without a plan are neither limited nor metered.
+type UsageTracker struct {
+	store UsageStore
+	plans map[string]*apiKeyPlan
+}
+
+func NewUsageTracker(store UsageStore, config UsageConfig) (*UsageTracker, error) {
+	plans := make(map[string]*apiKeyPlan)
+	for alias, planName := range config.Keys {
+		planCfg := config.Plans[planName]
+		if planCfg == nil {
+			return nil, fmt.Errorf("api key %s references undefined plan %s", alias, planName)
+		}
+		plan := &apiKeyPlan{
+			name:                planName,
+			methodBlacklist:     NewStringSetFromStrings(planCfg.MethodBlacklist),
+			defaultComputeUnits: 1,
+			computeUnits:        planCfg.ComputeUnits,
+			quotas:              planCfg.Quotas,
+		}
+		if len(planCfg.MethodWhitelist) > 0 {
+			plan.methodWhitelist = NewStringSetFromStrings(planCfg.MethodWhitelist)
+		}
+		if planCfg.DefaultComputeUnits > 0 {
+			plan.defaultComputeUnits = planCfg.DefaultComputeUnits
+		}
+		windows := make(map[time.Duration]int)
+		for _, quota := range planCfg.Quotas {
+			if quota.Interval <= 0 {
+				return nil, fmt.Errorf("plan %s has a quota without interval", planName)
+			}
+			interval := time.Duration(quota.Interval)
+			i, ok := windows[interval]
+			if !ok {
+				i = len(plan.windows)
+				windows[interval] = i
+				plan.windows = append(plan.windows, quotaWindow{interval: interval})
+			}
+			plan.windows[i].quotas = append(plan.windows[i].quotas, quota)
+		}
+		plans[alias] = plan
+	}
+	return &UsageTracker{
+		store: store,
+		plans: plans,
+	}, nil
+}
+
+// Take checks whether the API key may call the method, and accounts for the request against the quotas of the key.
+// A nil UsageTracker allows all requests.
+func (u *UsageTracker) Take(ctx context.Context, alias string, method string) error {
+	if u == nil {
+		return nil
+	}
+	plan := u.plans[alias]
+	if plan == nil {
+		return nil
+	}
+	if (plan.methodWhitelist != nil && !plan.methodWhitelist.Has(method)) || plan.methodBlacklist.Has(method) {
+		return ErrMethodNotWhitelisted
+	}
+
+	incr := Usage{Requests: 1, ComputeUnits: plan.methodComputeUnits(method)}
+	for i, window := range plan.windows {
+		usage, err := u.store.IncrWindow(ctx, alias, window.interval, incr)
+		if err != nil {
+			// fail open like the frontend rate limiter, an unavailable store shouldn't reject all requests,
+			// but the request isn't accounted for, so roll back the windows incremented so far
+			log.Warn("error taking api key quota", "auth", alias, "err", err)
+			u.rollback(ctx, alias, plan.windows[:i], incr)
+			return nil
+		}
+		for _, quota := range window.quotas {
+			if (quota.MaxRequests > 0 && usage.Requests > quota.MaxRequests) ||
+				(quota.MaxComputeUnits > 0 && usage.ComputeUnits > quota.MaxComputeUnits) {
+				// rejected requests don't count, so roll back the windows incremented so far
+				u.rollback(ctx, alias, plan.windows[:i+1], incr)
+				RecordAPIKeyQuotaExceeded(alias, plan.name)
+				return ErrOverQuota
+			}
+		}
+	}
+
+	if err := u.store.IncrTotal(ctx, alias, incr); err != nil {
+		log.Warn("error recording api key usage", "auth", alias, "err", err)
+	}
+	RecordAPIKeyUsage(alias, plan.name, incr.ComputeUnits)
+	return nil
+}
+
+func (u *UsageTracker) rollback(ctx context.Context, alias string, windows []quotaWindow, incr Usage) {
+	decr := Usage{Requests: -incr.Requests, ComputeUnits: -incr.ComputeUnits}
+	for _, window := range windows {
+		if _, err := u.store.IncrWindow(ctx, alias, window.interval, decr); err != nil {
+			log.Warn("error rolling back api key quota", "auth", alias, "err", err)
+		}
+	}
+}
+
+// APIKeyUsage is the usage report of an API key.
+type APIKeyUsage struct {
+	Plan   string             `json:"plan"`
+	Total  Usage              `json:"total"`
+	Quotas []APIKeyQuotaUsage `json:"quotas"`
+}
+
+// APIKeyQuotaUsage is the usage of an API key in the current window of a quota.
+type APIKeyQuotaUsage struct {
+	Interval        string `json:"interval"`
+	MaxRequests     int64  `json:"max_requests"`
+	MaxComputeUnits int64  `json:"max_compute_units"`
+	Usage
+}
+
+// Report returns the usage of all API keys with a plan, by authentication alias.
+func (u *UsageTracker) Report(ctx context.Context) (map[string]*APIKeyUsage, error) {
+	report := make(map[string]*APIKeyUsage, len(u.plans))
+	for alias, plan := range u.plans {
+		total, err := u.store.Total(ctx, alias)
+		if err != nil {
+			return nil, err
+		}
+		keyUsage := &APIKeyUsage{
+			Plan:   plan.name,
+			Total:  total,
+			Quotas: make([]APIKeyQuotaUsage, 0, len(plan.quotas)),
+		}
+		for _, quota := range plan.quotas {
+			usage, err := u.store.Window(ctx, alias, time.Duration(quota.Interval))
+			if err != nil {
+				return nil, err
+			}
+			keyUsage.Quotas = append(keyUsage.Quotas, APIKeyQuotaUsage{
+				Interval:        time.Duration(quota.Interval).String(),
+				MaxRequests:     quota.MaxRequests,
+				MaxComputeUnits: quota.MaxComputeUnits,
+				Usage:           usage,
+			})
+		}
+		report[alias] = keyUsage
+	}
+	return report, nil
+}
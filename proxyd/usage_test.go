@@ -0,0 +1,169 @@
+package proxyd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsageTracker(t *testing.T) {
+	redisServer, err := miniredis.Run()
+	require.NoError(t, err)
+	defer redisServer.Close()
+
+	redisClient := redis.NewClient(&redis.Options{
+		Addr: fmt.Sprintf("127.0.0.1:%s", redisServer.Port()),
+	})
+
+	config := UsageConfig{
+		Keys: map[string]string{
+			"partner": "basic",
+			"limited": "reads",
+		},
+		Plans: map[string]*APIKeyPlanConfig{
+			"basic": {
+				MethodBlacklist: []string{"debug_traceTransaction"},
+				ComputeUnits:    map[string]int64{"eth_getLogs": 10},
+				Quotas: []APIKeyQuotaConfig{
+					{Interval: TOMLDuration(time.Hour), MaxRequests: 5},
+					{Interval: TOMLDuration(24 * time.Hour), MaxComputeUnits: 22},
+				},
+			},
+			"reads": {
+				MethodWhitelist: []string{"eth_call"},
+			},
+		},
+	}
+
+	stores := []struct {
+		name  string
+		store UsageStore
+	}{
+		{"memory", NewMemoryUsageStore()},
+		{"redis", NewRedisUsageStore(redisClient, "")},
+	}
+
+	for _, cfg := range stores {
+		t.Run(cfg.name, func(t *testing.T) {
+			ctx := context.Background()
+			u, err := NewUsageTracker(cfg.store, config)
+			require.NoError(t, err)
+
+			// Keys without a plan are not limited
+			for i := 0; i < 10; i++ {
+				require.NoError(t, u.Take(ctx, "other", "debug_traceTransaction"))
+			}
+
+			// Method allow and deny lists
+			require.ErrorIs(t, u.Take(ctx, "partner", "debug_traceTransaction"), ErrMethodNotWhitelisted)
+			require.NoError(t, u.Take(ctx, "limited", "eth_call"))
+			require.ErrorIs(t, u.Take(ctx, "limited", "eth_getLogs"), ErrMethodNotWhitelisted)
+
+			// Compute unit quota: 2 getLogs and 2 other calls use 22 compute units
+			require.NoError(t, u.Take(ctx, "partner", "eth_getLogs"))
+			require.NoError(t, u.Take(ctx, "partner", "eth_getLogs"))
+			require.NoError(t, u.Take(ctx, "partner", "eth_call"))
+			require.NoError(t, u.Take(ctx, "partner", "eth_call"))
+			require.ErrorIs(t, u.Take(ctx, "partner", "eth_call"), ErrOverQuota)
+			// Rejected requests don't count against the quotas
+			require.ErrorIs(t, u.Take(ctx, "partner", "eth_call"), ErrOverQuota)
+
+			report, err := u.Report(ctx)
+			require.NoError(t, err)
+			require.Len(t, report, 2)
+			partner := report["partner"]
+			require.Equal(t, "basic", partner.Plan)
+			require.Equal(t, Usage{Requests: 4, ComputeUnits: 22}, partner.Total)
+			require.Equal(t, []APIKeyQuotaUsage{
+				{Interval: "1h0m0s", MaxRequests: 5, Usage: Usage{Requests: 4, ComputeUnits: 22}},
+				{Interval: "24h0m0s", MaxComputeUnits: 22, Usage: Usage{Requests: 4, ComputeUnits: 22}},
+			}, partner.Quotas)
+			require.Equal(t, Usage{Requests: 1, ComputeUnits: 1}, report["limited"].Total)
+
+			// Usage store errors fail open
+			if cfg.name == "redis" {
+				redisServer.Close()
+				require.NoError(t, u.Take(ctx, "partner", "eth_call"))
+			}
+		})
+	}
+}
+
+func TestUsageTrackerUndefinedPlan(t *testing.T) {
+	_, err := NewUsageTracker(NewMemoryUsageStore(), UsageConfig{
+		Keys: map[string]string{"partner": "missing"},
+	})
+	require.Error(t, err)
+}
+
+func TestUsageTrackerSharedInterval(t *testing.T) {
+	ctx := context.Background()
+	u, err := NewUsageTracker(NewMemoryUsageStore(), UsageConfig{
+		Keys: map[string]string{"partner": "basic"},
+		Plans: map[string]*APIKeyPlanConfig{
+			"basic": {
+				Quotas: []APIKeyQuotaConfig{
+					{Interval: TOMLDuration(time.Hour), MaxRequests: 3},
+					{Interval: TOMLDuration(time.Hour), MaxComputeUnits: 4},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	// quotas on the same interval share a window, which counts each request once
+	for i := 0; i < 3; i++ {
+		require.NoError(t, u.Take(ctx, "partner", "eth_call"))
+	}
+	require.ErrorIs(t, u.Take(ctx, "partner", "eth_call"), ErrOverQuota)
+
+	report, err := u.Report(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []APIKeyQuotaUsage{
+		{Interval: "1h0m0s", MaxRequests: 3, Usage: Usage{Requests: 3, ComputeUnits: 3}},
+		{Interval: "1h0m0s", MaxComputeUnits: 4, Usage: Usage{Requests: 3, ComputeUnits: 3}},
+	}, report["partner"].Quotas)
+}
+
+// failingUsageStore fails to increment the windows of the given interval.
+type failingUsageStore struct {
+	*MemoryUsageStore
+	failInterval time.Duration
+}
+
+func (s *failingUsageStore) IncrWindow(ctx context.Context, key string, dur time.Duration, incr Usage) (Usage, error) {
+	if dur == s.failInterval {
+		return Usage{}, errors.New("store unavailable")
+	}
+	return s.MemoryUsageStore.IncrWindow(ctx, key, dur, incr)
+}
+
+func TestUsageTrackerFailOpenRollback(t *testing.T) {
+	ctx := context.Background()
+	store := &failingUsageStore{MemoryUsageStore: NewMemoryUsageStore(), failInterval: 24 * time.Hour}
+	u, err := NewUsageTracker(store, UsageConfig{
+		Keys: map[string]string{"partner": "basic"},
+		Plans: map[string]*APIKeyPlanConfig{
+			"basic": {
+				Quotas: []APIKeyQuotaConfig{
+					{Interval: TOMLDuration(time.Hour), MaxRequests: 1},
+					{Interval: TOMLDuration(24 * time.Hour), MaxRequests: 10},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	// the store fails on the second window, so the request is allowed, and the first window is rolled back
+	require.NoError(t, u.Take(ctx, "partner", "eth_call"))
+	require.NoError(t, u.Take(ctx, "partner", "eth_call"))
+	usage, err := store.Window(ctx, "partner", time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, Usage{}, usage)
+}
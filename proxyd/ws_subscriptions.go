@@ -0,0 +1,603 @@
+package proxyd
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// number of messages buffered per client, before the client is considered too slow and disconnected
+	wsClientSendBufferSize = 256
+	// timeout for requests to the upstream backend, e.g. to subscribe
+	wsUpstreamRequestTimeout = 10 * time.Second
+)
+
+// wsBackendMessage is a response or a subscription notification of a backend.
+type wsBackendMessage struct {
+	ID     json.RawMessage        `json:"id"`
+	Method string                 `json:"method"`
+	Params *wsSubscriptionPayload `json:"params"`
+	Result json.RawMessage        `json:"result"`
+	Error  *RPCErr                `json:"error"`
+}
+
+type wsSubscriptionPayload struct {
+	Subscription string          `json:"subscription"`
+	Result       json.RawMessage `json:"result"`
+}
+
+type wsNotification struct {
+	JSONRPC string                `json:"jsonrpc"`
+	Method  string                `json:"method"`
+	Params  wsSubscriptionPayload `json:"params"`
+}
+
+// upstreamSubscription is a single backend subscription, shared by all clients subscribing with the same parameters.
+type upstreamSubscription struct {
+	key    string
+	params json.RawMessage
+	// id is the subscription ID of the backend, empty while not subscribed
+	id string
+	// clients receiving the notifications, by the subscription ID handed out to the client
+	clients map[string]*wsSubscriptionClient
+	// pending is the number of clients subscribing, that are not registered yet
+	pending int
+
+	// ready is closed once the initial subscription completed, with err set if it failed
+	ready chan struct{}
+	err   error
+}
+
+type pendingWSRequest struct {
+	res chan *RPCRes
+	// sub is set for subscription requests, to register the subscription before any notification is processed
+	sub *upstreamSubscription
+}
+
+// WSSubscriptionManager terminates eth_subscribe requests of websocket clients,
+// and shares a single upstream subscription per subscription type and filter between all clients.
+// Subscriptions are served over a single upstream connection. If the backend fails,
+// the connection fails over to the next backend of the group, and all subscriptions are resubscribed.
+type WSSubscriptionManager struct {
+	bg *BackendGroup
+
+	mu       sync.Mutex
+	backend  *Backend
+	conn     *websocket.Conn
+	subs     map[string]*upstreamSubscription
+	subsByID map[string]*upstreamSubscription
+	pending  map[string]*pendingWSRequest
+	reqID    uint64
+
+	connMu sync.Mutex // serializes writes to the upstream connection
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewWSSubscriptionManager(bg *BackendGroup) *WSSubscriptionManager {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &WSSubscriptionManager{
+		bg:       bg,
+		subs:     make(map[string]*upstreamSubscription),
+		subsByID: make(map[string]*upstreamSubscription),
+		pending:  make(map[string]*pendingWSRequest),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+func (m *WSSubscriptionManager) Start() {
+	m.wg.Add(1)
+	go m.run()
+}
+
+func (m *WSSubscriptionManager) Shutdown() {
+	m.cancel()
+	m.mu.Lock()
+	if m.conn != nil {
+		m.conn.Close()
+	}
+	m.mu.Unlock()
+	m.wg.Wait()
+}
+
+// run maintains the upstream connection, and fails over to the next backend when it breaks.
+func (m *WSSubscriptionManager) run() {
+	defer m.wg.Done()
+	for attempt := 0; ; attempt++ {
+		backend, conn := m.dial()
+		if conn == nil {
+			log.Error("no backend available for ws subscriptions", "backend_group", m.bg.Name)
+			sleepContext(m.ctx, calcBackoff(attempt))
+			if m.ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+		attempt = 0
+		activeBackendWsConnsGauge.WithLabelValues(backend.Name).Inc()
+		m.mu.Lock()
+		m.backend = backend
+		m.conn = conn
+		m.mu.Unlock()
+		log.Info("connected ws subscriptions to backend", "name", backend.Name)
+
+		go m.resubscribe()
+		err := m.readPump(backend, conn)
+
+		m.disconnect()
+		conn.Close()
+		activeBackendWsConnsGauge.WithLabelValues(backend.Name).Dec()
+		if m.ctx.Err() != nil {
+			return
+		}
+		log.Warn("lost ws subscriptions backend connection, failing over", "name", backend.Name, "err", err)
+	}
+}
+
+// dial connects to a backend of the consensus group of the backend group, or to a healthy backend
+// for groups that are not consensus-aware, in the order requests are routed to them.
+func (m *WSSubscriptionManager) dial() (*Backend, *websocket.Conn) {
+	for _, back := range m.bg.orderedBackendsForRequest() {
+		if !back.IsHealthy() {
+			log.Warn("skipping unhealthy ws backend", "name", back.Name)
+			continue
+		}
+		conn, _, err := back.dialer.DialContext(m.ctx, back.wsURL, nil) // nolint:bodyclose
+		if err != nil {
+			log.Warn("error dialing ws backend", "name", back.Name, "err", err)
+			continue
+		}
+		return back, conn
+	}
+	return nil, nil
+}
+
+// disconnect fails all pending requests, and marks all subscriptions as not subscribed.
+func (m *WSSubscriptionManager) disconnect() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.backend = nil
+	m.conn = nil
+	for id, p := range m.pending {
+		close(p.res)
+		delete(m.pending, id)
+	}
+	for id, sub := range m.subsByID {
+		sub.id = ""
+		delete(m.subsByID, id)
+	}
+	activeUpstreamWsSubscriptionsGauge.Set(0)
+}
+
+// resubscribe subscribes all established subscriptions on the current connection.
+// The clients of subscriptions that cannot be resubscribed are disconnected, so they can subscribe again.
+func (m *WSSubscriptionManager) resubscribe() {
+	m.mu.Lock()
+	subs := make([]*upstreamSubscription, 0, len(m.subs))
+	for _, sub := range m.subs {
+		select {
+		case <-sub.ready:
+			if sub.err == nil {
+				subs = append(subs, sub)
+			}
+		default: // the initial subscription is still in progress, and fails with the previous connection
+		}
+	}
+	m.mu.Unlock()
+
+	for _, sub := range subs {
+		if err := m.subscribeUpstream(sub); err != nil {
+			log.Warn("error resubscribing ws subscription", "params", sub.key, "err", err)
+			m.mu.Lock()
+			clients := make([]*wsSubscriptionClient, 0, len(sub.clients))
+			for _, client := range sub.clients {
+				clients = append(clients, client)
+			}
+			m.mu.Unlock()
+			for _, client := range clients {
+				client.conn.Close()
+			}
+		}
+	}
+}
+
+func (m *WSSubscriptionManager) readPump(backend *Backend, conn *websocket.Conn) error {
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		RecordWSMessage(m.ctx, backend.Name, SourceBackend)
+
+		var res wsBackendMessage
+		if err := json.Unmarshal(msg, &res); err != nil {
+			log.Warn("error parsing ws backend message", "name", backend.Name, "err", err)
+			continue
+		}
+		if res.Method == "eth_subscription" && res.Params != nil {
+			m.notify(res.Params)
+			continue
+		}
+		m.respond(&res)
+	}
+}
+
+func (m *WSSubscriptionManager) respond(res *wsBackendMessage) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p, ok := m.pending[string(res.ID)]
+	if !ok {
+		return
+	}
+	delete(m.pending, string(res.ID))
+	rpcRes := NewRPCRes(res.ID, res.Result)
+	if res.Error != nil {
+		rpcRes = NewRPCErrorRes(res.ID, res.Error)
+	}
+	// Register the subscription before processing any notification of it
+	var id string
+	if p.sub != nil && res.Error == nil && json.Unmarshal(res.Result, &id) == nil {
+		if m.subs[p.sub.key] == p.sub {
+			p.sub.id = id
+			m.subsByID[id] = p.sub
+			activeUpstreamWsSubscriptionsGauge.Set(float64(len(m.subsByID)))
+		} else {
+			// All clients unsubscribed in the meantime
+			go m.unsubscribeUpstream(id)
+		}
+	}
+	p.res <- rpcRes
+}
+
+func (m *WSSubscriptionManager) notify(payload *wsSubscriptionPayload) {
+	m.mu.Lock()
+	sub, ok := m.subsByID[payload.Subscription]
+	if !ok {
+		m.mu.Unlock()
+		return
+	}
+	clients := make(map[string]*wsSubscriptionClient, len(sub.clients))
+	for id, client := range sub.clients {
+		clients[id] = client
+	}
+	m.mu.Unlock()
+
+	for id, client := range clients {
+		client.send(mustMarshalJSON(&wsNotification{
+			JSONRPC: JSONRPCVersion,
+			Method:  "eth_subscription",
+			Params: wsSubscriptionPayload{
+				Subscription: id,
+				Result:       payload.Result,
+			},
+		}))
+	}
+}
+
+// request sends a request over the upstream connection, and waits for the response.
+func (m *WSSubscriptionManager) request(method string, params json.RawMessage, sub *upstreamSubscription) (*RPCRes, error) {
+	m.mu.Lock()
+	conn := m.conn
+	if conn == nil {
+		m.mu.Unlock()
+		return nil, ErrNoBackends
+	}
+	m.reqID++
+	id := strconv.FormatUint(m.reqID, 10)
+	res := make(chan *RPCRes, 1)
+	m.pending[id] = &pendingWSRequest{res: res, sub: sub}
+	backend := m.backend
+	m.mu.Unlock()
+
+	req := &RPCReq{
+		JSONRPC: JSONRPCVersion,
+		Method:  method,
+		Params:  params,
+		ID:      json.RawMessage(id),
+	}
+	RecordRPCForward(m.ctx, backend.Name, method, RPCRequestSourceWS)
+	if err := m.writeConn(conn, mustMarshalJSON(req)); err != nil {
+		m.mu.Lock()
+		delete(m.pending, id)
+		m.mu.Unlock()
+		return nil, wrapErr(err, "error writing to backend")
+	}
+
+	timer := time.NewTimer(wsUpstreamRequestTimeout)
+	defer timer.Stop()
+	select {
+	case r, ok := <-res:
+		if !ok {
+			return nil, ErrBackendOffline
+		}
+		return r, nil
+	case <-timer.C:
+		m.mu.Lock()
+		delete(m.pending, id)
+		m.mu.Unlock()
+		return nil, ErrGatewayTimeout
+	case <-m.ctx.Done():
+		return nil, m.ctx.Err()
+	}
+}
+
+func (m *WSSubscriptionManager) writeConn(conn *websocket.Conn, msg []byte) error {
+	m.connMu.Lock()
+	defer m.connMu.Unlock()
+	if err := conn.SetWriteDeadline(time.Now().Add(defaultWSWriteTimeout)); err != nil {
+		return err
+	}
+	return conn.WriteMessage(websocket.TextMessage, msg)
+}
+
+func (m *WSSubscriptionManager) subscribeUpstream(sub *upstreamSubscription) error {
+	res, err := m.request("eth_subscribe", sub.params, sub)
+	if err != nil {
+		return err
+	}
+	if res.IsError() {
+		return res.Error
+	}
+	return nil
+}
+
+func (m *WSSubscriptionManager) unsubscribeUpstream(id string) {
+	if _, err := m.request("eth_unsubscribe", mustMarshalJSON([]string{id}), nil); err != nil {
+		log.Warn("error unsubscribing ws subscription", "id", id, "err", err)
+	}
+}
+
+// subscribe returns the upstream subscription with the given parameters, and subscribes upstream if there is none yet.
+// The subscribing client doesn't receive notifications until it is registered.
+func (m *WSSubscriptionManager) subscribe(params json.RawMessage) (*upstreamSubscription, error) {
+	var key bytes.Buffer
+	if err := json.Compact(&key, params); err != nil {
+		return nil, ErrParseErr
+	}
+	m.mu.Lock()
+	sub, ok := m.subs[key.String()]
+	if !ok {
+		sub = &upstreamSubscription{
+			key:     key.String(),
+			params:  params,
+			clients: make(map[string]*wsSubscriptionClient),
+			ready:   make(chan struct{}),
+		}
+		m.subs[sub.key] = sub
+	}
+	sub.pending++
+	m.mu.Unlock()
+
+	if !ok {
+		err := m.subscribeUpstream(sub)
+		m.mu.Lock()
+		sub.err = err
+		close(sub.ready)
+		if err != nil {
+			// fail all clients that joined in the meantime
+			delete(m.subs, sub.key)
+		}
+		m.mu.Unlock()
+		if err != nil {
+			return nil, err
+		}
+		return sub, nil
+	}
+
+	<-sub.ready
+	if sub.err != nil {
+		return nil, sub.err
+	}
+	return sub, nil
+}
+
+// register adds the client subscription to the upstream subscription returned by subscribe,
+// so the client receives its notifications.
+func (m *WSSubscriptionManager) register(sub *upstreamSubscription, client *wsSubscriptionClient, clientSubID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sub.pending--
+	sub.clients[clientSubID] = client
+	activeClientWsSubscriptionsGauge.Inc()
+}
+
+// unsubscribe removes the client subscription, and unsubscribes upstream if it was the last client.
+func (m *WSSubscriptionManager) unsubscribe(key string, clientSubID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sub, ok := m.subs[key]
+	if !ok {
+		return
+	}
+	if _, ok := sub.clients[clientSubID]; !ok {
+		return
+	}
+	delete(sub.clients, clientSubID)
+	activeClientWsSubscriptionsGauge.Dec()
+	if len(sub.clients) > 0 || sub.pending > 0 {
+		return
+	}
+	delete(m.subs, key)
+	if sub.id != "" {
+		delete(m.subsByID, sub.id)
+		activeUpstreamWsSubscriptionsGauge.Set(float64(len(m.subsByID)))
+		go m.unsubscribeUpstream(sub.id)
+	}
+}
+
+// ServeWS serves the subscriptions and requests of the client connection, until the connection is closed.
+func (m *WSSubscriptionManager) ServeWS(ctx context.Context, clientConn *websocket.Conn, methodWhitelist *StringSet, usage *UsageTracker) error {
+	client := &wsSubscriptionClient{
+		m:               m,
+		conn:            clientConn,
+		methodWhitelist: methodWhitelist,
+		usage:           usage,
+		subs:            make(map[string]string),
+		sendC:           make(chan []byte, wsClientSendBufferSize),
+		done:            make(chan struct{}),
+	}
+	go client.writePump()
+	err := client.readPump(ctx)
+	close(client.done)
+	clientConn.Close()
+	for clientSubID, key := range client.subs {
+		m.unsubscribe(key, clientSubID)
+	}
+	return err
+}
+
+// wsSubscriptionClient is a websocket client connection, served by the WSSubscriptionManager.
+type wsSubscriptionClient struct {
+	m               *WSSubscriptionManager
+	conn            *websocket.Conn
+	methodWhitelist *StringSet
+	usage           *UsageTracker
+	// subs maps the subscription IDs of the client to the key of the upstream subscription.
+	// Only accessed by the read pump.
+	subs  map[string]string
+	sendC chan []byte
+	done  chan struct{}
+}
+
+// send queues the message to the client. Clients that don't keep up are disconnected.
+func (c *wsSubscriptionClient) send(msg []byte) {
+	select {
+	case c.sendC <- msg:
+	case <-c.done:
+	default:
+		log.Warn("ws client is too slow, disconnecting")
+		c.conn.Close()
+	}
+}
+
+func (c *wsSubscriptionClient) writePump() {
+	for {
+		select {
+		case msg := <-c.sendC:
+			if err := c.conn.SetWriteDeadline(time.Now().Add(defaultWSWriteTimeout)); err != nil {
+				c.conn.Close()
+				return
+			}
+			if err := c.conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+				c.conn.Close()
+				return
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
+
+func (c *wsSubscriptionClient) readPump(ctx context.Context) error {
+	for {
+		_, msg, err := c.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				return nil
+			}
+			return err
+		}
+		RecordWSMessage(ctx, BackendProxyd, SourceClient)
+		rpcRequestsTotal.Inc()
+
+		req, err := ParseRPCReq(msg)
+		if err == nil && !c.methodWhitelist.Has(req.Method) {
+			err = ErrMethodNotWhitelisted
+		}
+		if err == nil {
+			err = c.usage.Take(ctx, GetAuthCtx(ctx), req.Method)
+		}
+		if err != nil {
+			var id json.RawMessage
+			method := MethodUnknown
+			if req != nil {
+				id = req.ID
+				method = req.Method
+			}
+			log.Info(
+				"error preparing client message",
+				"auth", GetAuthCtx(ctx),
+				"req_id", GetReqID(ctx),
+				"err", err,
+			)
+			RecordRPCError(ctx, BackendProxyd, method, err)
+			c.send(mustMarshalJSON(NewRPCErrorRes(id, err)))
+			continue
+		}
+
+		if res := c.handle(ctx, req); res != nil {
+			c.send(mustMarshalJSON(res))
+		}
+	}
+}
+
+// handle serves the request, and returns the response to send to the client,
+// or nil if the response has been queued already.
+func (c *wsSubscriptionClient) handle(ctx context.Context, req *RPCReq) *RPCRes {
+	switch req.Method {
+	case "eth_accounts":
+		RecordRPCForward(ctx, BackendProxyd, "eth_accounts", RPCRequestSourceWS)
+		return NewRPCRes(req.ID, emptyArrayResponse)
+	case "eth_subscribe":
+		var params []json.RawMessage
+		if err := json.Unmarshal(req.Params, &params); err != nil || len(params) == 0 {
+			return NewRPCErrorRes(req.ID, ErrInvalidRequest("invalid subscription params"))
+		}
+		clientSubID, err := newWSSubscriptionID()
+		if err != nil {
+			return NewRPCErrorRes(req.ID, err)
+		}
+		sub, err := c.m.subscribe(req.Params)
+		if err != nil {
+			log.Info("error subscribing", "auth", GetAuthCtx(ctx), "req_id", GetReqID(ctx), "err", err)
+			RecordRPCError(ctx, BackendProxyd, req.Method, err)
+			return NewRPCErrorRes(req.ID, err)
+		}
+		// queue the subscription ID before registering the client, so it precedes all notifications
+		c.send(mustMarshalJSON(NewRPCRes(req.ID, clientSubID)))
+		c.m.register(sub, c, clientSubID)
+		c.subs[clientSubID] = sub.key
+		return nil
+	case "eth_unsubscribe":
+		var params []string
+		if err := json.Unmarshal(req.Params, &params); err != nil || len(params) != 1 {
+			return NewRPCErrorRes(req.ID, ErrInvalidRequest("invalid unsubscribe params"))
+		}
+		key, ok := c.subs[params[0]]
+		if !ok {
+			return NewRPCErrorRes(req.ID, ErrSubscriptionNotFound)
+		}
+		delete(c.subs, params[0])
+		c.m.unsubscribe(key, params[0])
+		return NewRPCRes(req.ID, true)
+	default:
+		// Other requests are not bound to the connection, and are forwarded like HTTP requests.
+		ctx, cancel := context.WithTimeout(ctx, defaultRPCTimeout)
+		defer cancel()
+		res, _, err := c.m.bg.Forward(ctx, []*RPCReq{req}, false)
+		if err != nil {
+			return NewRPCErrorRes(req.ID, err)
+		}
+		return res[0]
+	}
+}
+
+func newWSSubscriptionID() (string, error) {
+	var id [16]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return "", err
+	}
+	return "0x" + hex.EncodeToString(id[:]), nil
+}